@@ -30,9 +30,21 @@ import (
 	"github.com/albapepper/scoracle-data/internal/cache"
 	"github.com/albapepper/scoracle-data/internal/config"
 	"github.com/albapepper/scoracle-data/internal/db"
+	"github.com/albapepper/scoracle-data/internal/errreport"
+	"github.com/albapepper/scoracle-data/internal/eventsink"
+	"github.com/albapepper/scoracle-data/internal/fixture"
 	"github.com/albapepper/scoracle-data/internal/listener"
 	"github.com/albapepper/scoracle-data/internal/maintenance"
+	"github.com/albapepper/scoracle-data/internal/metrics"
+	"github.com/albapepper/scoracle-data/internal/news"
+	"github.com/albapepper/scoracle-data/internal/newswatch"
 	"github.com/albapepper/scoracle-data/internal/notifications"
+	"github.com/albapepper/scoracle-data/internal/observability"
+	"github.com/albapepper/scoracle-data/internal/provider/bdl"
+	"github.com/albapepper/scoracle-data/internal/seed"
+	"github.com/albapepper/scoracle-data/internal/statstream"
+	"github.com/albapepper/scoracle-data/internal/webhook"
+	"github.com/albapepper/scoracle-data/internal/wsnotify"
 
 	_ "github.com/albapepper/scoracle-data/docs" // swagger docs
 )
@@ -51,6 +63,8 @@ func main() {
 		os.Exit(1)
 	}
 
+	observability.Init(cfg.OTelExporterOTLPEndpoint, logger)
+
 	// Context with signal handling
 	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
 	defer cancel()
@@ -68,26 +82,101 @@ func main() {
 		"max_conns", cfg.DBPoolMaxConns)
 
 	// Initialize cache
-	appCache := cache.New(cfg.CacheEnabled)
-	logger.Info("Cache initialized", "enabled", cfg.CacheEnabled)
+	appCache := cache.New(cfg)
+	logger.Info("Cache initialized", "enabled", cfg.CacheEnabled, "backend", cfg.CacheBackend)
+
+	// Metrics registry, shared by the HTTP handlers and every background
+	// pipeline below. Served on its own admin listener rather than the
+	// public router, so scraping never competes with user traffic.
+	metricsReg := metrics.New()
+	notifications.SetMetrics(metricsReg)
+	seed.SetMetrics(metricsReg)
+	bdl.SetMetrics(metricsReg)
+	fixture.SetMetrics(metricsReg)
+	news.SetMetrics(metricsReg)
+	newswatch.SetMetrics(metricsReg)
+	maintenance.SetMetrics(metricsReg)
+	maintenance.SetCache(appCache)
+	cache.SetMetrics(metricsReg)
+	webhook.SetMetrics(metricsReg)
+
+	// Event sink — publishes milestone/percentile-change events to an
+	// external bus (Kafka/Pulsar) for downstream consumers; EVENT_SINK=none
+	// (the default) makes this a no-op.
+	sink := eventsink.New(cfg, logger)
+	notifications.SetEventSink(sink)
+	listener.SetEventSink(sink)
+	adminAddr := cfg.MetricsListen
+	if adminAddr == "" {
+		adminAddr = fmt.Sprintf("%s:%d", cfg.APIHost, cfg.AdminMetricsPort)
+	}
+	go metricsReg.ListenAndServeAdmin(adminAddr, logger)
 
-	// Start notification dispatch worker (if FCM is configured)
-	fcmSender := notifications.NewFCMSender(cfg.FCMCredentialsFile, logger)
+	// Senders are registered independently — each only if its own config is
+	// present — so e.g. running with just FCM configured doesn't block on
+	// APNs/webhook setup.
+	senders := notifications.NewSenderRegistry()
+	fcmSender := notifications.NewFCMSender(ctx, cfg.FCMCredentialsFile, pool.Pool, logger)
 	if fcmSender != nil {
-		go notifications.StartWorker(ctx, pool.Pool, fcmSender, logger)
-		logger.Info("Notification dispatch worker started")
+		senders.Register(fcmSender)
+		logger.Info("FCM sender enabled")
+	}
+	if apnsSender := notifications.NewAPNSSender(cfg.APNSKeyFile, cfg.APNSKeyID, cfg.APNSTeamID, cfg.APNSBundleID, cfg.APNSProduction, logger); apnsSender != nil {
+		senders.Register(apnsSender)
+		logger.Info("APNs sender enabled")
+	}
+	if webhookSender := notifications.NewWebhookSender(cfg.WebhookURL, logger); webhookSender != nil {
+		senders.Register(webhookSender)
+		logger.Info("Webhook sender enabled")
+	}
+
+	if !senders.Empty() {
+		dispatcher := notifications.NewDispatcher(senders, 0, 0)
+		notifications.RegisterDepthGauge(dispatcher)
+		go dispatcher.Run(ctx, pool.Pool, logger)
 	} else {
-		logger.Info("Notification dispatch worker disabled (no FIREBASE_CREDENTIALS_FILE)")
+		logger.Info("Notification dispatch worker disabled (no senders configured)")
 	}
 
-	// Start LISTEN/NOTIFY consumer for real-time milestone events
-	go listener.Start(ctx, cfg.DatabaseURL, pool.Pool, fcmSender, logger)
+	// Start LISTEN/NOTIFY bus. Subscribers register before Start so their
+	// channels are included in the initial LISTEN batch.
+	notifyBus := listener.NewBus(logger)
+	listener.RegisterMilestoneHandler(ctx, notifyBus, pool.Pool, senders, logger)
+
+	// Web push — a second, independent subscriber on the same
+	// milestone_reached channel, so browser clients with no FCM/APNs device
+	// token still get milestone notifications over WebSocket.
+	wsHub := wsnotify.NewHub(logger)
+	listener.RegisterCodec(ctx, notifyBus, listener.MilestoneChannel, wsHub.HandleMilestone)
+
+	go notifyBus.Start(ctx, cfg.DatabaseURL, logger)
+
+	// Start maintenance tickers (cleanup, digest, catch-up sweep). Leader
+	// election via maintenance_leases means running more than one replica
+	// of this process for HA doesn't double-execute this work.
+	maintenanceLeader := maintenance.NewPostgresCoordinator(pool.Pool)
+	go maintenance.Start(ctx, pool.Pool, maintenance.DefaultConfig(), maintenanceLeader, logger)
+
+	// Start the error-report digest loop: notifications/listener/maintenance
+	// call errreport.Record as transient errors occur, and this flushes an
+	// aggregated summary to maintainers every ErrorReportWindow rather than
+	// one push per failure.
+	smtpCfg := errreport.SMTPConfig{
+		Host:     cfg.SMTPHost,
+		Port:     cfg.SMTPPort,
+		Username: cfg.SMTPUsername,
+		Password: cfg.SMTPPassword,
+		From:     cfg.SMTPFrom,
+		To:       cfg.MaintainerEmail,
+	}
+	go errreport.Start(ctx, pool.Pool, fcmSender, smtpCfg, cfg.ErrorReportWindow, logger)
 
-	// Start maintenance tickers (cleanup, digest, catch-up sweep)
-	go maintenance.Start(ctx, pool.Pool, maintenance.DefaultConfig(), logger)
+	// Start stat stream listener, feeding the broker behind the SSE endpoint
+	statStream := statstream.NewBroker()
+	go statstream.Listen(ctx, cfg.DatabaseURL, statStream, logger)
 
 	// Create router
-	router := api.NewRouter(pool.Pool, appCache, cfg)
+	router := api.NewRouter(ctx, pool.Pool, appCache, cfg, statStream, metricsReg, wsHub, logger)
 
 	// Create HTTP server
 	addr := fmt.Sprintf("%s:%d", cfg.APIHost, cfg.APIPort)
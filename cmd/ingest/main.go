@@ -2,12 +2,14 @@
 //
 // Usage:
 //
-//	scoracle-ingest seed nba --season 2025
-//	scoracle-ingest seed nfl --season 2025
-//	scoracle-ingest seed football --season 2025 --league 8
+//	scoracle-ingest seed --provider balldontlie --sport NBA --season 2025
+//	scoracle-ingest seed --provider balldontlie --sport NFL --season 2025
+//	scoracle-ingest seed --provider sportmonks --sport FOOTBALL --season 2025 --league 8
 //	scoracle-ingest percentiles --sport NBA --season 2025
 //	scoracle-ingest fixtures process --sport NBA --max 10 --workers 2
 //	scoracle-ingest fixtures seed --id 42
+//	scoracle-ingest cache purge --prefix sportmonks:
+//	scoracle-ingest daemon --config scheduler.yaml
 package main
 
 import (
@@ -16,17 +18,28 @@ import (
 	"log/slog"
 	"os"
 	"os/signal"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/joho/godotenv"
 	"github.com/spf13/cobra"
 
+	"github.com/albapepper/scoracle-data/internal/cache"
 	"github.com/albapepper/scoracle-data/internal/config"
 	"github.com/albapepper/scoracle-data/internal/db"
 	"github.com/albapepper/scoracle-data/internal/fixture"
+	"github.com/albapepper/scoracle-data/internal/maintenance"
+	"github.com/albapepper/scoracle-data/internal/metrics"
+	"github.com/albapepper/scoracle-data/internal/observability"
 	"github.com/albapepper/scoracle-data/internal/provider/bdl"
-	"github.com/albapepper/scoracle-data/internal/provider/sportmonks"
+	"github.com/albapepper/scoracle-data/internal/provider/registry"
+	"github.com/albapepper/scoracle-data/internal/provider/seasonseed"
+	"github.com/albapepper/scoracle-data/internal/scheduler"
 	"github.com/albapepper/scoracle-data/internal/seed"
+	"github.com/albapepper/scoracle-data/internal/sport"
+	"github.com/albapepper/scoracle-data/internal/webhook"
 )
 
 var logger = slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo}))
@@ -43,6 +56,8 @@ func main() {
 	root.AddCommand(seedCmd())
 	root.AddCommand(percentilesCmd())
 	root.AddCommand(fixturesCmd())
+	root.AddCommand(cacheCmd())
+	root.AddCommand(daemonCmd())
 
 	if err := root.Execute(); err != nil {
 		os.Exit(1)
@@ -53,107 +68,101 @@ func main() {
 // seed command
 // --------------------------------------------------------------------------
 
-func seedCmd() *cobra.Command {
-	cmd := &cobra.Command{
-		Use:   "seed",
-		Short: "Seed data from external providers",
-	}
-	cmd.AddCommand(seedNBACmd())
-	cmd.AddCommand(seedNFLCmd())
-	cmd.AddCommand(seedFootballCmd())
-	return cmd
+// seedRunFlags are the --resume/--run-id/--dry-run flags shared by the
+// `seed` command, bound via addSeedRunFlags.
+type seedRunFlags struct {
+	resume bool
+	runID  string
+	dryRun bool
 }
 
-func seedNBACmd() *cobra.Command {
-	var season int
-	cmd := &cobra.Command{
-		Use:   "nba",
-		Short: "Seed NBA data from BallDontLie",
-		RunE: func(cmd *cobra.Command, args []string) error {
-			return runSeed(func(ctx context.Context, cfg *config.Config, pool *db.Pool) error {
-				if cfg.BDLAPIKey == "" {
-					return fmt.Errorf("BALLDONTLIE_API_KEY is required")
-				}
-				handler := bdl.NewNBAHandler(cfg.BDLAPIKey, logger)
-				start := time.Now()
-				result := seed.SeedNBA(ctx, pool.Pool, handler, season, logger)
-				logger.Info("NBA seed finished", "duration", time.Since(start).Round(time.Second), "summary", result.Summary())
-				if len(result.Errors) > 0 {
-					for _, e := range result.Errors {
-						logger.Error("seed error", "error", e)
-					}
-				}
-				return nil
-			})
-		},
-	}
-	cmd.Flags().IntVar(&season, "season", config.SportRegistry["NBA"].CurrentSeason, "Season year")
-	return cmd
+func addSeedRunFlags(cmd *cobra.Command, f *seedRunFlags) {
+	cmd.Flags().BoolVar(&f.resume, "resume", false, "Resume a previous run (by --run-id) instead of starting over")
+	cmd.Flags().StringVar(&f.runID, "run-id", "", "Seed run ID to resume or record progress under (generated if omitted)")
+	cmd.Flags().BoolVar(&f.dryRun, "dry-run", false, "Fetch from the provider but skip all database writes")
 }
 
-func seedNFLCmd() *cobra.Command {
-	var season int
+// seedCmd seeds a full season of data for one sport from one upstream
+// provider. The provider and sport are both picked at the CLI rather than
+// hardcoded per-subcommand (see internal/provider/seasonseed), so adding a
+// new vendor is a new seasonseed.Register call, not a new cobra command.
+func seedCmd() *cobra.Command {
+	var providerName, sportCode string
+	var season, leagueID int
+	var rf seedRunFlags
 	cmd := &cobra.Command{
-		Use:   "nfl",
-		Short: "Seed NFL data from BallDontLie",
+		Use:   "seed",
+		Short: "Seed a full season of data from an upstream provider",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runSeed(func(ctx context.Context, cfg *config.Config, pool *db.Pool) error {
-				if cfg.BDLAPIKey == "" {
-					return fmt.Errorf("BALLDONTLIE_API_KEY is required")
-				}
-				handler := bdl.NewNFLHandler(cfg.BDLAPIKey, logger)
-				start := time.Now()
-				result := seed.SeedNFL(ctx, pool.Pool, handler, season, logger)
-				logger.Info("NFL seed finished", "duration", time.Since(start).Round(time.Second), "summary", result.Summary())
-				if len(result.Errors) > 0 {
-					for _, e := range result.Errors {
-						logger.Error("seed error", "error", e)
-					}
-				}
-				return nil
-			})
+			sportCode = strings.ToUpper(sportCode)
+			if season == 0 {
+				season = sport.CurrentSeason(sportCode)
+			}
+			if !sport.ValidateLeagueID(sportCode, leagueID) {
+				return fmt.Errorf("unknown league id %d for sport %q", leagueID, sportCode)
+			}
+			return runProviderSeed(providerName, sportCode, season, leagueID, rf)
 		},
 	}
-	cmd.Flags().IntVar(&season, "season", config.SportRegistry["NFL"].CurrentSeason, "Season year")
+	cmd.Flags().StringVar(&providerName, "provider", "", fmt.Sprintf("Upstream provider (%s)", strings.Join(seasonseed.Providers(), ", ")))
+	cmd.Flags().StringVar(&sportCode, "sport", "", "Sport to seed (NBA, NFL, FOOTBALL)")
+	cmd.Flags().IntVar(&season, "season", 0, "Season year (defaults to the sport's current season)")
+	cmd.Flags().IntVar(&leagueID, "league", 8, "League ID, for providers whose Capabilities().NeedsLeague (8=PL, 82=BL, 301=L1, 384=SA, 564=LL)")
+	addSeedRunFlags(cmd, &rf)
+	cmd.MarkFlagRequired("provider")
+	cmd.MarkFlagRequired("sport")
 	return cmd
 }
 
-func seedFootballCmd() *cobra.Command {
-	var season, leagueID int
-	cmd := &cobra.Command{
-		Use:   "football",
-		Short: "Seed Football data from SportMonks",
-		RunE: func(cmd *cobra.Command, args []string) error {
-			return runSeed(func(ctx context.Context, cfg *config.Config, pool *db.Pool) error {
-				if cfg.SportMonksAPIToken == "" {
-					return fmt.Errorf("SPORTMONKS_API_TOKEN is required")
-				}
-				handler := sportmonks.NewFootballHandler(cfg.SportMonksAPIToken, logger)
+// runProviderSeed builds providerName's SeasonSeeder, checks that it
+// actually supports sportCode, and runs the full-season seed, handling the
+// connect/teardown ceremony shared by every ingest command. rf.resume/
+// rf.runID checkpoint progress via seed_runs (see seed.Checkpointer) so an
+// interrupted run can skip completed phases on retry instead of redoing
+// the whole thing; rf.dryRun fetches from the provider but skips every
+// database write.
+func runProviderSeed(providerName, sportCode string, season, leagueID int, rf seedRunFlags) error {
+	return runSeed(func(ctx context.Context, cfg *config.Config, pool *db.Pool) error {
+		ctx, span := observability.Start(ctx, "ingest.seed", map[string]string{
+			"provider": providerName, "sport": sportCode, "season": strconv.Itoa(season),
+		})
+		seeder, err := seasonseed.Build(providerName, cfg, logger)
+		if err != nil {
+			span.End(err)
+			return err
+		}
+		if caps := seeder.Capabilities(); !caps.Supports(sportCode) {
+			err := fmt.Errorf("provider %q does not support sport %q (supports: %s)", providerName, sportCode, strings.Join(caps.Sports, ", "))
+			span.End(err)
+			return err
+		}
 
-				// Resolve SportMonks season ID
-				smSeasonID, err := seed.ResolveProviderSeasonID(ctx, pool.Pool, leagueID, season)
-				if err != nil {
-					return fmt.Errorf("resolve season: %w", err)
-				}
-				logger.Info("Resolved provider season", "league_id", leagueID, "season", season, "sm_season_id", smSeasonID)
+		cp := seed.NewPostgresCheckpointer(pool.Pool)
+		state, err := cp.StartRun(ctx, rf.runID, sportCode, season, leagueID, rf.resume)
+		if err != nil {
+			span.End(err)
+			return fmt.Errorf("start seed run: %w", err)
+		}
+		logger.Info("seed run", "run_id", state.RunID, "provider", providerName, "resume", rf.resume, "dry_run", rf.dryRun)
 
-				start := time.Now()
-				result := seed.SeedFootballSeason(ctx, pool.Pool, handler, smSeasonID, leagueID, season, leagueID, logger)
-				logger.Info("Football seed finished",
-					"league_id", leagueID, "duration", time.Since(start).Round(time.Second),
-					"summary", result.Summary())
-				if len(result.Errors) > 0 {
-					for _, e := range result.Errors {
-						logger.Error("seed error", "error", e)
-					}
-				}
-				return nil
-			})
-		},
-	}
-	cmd.Flags().IntVar(&season, "season", config.SportRegistry["FOOTBALL"].CurrentSeason, "Season year")
-	cmd.Flags().IntVar(&leagueID, "league", 8, "League ID (8=PL, 82=BL, 301=L1, 384=SA, 564=LL)")
-	return cmd
+		start := time.Now()
+		result, err := seeder.SeedSeason(ctx, pool.Pool, sportCode, season, seasonseed.Options{
+			LeagueID:     leagueID,
+			Checkpointer: cp,
+			State:        state,
+			DryRun:       rf.dryRun,
+		})
+		_ = cp.Finish(ctx, state.RunID, err)
+		span.End(err)
+		if err != nil {
+			return err
+		}
+		logger.Info(sportCode+" seed finished", "duration", time.Since(start).Round(time.Second), "summary", result.Summary())
+		for _, e := range result.Errors {
+			logger.Error("seed error", "error", e)
+		}
+		return nil
+	})
 }
 
 // --------------------------------------------------------------------------
@@ -163,14 +172,28 @@ func seedFootballCmd() *cobra.Command {
 func percentilesCmd() *cobra.Command {
 	var sport string
 	var season int
+	var resume bool
+	var runID string
 	cmd := &cobra.Command{
 		Use:   "percentiles",
 		Short: "Recalculate percentile rankings",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runSeed(func(ctx context.Context, cfg *config.Config, pool *db.Pool) error {
-				logger.Info("Recalculating percentiles", "sport", sport, "season", season)
+				cp := seed.NewPostgresCheckpointer(pool.Pool)
+				state, err := cp.StartRun(ctx, runID, sport, season, 0, resume)
+				if err != nil {
+					return fmt.Errorf("start seed run: %w", err)
+				}
+				logger.Info("Recalculating percentiles", "sport", sport, "season", season, "run_id", state.RunID)
+
+				// recalculate_percentiles is a single atomic SQL function
+				// call, not a paginated Go loop — there's no mid-call
+				// cursor to checkpoint, so the best we can offer here is
+				// resuming the run itself if it was interrupted before
+				// this call ever completed.
 				start := time.Now()
 				players, teams, err := seed.RecalculatePercentiles(ctx, pool.Pool, sport, season)
+				_ = cp.Finish(ctx, state.RunID, err)
 				if err != nil {
 					return err
 				}
@@ -184,6 +207,8 @@ func percentilesCmd() *cobra.Command {
 	}
 	cmd.Flags().StringVar(&sport, "sport", "NBA", "Sport (NBA, NFL, FOOTBALL)")
 	cmd.Flags().IntVar(&season, "season", 2025, "Season year")
+	cmd.Flags().BoolVar(&resume, "resume", false, "Resume a previous run (by --run-id) instead of starting over")
+	cmd.Flags().StringVar(&runID, "run-id", "", "Seed run ID to resume or record progress under (generated if omitted)")
 	return cmd
 }
 
@@ -214,12 +239,12 @@ func fixturesProcessCmd() *cobra.Command {
 		Short: "Find and seed all pending fixtures",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runSeed(func(ctx context.Context, cfg *config.Config, pool *db.Pool) error {
-				deps := buildFixtureDeps(cfg)
+				reg := registry.Build(cfg, logger)
 				start := time.Now()
 				result := fixture.ProcessPending(
-					ctx, pool.Pool, deps, sport,
+					ctx, pool.Pool, reg, sport,
 					maxFixtures, maxRetries, workers,
-					!skipPercentiles, logger,
+					!skipPercentiles, logger, nil,
 				)
 				logger.Info("Fixtures process finished",
 					"duration", time.Since(start).Round(time.Second),
@@ -254,10 +279,10 @@ func fixturesSeedCmd() *cobra.Command {
 				return fmt.Errorf("--id is required")
 			}
 			return runSeed(func(ctx context.Context, cfg *config.Config, pool *db.Pool) error {
-				deps := buildFixtureDeps(cfg)
+				reg := registry.Build(cfg, logger)
 				start := time.Now()
 				result := fixture.SeedFixture(
-					ctx, pool.Pool, deps,
+					ctx, pool.Pool, reg,
 					fixtureID, !skipPercentiles, logger,
 				)
 				logger.Info("Fixture seed finished",
@@ -275,24 +300,169 @@ func fixturesSeedCmd() *cobra.Command {
 	return cmd
 }
 
-// buildFixtureDeps creates handler dependencies based on configured API keys.
-func buildFixtureDeps(cfg *config.Config) *fixture.Deps {
-	deps := &fixture.Deps{}
-	if cfg.BDLAPIKey != "" {
-		deps.NBAHandler = bdl.NewNBAHandler(cfg.BDLAPIKey, logger)
-		deps.NFLHandler = bdl.NewNFLHandler(cfg.BDLAPIKey, logger)
+// --------------------------------------------------------------------------
+// cache command
+// --------------------------------------------------------------------------
+
+func cacheCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cache",
+		Short: "Manage the shared provider response cache",
+	}
+	cmd.AddCommand(cachePurgeCmd())
+	return cmd
+}
+
+// cachePurgeCmd evicts cached provider responses by key prefix (see
+// cache.Cache.DeletePrefix and sportmonks.cacheKeyFor), forcing the next
+// seed to refetch rather than serve a stale cached body. Doesn't need
+// runSeed's DB connection — the cache itself is the only dependency.
+func cachePurgeCmd() *cobra.Command {
+	var prefix string
+	cmd := &cobra.Command{
+		Use:   "purge",
+		Short: "Evict cached provider responses by key prefix",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.Load()
+			if err != nil {
+				return fmt.Errorf("load config: %w", err)
+			}
+			c := cache.New(cfg)
+			c.DeletePrefix(prefix)
+			logger.Info("cache purged", "prefix", prefix)
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&prefix, "prefix", "sportmonks:", "Key prefix to purge (e.g. sportmonks: to clear every SportMonks response)")
+	return cmd
+}
+
+// --------------------------------------------------------------------------
+// daemon command
+// --------------------------------------------------------------------------
+
+// daemonCmd runs the built-in scheduler in-process, replacing the external
+// cron entries that otherwise drive `fixtures process`/`percentiles` on a
+// schedule. See internal/scheduler for the job registry, the cron-subset
+// evaluator, and the per-job overlap-prevention lease; registerDaemonJobs
+// below is this binary's list of schedulable cmd names.
+func daemonCmd() *cobra.Command {
+	var configPath string
+	cmd := &cobra.Command{
+		Use:   "daemon",
+		Short: "Run the built-in job scheduler (replaces external cron)",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDaemon(configPath)
+		},
+	}
+	cmd.Flags().StringVar(&configPath, "config", "scheduler.yaml", "Path to the scheduler's YAML job config")
+	return cmd
+}
+
+// runDaemon doesn't reuse runSeed: runSeed starts its own bare /metrics
+// admin listener and never hands the caller its Registry, but the daemon
+// needs that Registry to also register per-job gauges and to serve
+// /healthz alongside /metrics on the same listener.
+func runDaemon(configPath string) error {
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+
+	observability.Init(cfg.OTelExporterOTLPEndpoint, logger)
+
+	metricsReg := metrics.New()
+	seed.SetMetrics(metricsReg)
+	bdl.SetMetrics(metricsReg)
+	fixture.SetMetrics(metricsReg)
+	webhook.SetMetrics(metricsReg)
+
+	pool, err := db.New(ctx, cfg)
+	if err != nil {
+		return fmt.Errorf("connect to database: %w", err)
+	}
+	defer pool.Close()
+
+	schedCfg, err := scheduler.LoadConfig(configPath)
+	if err != nil {
+		return err
 	}
-	if cfg.SportMonksAPIToken != "" {
-		deps.FootballHandler = sportmonks.NewFootballHandler(cfg.SportMonksAPIToken, logger)
+
+	reg := scheduler.NewRegistry()
+	registerDaemonJobs(reg, cfg)
+
+	coordinator := maintenance.NewPostgresCoordinator(pool.Pool)
+	sched, err := scheduler.New(schedCfg, reg, pool.Pool, coordinator, metricsReg, logger)
+	if err != nil {
+		return fmt.Errorf("build scheduler: %w", err)
 	}
-	return deps
+
+	adminAddr := cfg.MetricsListen
+	if adminAddr == "" {
+		adminAddr = fmt.Sprintf("%s:%d", cfg.APIHost, cfg.AdminMetricsPort)
+	}
+	go sched.ListenAndServeAdmin(adminAddr)
+
+	// The retry loop runs on its own ticker (see retryLoopInterval)
+	// independent of scheduler.yaml, so failed fixture seeds get backed-off
+	// retries and eventual dead-lettering even if the operator never adds a
+	// fixtures.Retry entry to the cron config.
+	providerReg := registry.Build(cfg, logger)
+	retrier := fixture.NewRetrier()
+	go retrier.RunRetryLoop(ctx, pool.Pool, providerReg, logger)
+
+	sched.Run(ctx)
+	return nil
+}
+
+// registerDaemonJobs binds every cmd name a scheduler.yaml job can
+// reference to the existing one-shot CLI logic it wraps, so the daemon and
+// `scoracle-ingest fixtures process`/`percentiles` stay in lockstep instead
+// of accumulating separate code paths.
+func registerDaemonJobs(reg *scheduler.Registry, cfg *config.Config) {
+	reg.Register("fixtures.ProcessPending", func(ctx context.Context, pool *pgxpool.Pool, args map[string]any, logger *slog.Logger) error {
+		sportCode := scheduler.ArgString(args, "sport", "")
+		r := registry.Build(cfg, logger)
+		result := fixture.ProcessPending(
+			ctx, pool, r, sportCode,
+			scheduler.ArgInt(args, "max", 50),
+			scheduler.ArgInt(args, "max_retries", 3),
+			scheduler.ArgInt(args, "workers", 2),
+			!scheduler.ArgBool(args, "skip_percentiles", false),
+			logger, nil,
+		)
+		if len(result.Errors) > 0 {
+			return fmt.Errorf("fixtures process: %d errors (first: %s)", len(result.Errors), result.Errors[0])
+		}
+		return nil
+	})
+
+	reg.Register("percentiles.Recalculate", func(ctx context.Context, pool *pgxpool.Pool, args map[string]any, logger *slog.Logger) error {
+		sportCode := scheduler.ArgString(args, "sport", "NBA")
+		season := scheduler.ArgInt(args, "season", 0)
+		if season == 0 {
+			season = sport.CurrentSeason(sportCode)
+		}
+		players, teams, err := seed.RecalculatePercentiles(ctx, pool, sportCode, season)
+		if err != nil {
+			return err
+		}
+		logger.Info("percentiles recalculated", "sport", sportCode, "season", season, "players", players, "teams", teams)
+		return nil
+	})
 }
 
 // --------------------------------------------------------------------------
 // Shared setup
 // --------------------------------------------------------------------------
 
-// runSeed handles config loading, DB connection, and context cancellation.
+// runSeed handles config loading, DB connection, metrics wiring, and
+// context cancellation. The admin metrics listener is started here (not
+// just in cmd/api) since `fixtures process` backfills can run long enough
+// to be worth scraping.
 func runSeed(fn func(ctx context.Context, cfg *config.Config, pool *db.Pool) error) error {
 	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
 	defer cancel()
@@ -302,6 +472,19 @@ func runSeed(fn func(ctx context.Context, cfg *config.Config, pool *db.Pool) err
 		return fmt.Errorf("load config: %w", err)
 	}
 
+	observability.Init(cfg.OTelExporterOTLPEndpoint, logger)
+
+	metricsReg := metrics.New()
+	seed.SetMetrics(metricsReg)
+	bdl.SetMetrics(metricsReg)
+	fixture.SetMetrics(metricsReg)
+	webhook.SetMetrics(metricsReg)
+	adminAddr := cfg.MetricsListen
+	if adminAddr == "" {
+		adminAddr = fmt.Sprintf("%s:%d", cfg.APIHost, cfg.AdminMetricsPort)
+	}
+	go metricsReg.ListenAndServeAdmin(adminAddr, logger)
+
 	pool, err := db.New(ctx, cfg)
 	if err != nil {
 		return fmt.Errorf("connect to database: %w", err)
@@ -0,0 +1,43 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/albapepper/scoracle-data/internal/api/respond"
+)
+
+type invalidateRequest struct {
+	Prefix string `json:"prefix"`
+}
+
+// InvalidateCache evicts every cache entry whose key starts with the given
+// prefix, across every configured cache tier. Intended to be called by the
+// seeder once a batch of UpsertPlayerStats/UpsertTeamStats writes completes,
+// so the next request observes fresh data instead of waiting out the TTL.
+// @Summary Invalidate cached entries by prefix
+// @Description Evicts cache entries whose key starts with the given prefix (e.g. "stats:player:23") from every cache tier.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param body body invalidateRequest true "Prefix to invalidate"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} respond.ErrorResponse
+// @Router /admin/cache/invalidate [post]
+func (h *Handler) InvalidateCache(w http.ResponseWriter, r *http.Request) {
+	var req invalidateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respond.WriteError(w, http.StatusBadRequest, "INVALID_BODY", "Request body must be {\"prefix\": \"...\"}")
+		return
+	}
+	if req.Prefix == "" {
+		respond.WriteError(w, http.StatusBadRequest, "MISSING_PREFIX", "prefix is required")
+		return
+	}
+
+	h.cache.DeletePrefix(req.Prefix)
+
+	respond.WriteJSONObject(w, http.StatusOK, map[string]interface{}{
+		"invalidated": req.Prefix,
+	})
+}
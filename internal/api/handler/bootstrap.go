@@ -1,11 +1,13 @@
 package handler
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 
 	"github.com/albapepper/scoracle-data/internal/api/respond"
 	"github.com/albapepper/scoracle-data/internal/cache"
+	"github.com/albapepper/scoracle-data/internal/db"
 )
 
 // GetAutofillDatabase returns the entity bootstrap database for a sport.
@@ -29,22 +31,53 @@ func (h *Handler) GetAutofillDatabase(w http.ResponseWriter, r *http.Request) {
 	cacheKey := fmt.Sprintf("autofill:%s", sport)
 	ttl := cache.TTLEntityInfo
 
-	if data, etag, ok := h.cache.Get(cacheKey); ok {
+	if data, etag, fresh, ok := h.cacheGetSWR(cacheKey); ok {
 		if cache.CheckETagMatch(r.Header.Get("If-None-Match"), etag) {
+			h.incNotModified(cacheKey)
 			respond.WriteNotModified(w, etag)
 			return
 		}
-		respond.WriteJSON(w, data, etag, ttl, true)
+		respond.WriteJSON(w, r, data, etag, ttl, true)
+		if !fresh {
+			h.refreshAutofillStale(cacheKey, sport)
+		}
 		return
 	}
 
-	var raw []byte
-	err := h.pool.QueryRow(r.Context(), "autofill_entities", sport).Scan(&raw)
+	raw, err := h.coalescedQuery(cacheKey, func() ([]byte, error) {
+		var raw []byte
+		err := h.timedQuery(r.Context(), "autofill_entities", func() error {
+			return db.QueryRowAutofillEntities(r.Context(), h.pool, sport).Scan(&raw)
+		})
+		return raw, err
+	})
 	if err != nil || raw == nil {
 		respond.WriteError(w, http.StatusNotFound, "NOT_FOUND", "No entities found for "+sport)
 		return
 	}
 
-	etag := h.cache.Set(cacheKey, raw, ttl)
-	respond.WriteJSON(w, raw, etag, ttl, false)
+	etag := h.cacheSetWithStale(cacheKey, raw, ttl)
+	respond.WriteJSON(w, r, raw, etag, ttl, false)
+}
+
+// refreshAutofillStale re-runs the autofill query in the background after a
+// stale-but-servable cache entry has already answered the caller, so the
+// next request finds fresh data instead of repeating the same staleness
+// check. Goes through coalescedQuery so a burst of stale hits for the same
+// sport still issues one Postgres query rather than one per request.
+func (h *Handler) refreshAutofillStale(cacheKey, sport string) {
+	go func() {
+		ctx := context.Background()
+		raw, err := h.coalescedQuery(cacheKey, func() ([]byte, error) {
+			var raw []byte
+			err := h.timedQuery(ctx, "autofill_entities", func() error {
+				return db.QueryRowAutofillEntities(ctx, h.pool, sport).Scan(&raw)
+			})
+			return raw, err
+		})
+		if err != nil || raw == nil {
+			return
+		}
+		h.cacheSetWithStale(cacheKey, raw, cache.TTLEntityInfo)
+	}()
 }
@@ -0,0 +1,91 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/albapepper/scoracle-data/internal/api/respond"
+	"github.com/albapepper/scoracle-data/internal/fixture"
+)
+
+// fixtureStreamBuffer sizes the events channel generously relative to a
+// typical run's group/fixture count, so a slow-to-flush client doesn't make
+// ProcessPending block on publish mid-run.
+const fixtureStreamBuffer = 256
+
+// StreamFixturesProcess upgrades to text/event-stream and runs
+// fixture.ProcessPending in the background, forwarding its group_started/
+// group_completed/fixture_seeded/run_complete events to the client as named
+// SSE events as the run proceeds. The connection closes itself once
+// run_complete is sent.
+// @Summary Stream a fixture-processing run
+// @Description Server-Sent Events stream of fixture.ProcessPending progress (group_started, group_completed, fixture_seeded, run_complete).
+// @Tags admin
+// @Produce text/event-stream
+// @Param sport query string false "Filter by sport (NBA, NFL, FOOTBALL); empty = all"
+// @Param max query int false "Maximum fixtures to process (default 50)"
+// @Router /admin/fixtures/stream [get]
+func (h *Handler) StreamFixturesProcess(w http.ResponseWriter, r *http.Request) {
+	sport := r.URL.Query().Get("sport")
+
+	maxFixtures := 50
+	if m := r.URL.Query().Get("max"); m != "" {
+		n, err := strconv.Atoi(m)
+		if err != nil {
+			respond.WriteError(w, http.StatusBadRequest, "INVALID_MAX", "max must be an integer")
+			return
+		}
+		maxFixtures = n
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		respond.WriteError(w, http.StatusInternalServerError, "STREAMING_UNSUPPORTED", "Server does not support streaming responses")
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	ctx := r.Context()
+	events := make(chan fixture.Event, fixtureStreamBuffer)
+	go func() {
+		defer close(events)
+		fixture.ProcessPending(
+			ctx, h.pool, h.providerReg, sport,
+			maxFixtures, 3, 2,
+			true, h.logger, events,
+		)
+	}()
+
+	ticker := time.NewTicker(sseHeartbeatInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case ev, open := <-events:
+			if !open {
+				return
+			}
+			data, err := json.Marshal(ev)
+			if err != nil {
+				continue
+			}
+			fmt.Fprintf(w, "event: %s\ndata: %s\n\n", ev.Type, data)
+			flusher.Flush()
+			if ev.Type == fixture.EventRunComplete {
+				return
+			}
+		case <-ticker.C:
+			fmt.Fprint(w, ": heartbeat\n\n")
+			flusher.Flush()
+		}
+	}
+}
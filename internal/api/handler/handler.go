@@ -4,37 +4,91 @@
 package handler
 
 import (
+	"context"
+	"log/slog"
 	"net/http"
 	"time"
 
 	"github.com/jackc/pgx/v5/pgxpool"
+	"golang.org/x/sync/singleflight"
 
 	"github.com/albapepper/scoracle-data/internal/api/respond"
 	"github.com/albapepper/scoracle-data/internal/cache"
 	"github.com/albapepper/scoracle-data/internal/config"
+	"github.com/albapepper/scoracle-data/internal/db"
 	"github.com/albapepper/scoracle-data/internal/external"
+	"github.com/albapepper/scoracle-data/internal/httpcache"
+	"github.com/albapepper/scoracle-data/internal/metrics"
+	"github.com/albapepper/scoracle-data/internal/news"
+	"github.com/albapepper/scoracle-data/internal/newswatch"
+	"github.com/albapepper/scoracle-data/internal/provider/registry"
+	"github.com/albapepper/scoracle-data/internal/statstream"
+	"github.com/albapepper/scoracle-data/internal/wsnotify"
 )
 
 // Handler holds shared dependencies for all endpoint handlers.
 type Handler struct {
-	pool    *pgxpool.Pool
-	cache   *cache.Cache
-	cfg     *config.Config
-	news    *external.NewsService
-	twitter *external.TwitterService
+	pool        *pgxpool.Pool
+	cache       cache.Cache
+	cfg         *config.Config
+	news        *news.Service
+	twitter     *external.TwitterService
+	metrics     *metrics.Registry
+	stream      *statstream.Broker
+	providerReg *registry.Registry
+	wsHub       *wsnotify.Hub
+	poller      *newswatch.Poller
+	logger      *slog.Logger
+	sf          singleflight.Group
 }
 
-// New creates a Handler with shared dependencies.
-func New(pool *pgxpool.Pool, c *cache.Cache, cfg *config.Config) *Handler {
+// New creates a Handler with shared dependencies. reg is constructed by the
+// caller (cmd/api/main.go) since it's shared with every background
+// pipeline and served on its own admin listener, not just this handler's
+// /metrics route. stream is likewise constructed by the caller since it's
+// shared with the statstream.Listen goroutine that feeds it. wsHub is
+// likewise constructed by the caller since it's registered against the
+// listener.Bus before bus.Start — see cmd/api/main.go. c may be any
+// cache.Cache backend (memory, Redis, or tiered) — see internal/cache.New.
+// logger is used for the admin fixture-stream handler, which needs one to
+// pass through to fixture.ProcessPending same as cmd/ingest does.
+func New(pool *pgxpool.Pool, c cache.Cache, cfg *config.Config, stream *statstream.Broker, reg *metrics.Registry, wsHub *wsnotify.Hub, logger *slog.Logger) *Handler {
+	// Shared conditional-GET cache: the RSS provider and the journalist feed
+	// fetch both revalidate through it, so a repeat request for either costs
+	// a 304 instead of a full re-download once its Cache-Control window
+	// expires.
+	httpCache := httpcache.NewTransport(nil, cfg.NewsHTTPCacheMB)
+	newsService := news.NewService(cfg.NewsAPIKey, cfg.NewsFeeds, cfg.NewsRulesFile, httpCache)
+
 	return &Handler{
-		pool:    pool,
-		cache:   c,
-		cfg:     cfg,
-		news:    external.NewNewsService(cfg.NewsAPIKey),
-		twitter: external.NewTwitterService(cfg.TwitterBearerToken, cfg.TwitterListID),
+		pool:        pool,
+		cache:       c,
+		cfg:         cfg,
+		news:        newsService,
+		twitter:     external.NewTwitterService(cfg.TwitterBearerToken, cfg.TwitterListID, reg, pool, httpCache),
+		metrics:     reg,
+		stream:      stream,
+		providerReg: registry.Build(cfg, logger),
+		wsHub:       wsHub,
+		poller:      newswatch.NewPoller(pool, newsService, logger),
+		logger:      logger,
 	}
 }
 
+// Metrics returns the handler's metrics registry, so the router can mount
+// both the collector middleware and the /metrics endpoint against it.
+func (h *Handler) Metrics() *metrics.Registry {
+	return h.metrics
+}
+
+// StartBackgroundTasks kicks off goroutines that keep handler state fresh
+// ahead of requests: the journalist feed's proactive refresh, and the
+// newswatch Poller's fetch/diff/deliver loop. Runs until ctx is canceled.
+func (h *Handler) StartBackgroundTasks(ctx context.Context) {
+	h.twitter.StartBackgroundRefresh(ctx)
+	go h.poller.Run(ctx)
+}
+
 // Root serves API info at /.
 // @Summary API root info
 // @Description Returns API name, version, status, and available optimizations.
@@ -83,7 +137,9 @@ func (h *Handler) HealthCheck(w http.ResponseWriter, r *http.Request) {
 // @Router /health/db [get]
 func (h *Handler) HealthCheckDB(w http.ResponseWriter, r *http.Request) {
 	var n int
-	err := h.pool.QueryRow(r.Context(), "health_check").Scan(&n)
+	err := h.timedQuery(r.Context(), "health_check", func() error {
+		return db.QueryRowHealthCheck(r.Context(), h.pool).Scan(&n)
+	})
 	if err != nil {
 		respond.WriteJSONObject(w, http.StatusServiceUnavailable, map[string]interface{}{
 			"status":    "unhealthy",
@@ -0,0 +1,81 @@
+package handler
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+// cachePrefix extracts the leading "kind:" segment of a cache key (e.g.
+// "stats:player:123:NBA:2025:0" -> "stats"), which is what cache hit/miss/set
+// counters are labeled by.
+func cachePrefix(cacheKey string) string {
+	if i := strings.IndexByte(cacheKey, ':'); i >= 0 {
+		return cacheKey[:i]
+	}
+	return cacheKey
+}
+
+// cacheGet wraps h.cache.Get with hit/miss instrumentation, labeled by the
+// cache key's prefix (stats, seasons, stat_defs, tweets, autofill, ...).
+func (h *Handler) cacheGet(cacheKey string) (data []byte, etag string, ok bool) {
+	data, etag, ok = h.cache.Get(cacheKey)
+	labels := map[string]string{"prefix": cachePrefix(cacheKey)}
+	if ok {
+		h.metrics.IncCounter("cache_hits_total", "Cache reads that found a fresh entry.", labels)
+	} else {
+		h.metrics.IncCounter("cache_misses_total", "Cache reads that found no entry.", labels)
+	}
+	return data, etag, ok
+}
+
+// cacheSet wraps h.cache.Set with a set-counter, labeled by prefix.
+func (h *Handler) cacheSet(cacheKey string, data []byte, ttl time.Duration) string {
+	etag := h.cache.Set(cacheKey, data, ttl)
+	h.metrics.IncCounter("cache_sets_total", "Cache writes.", map[string]string{"prefix": cachePrefix(cacheKey)})
+	return etag
+}
+
+// cacheGetSWR wraps h.cache.GetSWR with hit/stale-hit/miss instrumentation,
+// labeled by the cache key's prefix. A stale hit is counted separately from
+// a fresh hit since it means a handler is about to kick off a background
+// refresh rather than trusting the entry outright.
+func (h *Handler) cacheGetSWR(cacheKey string) (data []byte, etag string, fresh, ok bool) {
+	data, etag, fresh, ok = h.cache.GetSWR(cacheKey)
+	labels := map[string]string{"prefix": cachePrefix(cacheKey)}
+	switch {
+	case !ok:
+		h.metrics.IncCounter("cache_misses_total", "Cache reads that found no entry.", labels)
+	case fresh:
+		h.metrics.IncCounter("cache_hits_total", "Cache reads that found a fresh entry.", labels)
+	default:
+		h.metrics.IncCounter("cache_stale_hits_total", "Cache reads that found a stale-but-servable entry awaiting background refresh.", labels)
+	}
+	return data, etag, fresh, ok
+}
+
+// cacheSetWithStale wraps h.cache.SetWithStale with a set-counter, labeled
+// by prefix.
+func (h *Handler) cacheSetWithStale(cacheKey string, data []byte, ttl time.Duration) string {
+	etag := h.cache.SetWithStale(cacheKey, data, ttl)
+	h.metrics.IncCounter("cache_sets_total", "Cache writes.", map[string]string{"prefix": cachePrefix(cacheKey)})
+	return etag
+}
+
+// incNotModified records a 304 returned for an If-None-Match match, labeled
+// by the cache key's prefix.
+func (h *Handler) incNotModified(cacheKey string) {
+	h.metrics.IncCounter("cache_not_modified_total", "304 responses served from a matching ETag.",
+		map[string]string{"prefix": cachePrefix(cacheKey)})
+}
+
+// timedQuery runs fn (typically a pool.QueryRow(...).Scan(...) call) and
+// records its duration in the Postgres query histogram, labeled by the
+// prepared statement/function name (e.g. "api_entity_stats").
+func (h *Handler) timedQuery(ctx context.Context, fnName string, fn func() error) error {
+	start := time.Now()
+	err := fn()
+	h.metrics.ObserveDuration("postgres_query_duration_seconds", "Postgres query latency in seconds, labeled by function name.",
+		map[string]string{"function": fnName}, start)
+	return err
+}
@@ -0,0 +1,137 @@
+package handler
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/albapepper/scoracle-data/internal/api/respond"
+	"github.com/albapepper/scoracle-data/internal/newswatch"
+)
+
+// hmacSecretBytes is the length of a generated Subscription.HMACSecret,
+// before hex encoding.
+const hmacSecretBytes = 32
+
+type createSubscriptionRequest struct {
+	EntityName         string `json:"entity_name"`
+	Sport              string `json:"sport"`
+	Team               string `json:"team,omitempty"`
+	FirstName          string `json:"first_name,omitempty"`
+	LastName           string `json:"last_name,omitempty"`
+	MinIntervalSeconds int    `json:"min_interval_seconds"`
+	DeliveryURL        string `json:"delivery_url"`
+}
+
+// createSubscriptionResponse embeds the generated HMACSecret once, at
+// creation time — see newswatch.Subscription.HMACSecret's doc comment for
+// why it's otherwise never echoed back.
+type createSubscriptionResponse struct {
+	newswatch.Subscription
+	HMACSecret string `json:"hmac_secret"`
+}
+
+// CreateNewswatchSubscription registers a standing poll for an entity's
+// news, delivered by webhook whenever the background Poller finds articles
+// it hasn't delivered before. The response includes the generated HMAC
+// secret exactly once — store it, since GET/list never returns it again.
+// @Summary Create a newswatch subscription
+// @Description Registers an entity to poll for news and push newly discovered articles to delivery_url, signed with a generated HMAC secret.
+// @Tags newswatch
+// @Accept json
+// @Produce json
+// @Param body body createSubscriptionRequest true "Subscription to create"
+// @Success 201 {object} createSubscriptionResponse
+// @Failure 400 {object} respond.ErrorResponse
+// @Router /newswatch/subscriptions [post]
+func (h *Handler) CreateNewswatchSubscription(w http.ResponseWriter, r *http.Request) {
+	var req createSubscriptionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respond.WriteError(w, http.StatusBadRequest, "INVALID_BODY", "Request body must be valid JSON")
+		return
+	}
+	if req.EntityName == "" || req.Sport == "" || req.DeliveryURL == "" {
+		respond.WriteError(w, http.StatusBadRequest, "MISSING_FIELDS", "entity_name, sport, and delivery_url are required")
+		return
+	}
+
+	secret, err := generateHMACSecret()
+	if err != nil {
+		respond.WriteError(w, http.StatusInternalServerError, "SECRET_GENERATION_FAILED", "Failed to generate delivery signing secret")
+		return
+	}
+
+	sub, err := newswatch.CreateSubscription(r.Context(), h.pool, newswatch.Subscription{
+		EntityName:         req.EntityName,
+		Sport:              req.Sport,
+		Team:               req.Team,
+		FirstName:          req.FirstName,
+		LastName:           req.LastName,
+		MinIntervalSeconds: req.MinIntervalSeconds,
+		DeliveryURL:        req.DeliveryURL,
+		HMACSecret:         secret,
+	})
+	if err != nil {
+		respond.WriteError(w, http.StatusInternalServerError, "CREATE_FAILED", "Failed to create subscription")
+		return
+	}
+
+	respond.WriteJSONObject(w, http.StatusCreated, createSubscriptionResponse{
+		Subscription: sub,
+		HMACSecret:   secret,
+	})
+}
+
+// ListNewswatchSubscriptions returns every registered subscription, newest
+// first. HMAC secrets are never included.
+// @Summary List newswatch subscriptions
+// @Description Lists every registered newswatch subscription, newest first.
+// @Tags newswatch
+// @Produce json
+// @Success 200 {array} newswatch.Subscription
+// @Router /newswatch/subscriptions [get]
+func (h *Handler) ListNewswatchSubscriptions(w http.ResponseWriter, r *http.Request) {
+	subs, err := newswatch.ListSubscriptions(r.Context(), h.pool)
+	if err != nil {
+		respond.WriteError(w, http.StatusInternalServerError, "LIST_FAILED", "Failed to list subscriptions")
+		return
+	}
+	respond.WriteJSONObject(w, http.StatusOK, map[string]interface{}{
+		"subscriptions": subs,
+	})
+}
+
+// DeleteNewswatchSubscription removes a subscription and its seen-URL
+// bookkeeping.
+// @Summary Delete a newswatch subscription
+// @Description Removes a subscription and stops polling/delivering for it.
+// @Tags newswatch
+// @Param id path int true "Subscription ID"
+// @Success 204
+// @Failure 400 {object} respond.ErrorResponse
+// @Router /newswatch/subscriptions/{id} [delete]
+func (h *Handler) DeleteNewswatchSubscription(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		respond.WriteError(w, http.StatusBadRequest, "INVALID_ID", "id must be an integer")
+		return
+	}
+	if err := newswatch.DeleteSubscription(r.Context(), h.pool, id); err != nil {
+		respond.WriteError(w, http.StatusInternalServerError, "DELETE_FAILED", "Failed to delete subscription")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func generateHMACSecret() (string, error) {
+	b := make([]byte, hmacSecretBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generate hmac secret: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,16 @@
+package handler
+
+// coalescedQuery runs fn at most once per cacheKey across concurrent
+// requests: if a request for the same key is already in flight, callers
+// instead wait for its result. Collapses a thundering herd of cold
+// cache-miss requests (e.g. right after a deploy or a cache invalidation)
+// into a single Postgres query.
+func (h *Handler) coalescedQuery(cacheKey string, fn func() ([]byte, error)) ([]byte, error) {
+	v, err, _ := h.sf.Do(cacheKey, func() (interface{}, error) {
+		return fn()
+	})
+	if err != nil || v == nil {
+		return nil, err
+	}
+	return v.([]byte), nil
+}
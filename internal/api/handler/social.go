@@ -0,0 +1,221 @@
+package handler
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/albapepper/scoracle-data/internal/api/respond"
+	"github.com/albapepper/scoracle-data/internal/external"
+)
+
+// longPollTimeout bounds how long GetTwitterStream blocks waiting for new
+// tweets before giving up with a 504, so clients (and any intermediary
+// proxy) never hang indefinitely.
+const longPollTimeout = 30 * time.Second
+
+// rssItemLimit and jsonFeedItemLimit cap how many tweets each feed format
+// includes, independent of the journalist-feed search's own limit param.
+const (
+	rssItemLimit      = 50
+	jsonFeedItemLimit = 50
+)
+
+// --------------------------------------------------------------------------
+// RSS 2.0
+// --------------------------------------------------------------------------
+
+type rssFeed struct {
+	XMLName xml.Name  `xml:"rss"`
+	Version string    `xml:"version,attr"`
+	Channel rssChanel `xml:"channel"`
+}
+
+type rssChanel struct {
+	Title       string    `xml:"title"`
+	Link        string    `xml:"link"`
+	Description string    `xml:"description"`
+	Items       []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title       string `xml:"title"`
+	Link        string `xml:"link"`
+	Author      string `xml:"author"`
+	PubDate     string `xml:"pubDate"`
+	Description string `xml:"description"`
+	GUID        string `xml:"guid"`
+}
+
+// GetTwitterRSSFeed serves the cached journalist feed as RSS 2.0.
+// @Summary Journalist feed as RSS
+// @Description Returns the cached journalist X List feed as an RSS 2.0 document. Honors the same ?query= substring filter as the journalist-feed search.
+// @Tags social
+// @Produce xml
+// @Param query query string false "Substring filter over tweet text"
+// @Router /social/twitter/feed.rss [get]
+func (h *Handler) GetTwitterRSSFeed(w http.ResponseWriter, r *http.Request) {
+	if !h.twitter.IsConfigured() {
+		respond.WriteError(w, http.StatusServiceUnavailable, "SERVICE_UNAVAILABLE",
+			"Twitter API not configured. Set TWITTER_BEARER_TOKEN and TWITTER_JOURNALIST_LIST_ID.")
+		return
+	}
+
+	tweets, err := h.twitter.Feed()
+	if err != nil {
+		respond.WriteError(w, http.StatusBadGateway, "UPSTREAM_ERROR", err.Error())
+		return
+	}
+	tweets = external.FilterTweets(tweets, r.URL.Query().Get("query"), rssItemLimit)
+
+	feed := rssFeed{
+		Version: "2.0",
+		Channel: rssChanel{
+			Title:       "Scoracle Journalist Feed",
+			Link:        "https://scoracle.app",
+			Description: "Curated sports journalist tweets, polled from an X List.",
+			Items:       make([]rssItem, len(tweets)),
+		},
+	}
+	for i, t := range tweets {
+		feed.Channel.Items[i] = rssItem{
+			Title:       fmt.Sprintf("%s (@%s)", t.Author.Name, t.Author.Username),
+			Link:        t.URL,
+			Author:      t.Author.Username,
+			PubDate:     rssPubDate(t.CreatedAt),
+			Description: t.Text,
+			GUID:        t.URL,
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/rss+xml; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte(xml.Header))
+	_ = xml.NewEncoder(w).Encode(feed)
+}
+
+// rssPubDate converts a tweet's ISO-8601 CreatedAt into RFC 1123, which is
+// what RSS 2.0's pubDate expects. Falls back to the raw value if parsing
+// fails, rather than dropping the field.
+func rssPubDate(createdAt string) string {
+	t, err := time.Parse(time.RFC3339, createdAt)
+	if err != nil {
+		return createdAt
+	}
+	return t.UTC().Format(time.RFC1123Z)
+}
+
+// --------------------------------------------------------------------------
+// JSON Feed 1.1 — https://www.jsonfeed.org/version/1.1/
+// --------------------------------------------------------------------------
+
+type jsonFeedDoc struct {
+	Version     string         `json:"version"`
+	Title       string         `json:"title"`
+	HomePageURL string         `json:"home_page_url"`
+	FeedURL     string         `json:"feed_url"`
+	Items       []jsonFeedItem `json:"items"`
+}
+
+type jsonFeedItem struct {
+	ID            string               `json:"id"`
+	URL           string               `json:"url"`
+	Title         string               `json:"title"`
+	ContentText   string               `json:"content_text"`
+	DatePublished string               `json:"date_published"`
+	Authors       []jsonFeedItemAuthor `json:"authors,omitempty"`
+}
+
+type jsonFeedItemAuthor struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+// GetTwitterJSONFeed serves the cached journalist feed as a JSON Feed 1.1
+// document.
+// @Summary Journalist feed as JSON Feed
+// @Description Returns the cached journalist X List feed as a JSON Feed 1.1 document. Honors the same ?query= substring filter as the journalist-feed search.
+// @Tags social
+// @Produce json
+// @Param query query string false "Substring filter over tweet text"
+// @Router /social/twitter/feed.json [get]
+func (h *Handler) GetTwitterJSONFeed(w http.ResponseWriter, r *http.Request) {
+	if !h.twitter.IsConfigured() {
+		respond.WriteError(w, http.StatusServiceUnavailable, "SERVICE_UNAVAILABLE",
+			"Twitter API not configured. Set TWITTER_BEARER_TOKEN and TWITTER_JOURNALIST_LIST_ID.")
+		return
+	}
+
+	tweets, err := h.twitter.Feed()
+	if err != nil {
+		respond.WriteError(w, http.StatusBadGateway, "UPSTREAM_ERROR", err.Error())
+		return
+	}
+	tweets = external.FilterTweets(tweets, r.URL.Query().Get("query"), jsonFeedItemLimit)
+
+	doc := jsonFeedDoc{
+		Version:     "https://jsonfeed.org/version/1.1",
+		Title:       "Scoracle Journalist Feed",
+		HomePageURL: "https://scoracle.app",
+		FeedURL:     "/social/twitter/feed.json",
+		Items:       make([]jsonFeedItem, len(tweets)),
+	}
+	for i, t := range tweets {
+		doc.Items[i] = jsonFeedItem{
+			ID:            t.URL,
+			URL:           t.URL,
+			Title:         fmt.Sprintf("%s (@%s)", t.Author.Name, t.Author.Username),
+			ContentText:   t.Text,
+			DatePublished: t.CreatedAt,
+			Authors: []jsonFeedItemAuthor{{
+				Name: t.Author.Name,
+				URL:  fmt.Sprintf("https://twitter.com/%s", t.Author.Username),
+			}},
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/feed+json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(doc)
+}
+
+// --------------------------------------------------------------------------
+// Long-poll stream
+// --------------------------------------------------------------------------
+
+// GetTwitterStream blocks until the journalist feed's next refresh produces
+// new tweets and returns the resulting feed, or answers 504 if
+// longPollTimeout elapses first.
+// Clients poll this in a loop, passing back the "cursor" value from the
+// previous response as ?after= to avoid missing updates between calls.
+// @Summary Long-poll the journalist feed for new tweets
+// @Description Blocks (up to 30s) until the journalist feed refreshes with new tweets, then returns the feed and a cursor to pass as ?after= on the next call. Answers 504 if no new tweets arrive in that window.
+// @Tags social
+// @Produce json
+// @Param after query string false "Cursor (latest tweet ID) from a previous response"
+// @Param query query string false "Substring filter over tweet text"
+// @Router /social/twitter/stream [get]
+func (h *Handler) GetTwitterStream(w http.ResponseWriter, r *http.Request) {
+	if !h.twitter.IsConfigured() {
+		respond.WriteError(w, http.StatusServiceUnavailable, "SERVICE_UNAVAILABLE",
+			"Twitter API not configured. Set TWITTER_BEARER_TOKEN and TWITTER_JOURNALIST_LIST_ID.")
+		return
+	}
+
+	after := r.URL.Query().Get("after")
+	tweets, err := h.twitter.WaitForNewTweets(r.Context(), after, longPollTimeout)
+	if err != nil {
+		respond.WriteError(w, http.StatusGatewayTimeout, "TIMEOUT", "No new tweets within the poll window")
+		return
+	}
+	tweets = external.FilterTweets(tweets, r.URL.Query().Get("query"), 0)
+
+	latest := h.twitter.LatestTweetID()
+
+	respond.WriteJSONObject(w, http.StatusOK, map[string]interface{}{
+		"tweets": tweets,
+		"cursor": latest,
+	})
+}
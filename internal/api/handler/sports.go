@@ -0,0 +1,37 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/albapepper/scoracle-data/internal/api/respond"
+	"github.com/albapepper/scoracle-data/internal/sport"
+)
+
+// GetSports returns the set of sports registered with internal/sport,
+// letting clients discover supported sport codes, display names, and
+// current seasons without hardcoding them.
+// @Summary List registered sports
+// @Description Returns the sports registered at build time via internal/sport, with their current season.
+// @Tags sports
+// @Produce json
+// @Success 200 {object} map[string]interface{}
+// @Router /sports [get]
+func (h *Handler) GetSports(w http.ResponseWriter, r *http.Request) {
+	codes := sport.Codes()
+	sports := make([]map[string]interface{}, 0, len(codes))
+	for _, code := range codes {
+		def, ok := sport.Lookup(code)
+		if !ok {
+			continue
+		}
+		sports = append(sports, map[string]interface{}{
+			"code":           def.Code,
+			"name":           def.Name,
+			"current_season": def.CurrentSeason,
+		})
+	}
+
+	respond.WriteJSONObject(w, http.StatusOK, map[string]interface{}{
+		"sports": sports,
+	})
+}
@@ -0,0 +1,123 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/albapepper/scoracle-data/internal/api/respond"
+	"github.com/albapepper/scoracle-data/internal/db"
+	"github.com/albapepper/scoracle-data/internal/sport"
+)
+
+// sseHeartbeatInterval bounds how long a client (or intermediary proxy) goes
+// without any bytes on the connection, to keep it from being treated as
+// idle and closed.
+const sseHeartbeatInterval = 15 * time.Second
+
+// GetEntityStatsStream upgrades to text/event-stream and pushes a fresh
+// api_entity_stats JSON payload whenever internal/seed notifies that the
+// underlying row for (entityType, id, sport, season, leagueID) changed.
+//
+// Last-Event-ID isn't backed by a persisted event log (there's nothing to
+// replay), so "resume" is best-effort: a reconnecting client is immediately
+// sent the current row, same as a fresh connection, rather than every event
+// it missed while disconnected.
+// @Summary Stream entity stat updates
+// @Description Server-Sent Events stream of api_entity_stats JSON for an entity, pushed whenever the row changes.
+// @Tags stats
+// @Produce text/event-stream
+// @Param entityType path string true "Entity type" Enums(player, team)
+// @Param entityID path int true "Entity ID"
+// @Param sport query string true "Sport identifier" Enums(NBA, NFL, FOOTBALL)
+// @Param season query int false "Season year (defaults to current)"
+// @Param league_id query int false "League ID (for FOOTBALL)"
+// @Router /stats/{entityType}/{entityID}/stream [get]
+func (h *Handler) GetEntityStatsStream(w http.ResponseWriter, r *http.Request) {
+	entityType := chi.URLParam(r, "entityType")
+	idStr := chi.URLParam(r, "entityID")
+	sport := r.URL.Query().Get("sport")
+
+	if entityType != "player" && entityType != "team" {
+		respond.WriteError(w, http.StatusBadRequest, "INVALID_TYPE", "Entity type must be 'player' or 'team'")
+		return
+	}
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		respond.WriteError(w, http.StatusBadRequest, "INVALID_ID", "ID must be an integer")
+		return
+	}
+	if sport == "" {
+		respond.WriteError(w, http.StatusBadRequest, "MISSING_SPORT", "sport query parameter is required")
+		return
+	}
+
+	season := currentSeason(sport)
+	if s := r.URL.Query().Get("season"); s != "" {
+		season, err = strconv.Atoi(s)
+		if err != nil {
+			respond.WriteError(w, http.StatusBadRequest, "INVALID_SEASON", "season must be an integer")
+			return
+		}
+	}
+	leagueID := 0
+	if lid := r.URL.Query().Get("league_id"); lid != "" {
+		leagueID, _ = strconv.Atoi(lid)
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		respond.WriteError(w, http.StatusInternalServerError, "STREAMING_UNSUPPORTED", "Server does not support streaming responses")
+		return
+	}
+
+	cacheKey := fmt.Sprintf("stats:%s:%d:%s:%d:%d", entityType, id, sport, season, leagueID)
+	changes, unsubscribe := h.stream.Subscribe(cacheKey)
+	defer unsubscribe()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	ctx := r.Context()
+	eventID := 0
+	writeStats := func() {
+		var raw []byte
+		err := h.timedQuery(ctx, "api_entity_stats", func() error {
+			return db.QueryRowApiEntityStats(ctx, h.pool, entityType, id, sport, season, leagueID).Scan(&raw)
+		})
+		if err != nil || raw == nil {
+			return
+		}
+		eventID++
+		fmt.Fprintf(w, "id: %d\ndata: %s\n\n", eventID, raw)
+		flusher.Flush()
+	}
+
+	writeStats()
+
+	ticker := time.NewTicker(sseHeartbeatInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-changes:
+			writeStats()
+		case <-ticker.C:
+			fmt.Fprint(w, ": heartbeat\n\n")
+			flusher.Flush()
+		}
+	}
+}
+
+// currentSeason returns the registered current season for sportCode, or
+// this year if the sport isn't registered.
+func currentSeason(sportCode string) int {
+	return sport.CurrentSeason(sportCode)
+}
@@ -0,0 +1,101 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/albapepper/scoracle-data/internal/api/respond"
+	"github.com/albapepper/scoracle-data/internal/cache"
+	"github.com/albapepper/scoracle-data/internal/db"
+)
+
+// tweetHistoryDefaultLimit and tweetHistoryMaxLimit bound the ?limit= param,
+// mirroring the journalist-feed search's own clamping.
+const (
+	tweetHistoryDefaultLimit = 20
+	tweetHistoryMaxLimit     = 100
+)
+
+// GetTweetHistory searches persisted journalist tweets (see
+// internal/external/tweet_persist.go) rather than the in-memory feed cache,
+// so results cover tweets that have since scrolled out of the X List.
+// @Summary Search historical journalist tweets
+// @Description Searches tweets persisted to Postgres by the journalist feed refresher. Unlike /twitter/journalist-feed, results aren't limited to what's currently in the X List.
+// @Tags social
+// @Produce json
+// @Param query query string false "Substring filter over tweet text"
+// @Param sport query string false "Sport identifier" Enums(NBA, NFL, FOOTBALL)
+// @Param since query string false "RFC3339 timestamp; only tweets created at or after this time"
+// @Param limit query int false "Max results (default 20, max 100)"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} respond.ErrorResponse
+// @Failure 404 {object} respond.ErrorResponse
+// @Router /tweets/history [get]
+func (h *Handler) GetTweetHistory(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query().Get("query")
+	sport := r.URL.Query().Get("sport")
+
+	since := time.Time{}
+	if s := r.URL.Query().Get("since"); s != "" {
+		var err error
+		since, err = time.Parse(time.RFC3339, s)
+		if err != nil {
+			respond.WriteError(w, http.StatusBadRequest, "INVALID_SINCE", "since must be an RFC3339 timestamp")
+			return
+		}
+	}
+
+	limit := tweetHistoryDefaultLimit
+	if l := r.URL.Query().Get("limit"); l != "" {
+		var err error
+		limit, err = strconv.Atoi(l)
+		if err != nil {
+			respond.WriteError(w, http.StatusBadRequest, "INVALID_LIMIT", "limit must be an integer")
+			return
+		}
+	}
+	if limit < 1 {
+		limit = tweetHistoryDefaultLimit
+	}
+	if limit > tweetHistoryMaxLimit {
+		limit = tweetHistoryMaxLimit
+	}
+
+	var sportArg, queryArg interface{}
+	if sport != "" {
+		sportArg = sport
+	}
+	if query != "" {
+		queryArg = query
+	}
+
+	cacheKey := fmt.Sprintf("tweets:%s:%s:%s:%d", query, sport, since.Format(time.RFC3339), limit)
+	ttl := cache.TTLNews
+
+	if data, etag, ok := h.cacheGet(cacheKey); ok {
+		if cache.CheckETagMatch(r.Header.Get("If-None-Match"), etag) {
+			h.incNotModified(cacheKey)
+			respond.WriteNotModified(w, etag)
+			return
+		}
+		respond.WriteJSON(w, r, data, etag, ttl, true)
+		return
+	}
+
+	raw, err := h.coalescedQuery(cacheKey, func() ([]byte, error) {
+		var raw []byte
+		err := h.timedQuery(r.Context(), "api_tweet_search", func() error {
+			return db.QueryRowApiTweetSearch(r.Context(), h.pool, queryArg, sportArg, since, limit).Scan(&raw)
+		})
+		return raw, err
+	})
+	if err != nil || raw == nil {
+		respond.WriteError(w, http.StatusNotFound, "NOT_FOUND", "No tweets found")
+		return
+	}
+
+	etag := h.cacheSet(cacheKey, raw, ttl)
+	respond.WriteJSON(w, r, raw, etag, ttl, false)
+}
@@ -0,0 +1,40 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/albapepper/scoracle-data/internal/api/respond"
+	"github.com/albapepper/scoracle-data/internal/webhook"
+)
+
+// ReplayWebhookDelivery re-sends a past delivery's original payload to its
+// subscription's current URL/secret, recording a new attempt rather than
+// mutating the original one. Useful after fixing a subscriber endpoint that
+// was down for fixture.ProcessPending's original attempts.
+// @Summary Replay a webhook delivery
+// @Description Re-sends a past delivery's payload to its subscription, recording a new delivery attempt.
+// @Tags admin
+// @Produce json
+// @Param id path int true "Delivery ID"
+// @Success 200 {object} webhook.Delivery
+// @Failure 400 {object} respond.ErrorResponse
+// @Failure 502 {object} respond.ErrorResponse
+// @Router /admin/webhooks/{id}/replay [post]
+func (h *Handler) ReplayWebhookDelivery(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		respond.WriteError(w, http.StatusBadRequest, "INVALID_ID", "id must be an integer")
+		return
+	}
+
+	replayed, err := webhook.Replay(r.Context(), h.pool, id)
+	if err != nil {
+		respond.WriteError(w, http.StatusBadGateway, "REPLAY_FAILED", "Failed to replay webhook delivery")
+		return
+	}
+
+	respond.WriteJSONObject(w, http.StatusOK, replayed)
+}
@@ -0,0 +1,28 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/albapepper/scoracle-data/internal/api/respond"
+)
+
+// StreamNotifications upgrades to a WebSocket and streams milestone_reached
+// events to the client as it subscribes to entities, for web clients that
+// have no FCM/APNs device token to register for push. Blocks for the
+// connection's lifetime; see wsnotify.Hub.ServeWS.
+// @Summary Stream milestone notifications over WebSocket
+// @Description Upgrades to WebSocket. Client sends {"entity_type","entity_id","sport"} subscribe frames; server pushes milestone_reached events matching them.
+// @Tags notifications
+// @Param user_id query string true "User ID (placeholder until internal/api has real auth)"
+// @Router /ws/notifications [get]
+func (h *Handler) StreamNotifications(w http.ResponseWriter, r *http.Request) {
+	userID := r.URL.Query().Get("user_id")
+	if userID == "" {
+		respond.WriteError(w, http.StatusBadRequest, "MISSING_USER_ID", "user_id query parameter is required")
+		return
+	}
+
+	if err := h.wsHub.ServeWS(r.Context(), w, r, userID, h.logger); err != nil {
+		h.logger.Warn("ws upgrade failed", "user_id", userID, "error", err)
+	}
+}
@@ -0,0 +1,225 @@
+package api
+
+import (
+	"container/list"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	"github.com/albapepper/scoracle-data/internal/api/respond"
+	"github.com/albapepper/scoracle-data/internal/config"
+	"github.com/albapepper/scoracle-data/internal/metrics"
+)
+
+// limiterEntry is one client IP's token bucket, tracked in ipLimiter's LRU.
+type limiterEntry struct {
+	key     string
+	limiter *rate.Limiter
+}
+
+// ipLimiter hands out a per-client-IP token bucket, bounded to maxItems —
+// without a cap, every unique IP (including one-off scanner/bot traffic)
+// allocates a *rate.Limiter that's never freed, so an in-process map grows
+// without bound for the life of the process. Evicts least-recently-used on
+// overflow, mirroring internal/httpcache.Transport's container/list LRU.
+// A background sweep additionally removes limiters sitting at a full bucket
+// (i.e. idle for at least burst/rate seconds) so a deployment with bursty-
+// then-idle clients doesn't hold onto dead weight until the LRU cap forces
+// it out.
+type ipLimiter struct {
+	mu       sync.Mutex
+	order    *list.List // front = most recently used
+	items    map[string]*list.Element
+	maxItems int
+	rate     rate.Limit
+	burst    int
+}
+
+func newIPLimiter(requestsPerWindow int, window time.Duration, maxItems int) *ipLimiter {
+	rps := float64(requestsPerWindow) / window.Seconds()
+	l := &ipLimiter{
+		order:    list.New(),
+		items:    make(map[string]*list.Element),
+		maxItems: maxItems,
+		rate:     rate.Limit(rps),
+		burst:    requestsPerWindow / 2,
+	}
+	go l.sweepLoop()
+	return l
+}
+
+func (l *ipLimiter) getLimiter(ip string) *rate.Limiter {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if el, ok := l.items[ip]; ok {
+		l.order.MoveToFront(el)
+		return el.Value.(*limiterEntry).limiter
+	}
+
+	limiter := rate.NewLimiter(l.rate, l.burst)
+	el := l.order.PushFront(&limiterEntry{key: ip, limiter: limiter})
+	l.items[ip] = el
+
+	for l.maxItems > 0 && l.order.Len() > l.maxItems {
+		oldest := l.order.Back()
+		if oldest == nil {
+			break
+		}
+		l.order.Remove(oldest)
+		delete(l.items, oldest.Value.(*limiterEntry).key)
+	}
+
+	return limiter
+}
+
+// sweepLoop periodically evicts limiters that have been idle long enough to
+// refill to a full bucket — the clearest available signal (rate.Limiter
+// exposes no last-used timestamp) that a client has gone quiet.
+func (l *ipLimiter) sweepLoop() {
+	ticker := time.NewTicker(5 * time.Minute)
+	defer ticker.Stop()
+	for range ticker.C {
+		l.sweep()
+	}
+}
+
+func (l *ipLimiter) sweep() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	for el := l.order.Back(); el != nil; {
+		prev := el.Prev()
+		entry := el.Value.(*limiterEntry)
+		if entry.limiter.TokensAt(now) >= float64(l.burst) {
+			l.order.Remove(el)
+			delete(l.items, entry.key)
+		}
+		el = prev
+	}
+}
+
+// keyLimiter is one configured API-key tier's shared token bucket — shared
+// across every caller presenting that key, unlike ipLimiter's per-IP
+// buckets, since a tier quota is a contract with one API consumer, not with
+// each address it happens to connect from.
+type keyLimiter struct {
+	limiter *rate.Limiter
+	limit   int // requests per window, for the X-RateLimit-Limit header
+}
+
+// newKeyLimiters builds one keyLimiter per configured tier. The number of
+// tiers is fixed at config load time (an operator-managed list of issued API
+// keys), so unlike ipLimiter these never need bounding or eviction.
+func newKeyLimiters(tiers map[string]config.RateLimitTier) map[string]*keyLimiter {
+	out := make(map[string]*keyLimiter, len(tiers))
+	for key, tier := range tiers {
+		burst := tier.Burst
+		if burst <= 0 {
+			burst = tier.Requests / 2
+		}
+		rps := float64(tier.Requests) / tier.Window.Seconds()
+		out[key] = &keyLimiter{
+			limiter: rate.NewLimiter(rate.Limit(rps), burst),
+			limit:   tier.Requests,
+		}
+	}
+	return out
+}
+
+// RateLimitMiddleware rate-limits each request, preferring a per-key tier
+// quota (looked up from cfg.RateLimitTiers by the X-API-Key header) over the
+// default per-IP limit. Every response carries X-RateLimit-Limit/
+// -Remaining/-Reset so a well-behaved client can back off before it ever
+// gets a 429, and a blocked request increments rate_limited_total{ip_bucket}
+// on reg.
+func RateLimitMiddleware(reg *metrics.Registry, cfg *config.Config) func(http.Handler) http.Handler {
+	ips := newIPLimiter(cfg.RateLimitRequests, cfg.RateLimitWindow, cfg.RateLimitMaxIPs)
+	keys := newKeyLimiters(cfg.RateLimitTiers)
+	defaultLimit := cfg.RateLimitRequests
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var limiter *rate.Limiter
+			limit := defaultLimit
+
+			if apiKey := r.Header.Get("X-API-Key"); apiKey != "" {
+				if kl, ok := keys[apiKey]; ok {
+					limiter = kl.limiter
+					limit = kl.limit
+				}
+			}
+
+			ip, _, _ := net.SplitHostPort(r.RemoteAddr)
+			if ip == "" {
+				ip = r.RemoteAddr
+			}
+			if limiter == nil {
+				limiter = ips.getLimiter(ip)
+			}
+
+			allowed := limiter.Allow()
+			setRateLimitHeaders(w, limiter, limit)
+
+			if !allowed {
+				reg.IncCounter("rate_limited_total", "Requests rejected by the rate limiter, bucketed by client /16.",
+					map[string]string{"ip_bucket": ipBucket(ip)})
+				w.Header().Set("Retry-After", "60")
+				respond.WriteError(w, http.StatusTooManyRequests, "RATE_LIMITED", "Too many requests")
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// setRateLimitHeaders sets X-RateLimit-Limit/-Remaining/-Reset from
+// limiter's current state, so a client sees its quota tightening well before
+// it actually gets rate-limited.
+func setRateLimitHeaders(w http.ResponseWriter, limiter *rate.Limiter, limit int) {
+	now := time.Now()
+	tokens := limiter.TokensAt(now)
+	remaining := int(math.Floor(tokens))
+	if remaining < 0 {
+		remaining = 0
+	}
+
+	var resetSeconds int
+	if rps := float64(limiter.Limit()); rps > 0 {
+		deficit := float64(limiter.Burst()) - tokens
+		if deficit > 0 {
+			resetSeconds = int(math.Ceil(deficit / rps))
+		}
+	}
+
+	w.Header().Set("X-RateLimit-Limit", strconv.Itoa(limit))
+	w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(remaining))
+	w.Header().Set("X-RateLimit-Reset", strconv.Itoa(resetSeconds))
+}
+
+// ipBucket collapses ip to its /16 for IPv4 (first two octets) or its /32
+// for IPv6 (first two hextets) so rate_limited_total stays low-cardinality regardless
+// of how many distinct client addresses get rate-limited.
+func ipBucket(ip string) string {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return "unknown"
+	}
+	if v4 := parsed.To4(); v4 != nil {
+		return fmt.Sprintf("%d.%d.0.0/16", v4[0], v4[1])
+	}
+	parts := strings.SplitN(parsed.String(), ":", 3)
+	if len(parts) < 2 {
+		return "unknown"
+	}
+	return parts[0] + ":" + parts[1] + "::/32"
+}
@@ -0,0 +1,192 @@
+// Package respond provides shared JSON response utilities for API handlers.
+package respond
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// GzipThreshold is the minimum uncompressed payload size, in bytes, at which
+// WriteJSON gzips the response body (when the client sends Accept-Encoding:
+// gzip). Below this, compression overhead isn't worth the CPU cost. A var,
+// not a const, so a deployment can tune it without a code change.
+var GzipThreshold = 1024
+
+// ErrorResponse is the legacy error shape, which WriteError emits while
+// LegacyErrors is set (the default), kept for API clients that haven't
+// migrated to RFC 7807 problem documents. See LegacyErrors.
+type ErrorResponse struct {
+	Error struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+		Detail  string `json:"detail,omitempty"`
+	} `json:"error"`
+}
+
+// LegacyErrors makes WriteError/WriteErrorDetail keep emitting the old
+// {"error":{"code","message","detail"}} shape instead of an RFC 7807
+// problem document, for one release while API clients migrate. Flip to
+// false (or delete this flag and the branch it guards) once nothing depends
+// on the legacy shape.
+var LegacyErrors = true
+
+// ProblemContentType is the media type WriteProblem serves.
+const ProblemContentType = "application/problem+json"
+
+// Problem is an RFC 7807 (application/problem+json) document. Extensions
+// holds any additional members a particular error wants to surface (e.g. a
+// machine-readable "code", or a validation "errors" list) — they're
+// flattened into the same top-level JSON object as the standard
+// type/title/status/detail/instance members.
+type Problem struct {
+	Type       string
+	Title      string
+	Status     int
+	Detail     string
+	Instance   string
+	Extensions map[string]interface{}
+}
+
+// MarshalJSON flattens Extensions alongside the standard RFC 7807 members
+// into one JSON object, omitting any standard member left at its zero value.
+func (p Problem) MarshalJSON() ([]byte, error) {
+	m := make(map[string]interface{}, len(p.Extensions)+5)
+	for k, v := range p.Extensions {
+		m[k] = v
+	}
+	if p.Type != "" {
+		m["type"] = p.Type
+	}
+	if p.Title != "" {
+		m["title"] = p.Title
+	}
+	if p.Status != 0 {
+		m["status"] = p.Status
+	}
+	if p.Detail != "" {
+		m["detail"] = p.Detail
+	}
+	if p.Instance != "" {
+		m["instance"] = p.Instance
+	}
+	return json.Marshal(m)
+}
+
+// WriteProblem writes problem as an RFC 7807 application/problem+json
+// document with the given HTTP status, filling in problem.Status if the
+// caller left it unset.
+func WriteProblem(w http.ResponseWriter, status int, problem Problem) {
+	if problem.Status == 0 {
+		problem.Status = status
+	}
+	body, err := json.Marshal(problem)
+	if err != nil {
+		body = []byte(fmt.Sprintf(`{"status":%d,"title":"failed to marshal problem document"}`, status))
+	}
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.Header().Set("Cache-Control", "no-cache, no-store, must-revalidate")
+	w.WriteHeader(status)
+	w.Write(body)
+}
+
+// WriteJSON writes raw JSON bytes to the response with cache and ETag
+// headers, gzipping the body when it's at least GzipThreshold bytes and r's
+// Accept-Encoding allows it. etag is computed by the caller over the
+// uncompressed data, so If-None-Match still matches regardless of whether
+// this particular response was compressed.
+func WriteJSON(w http.ResponseWriter, r *http.Request, data []byte, etag string, ttl time.Duration, cacheHit bool) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", etag)
+	setCacheHeaders(w, ttl, cacheHit)
+
+	vary := "Accept-Encoding"
+	if len(data) >= GzipThreshold && acceptsGzip(r) {
+		vary += ", gzip"
+		w.Header().Set("Vary", vary)
+		w.Header().Set("Content-Encoding", "gzip")
+		w.WriteHeader(http.StatusOK)
+		gz := gzip.NewWriter(w)
+		defer gz.Close()
+		gz.Write(data)
+		return
+	}
+
+	w.Header().Set("Vary", vary)
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// acceptsGzip reports whether r's Accept-Encoding header lists gzip.
+func acceptsGzip(r *http.Request) bool {
+	if r == nil {
+		return false
+	}
+	for _, enc := range strings.Split(r.Header.Get("Accept-Encoding"), ",") {
+		if strings.TrimSpace(enc) == "gzip" {
+			return true
+		}
+	}
+	return false
+}
+
+// WriteNotModified sends a 304 with the matching ETag.
+func WriteNotModified(w http.ResponseWriter, etag string) {
+	w.Header().Set("ETag", etag)
+	w.WriteHeader(http.StatusNotModified)
+}
+
+// WriteError sends a structured error response for code/message, with no
+// extra detail. See WriteErrorDetail.
+func WriteError(w http.ResponseWriter, status int, code, message string) {
+	WriteErrorDetail(w, status, code, message, "")
+}
+
+// WriteErrorDetail sends a structured error response. While LegacyErrors is
+// set (the default) this is the old {"error":{...}} shape; with it cleared,
+// it is an RFC 7807 problem document (code surfaces as an extension member
+// so existing machine consumers of "code" keep working).
+func WriteErrorDetail(w http.ResponseWriter, status int, code, message, detail string) {
+	if LegacyErrors {
+		resp := ErrorResponse{}
+		resp.Error.Code = code
+		resp.Error.Message = message
+		resp.Error.Detail = detail
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-cache, no-store, must-revalidate")
+		w.WriteHeader(status)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+
+	WriteProblem(w, status, Problem{
+		Title:  message,
+		Detail: detail,
+		Extensions: map[string]interface{}{
+			"code": code,
+		},
+	})
+}
+
+// WriteJSONObject marshals a Go value to JSON and writes it.
+// Used for non-Postgres responses (health checks, news, twitter).
+func WriteJSONObject(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func setCacheHeaders(w http.ResponseWriter, ttl time.Duration, cacheHit bool) {
+	maxAge := int(ttl.Seconds())
+	swr := maxAge / 2
+	if cacheHit {
+		w.Header().Set("X-Cache", "HIT")
+	} else {
+		w.Header().Set("X-Cache", "MISS")
+	}
+	w.Header().Set("Cache-Control",
+		fmt.Sprintf("public, max-age=%d, stale-while-revalidate=%d", maxAge, swr))
+}
@@ -1,6 +1,9 @@
 package api
 
 import (
+	"context"
+	"log/slog"
+
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -10,16 +13,28 @@ import (
 	"github.com/albapepper/scoracle-data/internal/api/handler"
 	"github.com/albapepper/scoracle-data/internal/cache"
 	"github.com/albapepper/scoracle-data/internal/config"
+	"github.com/albapepper/scoracle-data/internal/metrics"
+	"github.com/albapepper/scoracle-data/internal/statstream"
+	"github.com/albapepper/scoracle-data/internal/wsnotify"
 )
 
-// NewRouter creates and configures the Chi router with all middleware and routes.
-func NewRouter(pool *pgxpool.Pool, appCache *cache.Cache, cfg *config.Config) *chi.Mux {
+// NewRouter creates and configures the Chi router with all middleware and
+// routes. ctx bounds the lifetime of background tasks started for handler
+// dependencies (e.g. the journalist feed's proactive refresh) — callers
+// should pass the same context they use for graceful shutdown. stream is
+// the broker fed by the caller's statstream.Listen goroutine; reg is the
+// process-wide metrics registry (served on its own admin listener by the
+// caller, not mounted here); pass the same instances to both. wsHub is the
+// caller's wsnotify.Hub, already registered against the listener.Bus.
+// logger is passed through to the handler for the admin fixture-stream
+// endpoint.
+func NewRouter(ctx context.Context, pool *pgxpool.Pool, appCache cache.Cache, cfg *config.Config, stream *statstream.Broker, reg *metrics.Registry, wsHub *wsnotify.Hub, logger *slog.Logger) *chi.Mux {
 	r := chi.NewRouter()
 
 	// --- Middleware stack ---
 	r.Use(middleware.RequestID)
 	r.Use(middleware.RealIP)
-	r.Use(TimingMiddleware)
+	r.Use(TracingMiddleware(reg))
 	r.Use(middleware.Compress(5)) // gzip
 
 	// CORS
@@ -34,17 +49,46 @@ func NewRouter(pool *pgxpool.Pool, appCache *cache.Cache, cfg *config.Config) *c
 
 	// Rate limiting
 	if cfg.RateLimitEnabled {
-		r.Use(RateLimitMiddleware(cfg.RateLimitRequests, cfg.RateLimitWindow))
+		r.Use(RateLimitMiddleware(reg, cfg))
 	}
 
 	// --- Handler dependencies ---
-	h := handler.New(pool, appCache, cfg)
+	h := handler.New(pool, appCache, cfg, stream, reg, wsHub, logger)
+
+	// Request/latency instrumentation — registered after chi's route
+	// matching so route patterns (not raw paths) are used as labels.
+	r.Use(h.Metrics().Middleware)
+	h.Metrics().RegisterGaugeFunc("db_pool_acquire_wait_seconds",
+		"Cumulative time spent waiting to acquire a connection from the pgx pool, in seconds.",
+		func() float64 { return pool.Stat().AcquireDuration().Seconds() })
+	h.Metrics().RegisterGaugeFunc("cache_entries",
+		"Keys currently held by the response cache backend.",
+		func() float64 { return cache.EntryCount(appCache) })
+
+	h.StartBackgroundTasks(ctx)
 
 	// --- Routes ---
 
 	// Root
 	r.Get("/", h.Root)
 
+	// Prometheus metrics are normally served on their own admin listener
+	// (see cmd/api/main.go's metricsReg.ListenAndServeAdmin) so scraping
+	// doesn't compete with public traffic. cfg.MetricsEnabled additionally
+	// mounts /metrics here, for deployments that scrape the public port
+	// instead of standing up the admin listener's network path.
+	if cfg.MetricsEnabled {
+		r.Get("/metrics", reg.Handler().ServeHTTP)
+	}
+
+	// Admin — invalidation hook for the seeder, not exposed publicly; deploy
+	// behind network-level restrictions (VPC/internal ingress only).
+	r.Route("/admin", func(r chi.Router) {
+		r.Post("/cache/invalidate", h.InvalidateCache)
+		r.Get("/fixtures/stream", h.StreamFixturesProcess)
+		r.Post("/webhooks/{id}/replay", h.ReplayWebhookDelivery)
+	})
+
 	// Health checks
 	r.Route("/health", func(r chi.Router) {
 		r.Get("/", h.HealthCheck)
@@ -70,6 +114,9 @@ func NewRouter(pool *pgxpool.Pool, appCache *cache.Cache, cfg *config.Config) *c
 
 	// API v1 routes
 	r.Route("/api/v1", func(r chi.Router) {
+		// Sports registry
+		r.Get("/sports", h.GetSports)
+
 		// Profiles
 		r.Get("/profile/{entityType}/{entityID}", h.GetProfile)
 
@@ -77,6 +124,7 @@ func NewRouter(pool *pgxpool.Pool, appCache *cache.Cache, cfg *config.Config) *c
 		r.Get("/stats/definitions", h.GetStatDefinitions)
 		r.Get("/stats/{entityType}/{entityID}", h.GetEntityStats)
 		r.Get("/stats/{entityType}/{entityID}/seasons", h.GetAvailableSeasons)
+		r.Get("/stats/{entityType}/{entityID}/stream", h.GetEntityStatsStream)
 
 		// Bootstrap / autofill
 		r.Get("/autofill_databases", h.GetAutofillDatabase)
@@ -88,6 +136,31 @@ func NewRouter(pool *pgxpool.Pool, appCache *cache.Cache, cfg *config.Config) *c
 		// Twitter
 		r.Get("/twitter/journalist-feed", h.GetJournalistFeed)
 		r.Get("/twitter/status", h.GetTwitterStatus)
+
+		// Tweets — historical search over persisted journalist tweets
+		r.Get("/tweets/history", h.GetTweetHistory)
+
+		// WebSocket push — milestone notifications for web clients with no
+		// FCM/APNs device token.
+		r.Get("/ws/notifications", h.StreamNotifications)
+	})
+
+	// Social feeds — RSS/JSON Feed/long-poll views over the journalist feed,
+	// for feed readers and services that want to subscribe rather than poll
+	// /api/v1/twitter/journalist-feed themselves.
+	r.Route("/social/twitter", func(r chi.Router) {
+		r.Get("/feed.rss", h.GetTwitterRSSFeed)
+		r.Get("/feed.json", h.GetTwitterJSONFeed)
+		r.Get("/stream", h.GetTwitterStream)
+	})
+
+	// Newswatch — standing subscriptions the background Poller (started in
+	// cmd/api/main.go alongside the other background tasks) fetches news
+	// for and pushes to a webhook. See internal/newswatch.
+	r.Route("/newswatch/subscriptions", func(r chi.Router) {
+		r.Post("/", h.CreateNewswatchSubscription)
+		r.Get("/", h.ListNewswatchSubscriptions)
+		r.Delete("/{id}", h.DeleteNewswatchSubscription)
 	})
 
 	return r
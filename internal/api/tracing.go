@@ -0,0 +1,53 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	chimw "github.com/go-chi/chi/v5/middleware"
+
+	"github.com/albapepper/scoracle-data/internal/metrics"
+	"github.com/albapepper/scoracle-data/internal/observability"
+)
+
+// TracingMiddleware wraps every request in an observability span and records
+// http_server_duration_seconds{route,status} on reg, keyed off chi's matched
+// route pattern (not the raw path, so templated routes like
+// /stats/{entityType}/{entityID} don't explode into one series per ID).
+// Registered before chi's route matching completes is fine — routePattern
+// falls back to the raw path if no route has matched yet (404s).
+func TracingMiddleware(reg *metrics.Registry) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx, span := observability.Start(r.Context(), "http.request", map[string]string{
+				"http.method": r.Method,
+				"http.path":   r.URL.Path,
+			})
+			ww := chimw.NewWrapResponseWriter(w, r.ProtoMajor)
+
+			next.ServeHTTP(ww, r.WithContext(ctx))
+
+			route := routePattern(r)
+			status := strconv.Itoa(ww.Status())
+			span.SetAttribute("http.route", route)
+			span.SetAttribute("http.status_code", status)
+			span.End(nil)
+
+			reg.ObserveDuration("http_server_duration_seconds",
+				"HTTP server request latency in seconds, labeled by route and status.",
+				map[string]string{"route": route, "status": status}, span.Start())
+		})
+	}
+}
+
+// routePattern returns the matched chi route pattern, falling back to the
+// raw path when no route has matched yet.
+func routePattern(r *http.Request) string {
+	if rctx := chi.RouteContext(r.Context()); rctx != nil {
+		if pattern := rctx.RoutePattern(); pattern != "" {
+			return pattern
+		}
+	}
+	return r.URL.Path
+}
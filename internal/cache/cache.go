@@ -0,0 +1,257 @@
+// Package cache provides the API's response cache. Cache is the interface
+// every backend (and the tiered wrapper) implements; handlers depend only on
+// this interface, never on a concrete backend, so the backend is a pure
+// config-time choice (see New).
+package cache
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// TTL constants matching the Python implementation.
+const (
+	TTLEntityInfo    = 24 * time.Hour // Profiles, bootstrap — rarely change
+	TTLCurrentSeason = 1 * time.Hour  // Current season stats
+	TTLHistorical    = 24 * time.Hour // Historical season stats
+	TTLNews          = 10 * time.Minute
+)
+
+// Cache is a thread-safe response cache with ETag support. Keys are
+// "kind:param1:param2..." strings (see individual handlers); DeletePrefix
+// takes the same shape with or without a trailing "*".
+type Cache interface {
+	// Get retrieves a cached value. ok is false on miss or expiry.
+	Get(key string) (data []byte, etag string, ok bool)
+	// Set stores a value with a TTL and returns its ETag.
+	Set(key string, data []byte, ttl time.Duration) string
+	// GetSWR retrieves a value that may be past its fresh TTL but still
+	// within its stale-while-revalidate grace window (see SetWithStale).
+	// ok is false on a true miss; fresh is only meaningful when ok is true,
+	// and distinguishes a value a caller can serve as-is from one it should
+	// serve while kicking off a background refresh.
+	GetSWR(key string) (data []byte, etag string, fresh, ok bool)
+	// SetWithStale stores a value that's fresh for ttl and then, instead of
+	// disappearing outright, stays servable-but-stale for an additional
+	// grace window — ttl/2, the same ratio respond.WriteJSON already
+	// advertises via its Cache-Control: stale-while-revalidate header, so
+	// the origin's own behavior matches what it tells downstream caches.
+	SetWithStale(key string, data []byte, ttl time.Duration) string
+	// Delete evicts a single key.
+	Delete(key string)
+	// DeletePrefix evicts every key starting with prefix (trailing "*" is
+	// stripped if present, so "stats:player:23:*" and "stats:player:23" are
+	// equivalent).
+	DeletePrefix(prefix string)
+	// Stats returns backend-specific diagnostics for /health/cache.
+	Stats() map[string]interface{}
+}
+
+type memEntry struct {
+	data      []byte
+	etag      string
+	expiresAt time.Time
+	// staleUntil is set only by SetWithStale — the grace window past
+	// expiresAt during which GetSWR still serves the entry, flagged stale.
+	// Zero for entries written by the plain Set, which has no stale window.
+	staleUntil time.Time
+}
+
+// MemoryCache is an in-memory TTL cache. It's always the L1 tier — even when
+// a Redis L2 is configured — since every request still benefits from
+// avoiding the network round trip for keys it recently served.
+type MemoryCache struct {
+	mu      sync.RWMutex
+	entries map[string]memEntry
+	enabled bool
+}
+
+// NewMemory creates a new in-memory cache. Pass enabled=false for a no-op
+// cache (every Get misses, Set is a cheap ETag compute with no storage).
+func NewMemory(enabled bool) *MemoryCache {
+	c := &MemoryCache{
+		entries: make(map[string]memEntry),
+		enabled: enabled,
+	}
+	if enabled {
+		go c.evictLoop()
+	}
+	return c
+}
+
+func (c *MemoryCache) Get(key string) (data []byte, etag string, ok bool) {
+	if !c.enabled {
+		return nil, "", false
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	e, exists := c.entries[key]
+	if !exists || time.Now().After(e.expiresAt) {
+		return nil, "", false
+	}
+	return e.data, e.etag, true
+}
+
+func (c *MemoryCache) Set(key string, data []byte, ttl time.Duration) string {
+	etag := ComputeETag(data)
+	c.put(key, data, etag, ttl)
+	return etag
+}
+
+func (c *MemoryCache) GetSWR(key string) (data []byte, etag string, fresh, ok bool) {
+	if !c.enabled {
+		return nil, "", false, false
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	e, exists := c.entries[key]
+	if !exists {
+		return nil, "", false, false
+	}
+	now := time.Now()
+	if now.Before(e.expiresAt) {
+		return e.data, e.etag, true, true
+	}
+	if !e.staleUntil.IsZero() && now.Before(e.staleUntil) {
+		return e.data, e.etag, false, true
+	}
+	return nil, "", false, false
+}
+
+func (c *MemoryCache) SetWithStale(key string, data []byte, ttl time.Duration) string {
+	etag := ComputeETag(data)
+	now := time.Now()
+	c.putSWR(key, data, etag, now.Add(ttl), now.Add(ttl+ttl/2))
+	return etag
+}
+
+// put stores data under an already-computed ETag, so callers backfilling
+// from an L2 (which already picked the ETag) don't get a second, possibly
+// different, ETag for the same payload. It carries no stale grace window —
+// use putSWR for that.
+func (c *MemoryCache) put(key string, data []byte, etag string, ttl time.Duration) {
+	c.putSWR(key, data, etag, time.Now().Add(ttl), time.Time{})
+}
+
+func (c *MemoryCache) putSWR(key string, data []byte, etag string, expiresAt, staleUntil time.Time) {
+	if !c.enabled {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[key] = memEntry{
+		data:       data,
+		etag:       etag,
+		expiresAt:  expiresAt,
+		staleUntil: staleUntil,
+	}
+}
+
+func (c *MemoryCache) Delete(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, key)
+}
+
+func (c *MemoryCache) DeletePrefix(prefix string) {
+	prefix = strings.TrimSuffix(prefix, "*")
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key := range c.entries {
+		if strings.HasPrefix(key, prefix) {
+			delete(c.entries, key)
+		}
+	}
+}
+
+// Stats returns cache statistics.
+func (c *MemoryCache) Stats() map[string]interface{} {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	active := 0
+	now := time.Now()
+	for _, e := range c.entries {
+		if now.Before(e.expiresAt) {
+			active++
+		}
+	}
+	return map[string]interface{}{
+		"backend":      "memory",
+		"enabled":      c.enabled,
+		"total_keys":   len(c.entries),
+		"active_keys":  active,
+		"expired_keys": len(c.entries) - active,
+	}
+}
+
+// evictLoop periodically removes expired entries.
+func (c *MemoryCache) evictLoop() {
+	ticker := time.NewTicker(5 * time.Minute)
+	defer ticker.Stop()
+	for range ticker.C {
+		c.evict()
+	}
+}
+
+func (c *MemoryCache) evict() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := time.Now()
+	for key, e := range c.entries {
+		if now.After(e.expiresAt) && (e.staleUntil.IsZero() || now.After(e.staleUntil)) {
+			delete(c.entries, key)
+		}
+	}
+}
+
+// ComputeETag generates a weak ETag from response data using SHA-256, so
+// every pod serving a TieredCache-backed key derives the same ETag for
+// identical content (MD5 would too, but SHA-256 is what every caller now
+// agrees to commit to — see DeletePrefix/backfill, which depend on L1 and
+// L2 never disagreeing about a payload's identity).
+func ComputeETag(data []byte) string {
+	hash := sha256.Sum256(data)
+	return fmt.Sprintf(`W/"%x"`, hash[:8])
+}
+
+// EntryCount extracts a best-effort key count from c.Stats(), for the
+// cache_entries gauge. MemoryCache and RedisCache report it directly as
+// "total_keys"; TieredCache nests both tiers' stats under "l1"/"l2", so
+// EntryCount recurses into whichever tier actually stores data (L2, since
+// L1 is just a short-lived backfill of the same keys).
+func EntryCount(c Cache) float64 {
+	return entryCount(c.Stats())
+}
+
+func entryCount(stats map[string]interface{}) float64 {
+	if n, ok := stats["total_keys"]; ok {
+		switch v := n.(type) {
+		case int:
+			return float64(v)
+		case int64:
+			return float64(v)
+		case float64:
+			return v
+		}
+	}
+	if l2, ok := stats["l2"].(map[string]interface{}); ok {
+		return entryCount(l2)
+	}
+	return 0
+}
+
+// CheckETagMatch checks if If-None-Match header matches the current ETag.
+func CheckETagMatch(ifNoneMatch, etag string) bool {
+	if ifNoneMatch == "" {
+		return false
+	}
+	if ifNoneMatch == "*" {
+		return true
+	}
+	// Simple comparison — handles the common single-etag case
+	return ifNoneMatch == etag
+}
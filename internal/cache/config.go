@@ -0,0 +1,25 @@
+package cache
+
+import (
+	"log"
+
+	"github.com/albapepper/scoracle-data/internal/config"
+)
+
+// New selects the cache backend per config: memory-only by default, or a
+// memory-L1-over-Redis-L2 TieredCache when CacheBackend is "redis" and
+// RedisURL is reachable. Never fails the caller — a broken Redis falls back
+// to memory-only so a cache outage degrades performance, not availability.
+func New(cfg *config.Config) Cache {
+	l1 := NewMemory(cfg.CacheEnabled)
+	if cfg.CacheBackend != "redis" || cfg.RedisURL == "" {
+		return l1
+	}
+
+	l2, err := NewRedis(cfg.RedisURL)
+	if err != nil {
+		log.Printf("[cache] redis backend unavailable (%v), falling back to memory-only", err)
+		return l1
+	}
+	return NewTiered(l1, l2)
+}
@@ -0,0 +1,34 @@
+package cache
+
+import "github.com/albapepper/scoracle-data/internal/metrics"
+
+// reg is the process-wide metrics registry for the cache package, set once
+// at startup via SetMetrics. A nil reg (the default) makes every
+// instrumented call a no-op. See internal/fixture.SetMetrics for why this
+// is a package-level var rather than a parameter threaded through every
+// Cache call.
+var reg *metrics.Registry
+
+// SetMetrics wires a metrics registry into subsequent RedisCache calls.
+// Call once from cmd/api/main.go (or cmd/ingest/main.go) before serving
+// traffic. Without this, a Redis L2 that goes unreachable mid-flight
+// degrades silently: NewRedis only checks reachability once at startup, and
+// every later Get/Set error is swallowed into a plain cache miss so
+// TieredCache keeps working off L1 alone. These counters are what makes
+// that degraded state visible on a dashboard instead of only showing up as
+// unexplained DB load.
+func SetMetrics(r *metrics.Registry) {
+	reg = r
+}
+
+func observeRedisOp(op string, err error) {
+	if reg == nil {
+		return
+	}
+	result := "ok"
+	if err != nil {
+		result = "error"
+	}
+	reg.IncCounter("scoracle_cache_redis_ops_total", "Redis cache backend operations, labeled by op and result.",
+		map[string]string{"op": op, "result": result})
+}
@@ -0,0 +1,149 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// redisOpTimeout bounds every individual Redis round trip, so a slow or
+// unreachable Redis degrades a single request rather than hanging it.
+const redisOpTimeout = 2 * time.Second
+
+// redisKeyPrefix namespaces this service's keys within a shared Redis
+// instance/cluster.
+const redisKeyPrefix = "scoracle:cache:"
+
+// RedisCache is a Cache backed by Redis, shared across API replicas so
+// cached payloads and ETags agree no matter which instance served a prior
+// request.
+type RedisCache struct {
+	client *redis.Client
+}
+
+// NewRedis connects to the Redis instance described by redisURL (a
+// redis://[:password@]host:port/db URL).
+func NewRedis(redisURL string) (*RedisCache, error) {
+	opts, err := redis.ParseURL(redisURL)
+	if err != nil {
+		return nil, fmt.Errorf("parse redis url: %w", err)
+	}
+	return &RedisCache{client: redis.NewClient(opts)}, nil
+}
+
+func (c *RedisCache) Get(key string) (data []byte, etag string, ok bool) {
+	ctx, cancel := context.WithTimeout(context.Background(), redisOpTimeout)
+	defer cancel()
+
+	fields, err := c.client.HGetAll(ctx, redisKey(key)).Result()
+	observeRedisOp("get", err)
+	if err != nil || len(fields) == 0 {
+		return nil, "", false
+	}
+	return []byte(fields["data"]), fields["etag"], true
+}
+
+func (c *RedisCache) Set(key string, data []byte, ttl time.Duration) string {
+	etag := ComputeETag(data)
+	ctx, cancel := context.WithTimeout(context.Background(), redisOpTimeout)
+	defer cancel()
+
+	pipe := c.client.TxPipeline()
+	pipe.HSet(ctx, redisKey(key), map[string]interface{}{"data": data, "etag": etag})
+	pipe.Expire(ctx, redisKey(key), ttl)
+	_, err := pipe.Exec(ctx)
+	observeRedisOp("set", err)
+	return etag
+}
+
+// GetSWR reads the same hash Get does, additionally checking the
+// fresh_until field SetWithStale writes. A key with no fresh_until field
+// (written by the plain Set) is reported fresh for as long as Redis' own
+// TTL keeps it around, matching Get's existing behavior.
+func (c *RedisCache) GetSWR(key string) (data []byte, etag string, fresh, ok bool) {
+	ctx, cancel := context.WithTimeout(context.Background(), redisOpTimeout)
+	defer cancel()
+
+	fields, err := c.client.HGetAll(ctx, redisKey(key)).Result()
+	observeRedisOp("get_swr", err)
+	if err != nil || len(fields) == 0 {
+		return nil, "", false, false
+	}
+
+	fresh = true
+	if freshUntil, parseErr := strconv.ParseInt(fields["fresh_until"], 10, 64); parseErr == nil {
+		fresh = time.Now().Before(time.Unix(freshUntil, 0))
+	}
+	return []byte(fields["data"]), fields["etag"], fresh, true
+}
+
+// SetWithStale stores data alongside the unix timestamp it stops being
+// fresh at, then lets it sit servable-but-stale in Redis for an additional
+// ttl/2 before the key's own TTL finally drops it — see GetSWR.
+func (c *RedisCache) SetWithStale(key string, data []byte, ttl time.Duration) string {
+	etag := ComputeETag(data)
+	ctx, cancel := context.WithTimeout(context.Background(), redisOpTimeout)
+	defer cancel()
+
+	freshUntil := time.Now().Add(ttl)
+	pipe := c.client.TxPipeline()
+	pipe.HSet(ctx, redisKey(key), map[string]interface{}{
+		"data":        data,
+		"etag":        etag,
+		"fresh_until": freshUntil.Unix(),
+	})
+	pipe.Expire(ctx, redisKey(key), ttl+ttl/2)
+	_, err := pipe.Exec(ctx)
+	observeRedisOp("set_swr", err)
+	return etag
+}
+
+func (c *RedisCache) Delete(key string) {
+	ctx, cancel := context.WithTimeout(context.Background(), redisOpTimeout)
+	defer cancel()
+	err := c.client.Del(ctx, redisKey(key)).Err()
+	observeRedisOp("delete", err)
+}
+
+func (c *RedisCache) DeletePrefix(prefix string) {
+	ctx, cancel := context.WithTimeout(context.Background(), redisOpTimeout)
+	defer cancel()
+
+	match := redisKey(prefix)
+	if match[len(match)-1] != '*' {
+		match += "*"
+	}
+
+	iter := c.client.Scan(ctx, 0, match, 0).Iterator()
+	var keys []string
+	for iter.Next(ctx) {
+		keys = append(keys, iter.Val())
+	}
+	err := iter.Err()
+	if err == nil && len(keys) > 0 {
+		err = c.client.Del(ctx, keys...).Err()
+	}
+	observeRedisOp("delete_prefix", err)
+}
+
+func (c *RedisCache) Stats() map[string]interface{} {
+	ctx, cancel := context.WithTimeout(context.Background(), redisOpTimeout)
+	defer cancel()
+
+	size, err := c.client.DBSize(ctx).Result()
+	stats := map[string]interface{}{"backend": "redis"}
+	if err != nil {
+		stats["reachable"] = false
+		return stats
+	}
+	stats["reachable"] = true
+	stats["total_keys"] = size
+	return stats
+}
+
+func redisKey(key string) string {
+	return redisKeyPrefix + key
+}
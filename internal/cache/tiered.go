@@ -0,0 +1,81 @@
+package cache
+
+import "time"
+
+// l1BackfillTTL bounds how long a value fetched from L2 lives in L1 once
+// backfilled. Short and fixed rather than the original TTL, since we don't
+// know how much of that TTL L2's copy has left.
+const l1BackfillTTL = 5 * time.Minute
+
+// TieredCache serves reads from an in-memory L1 in front of a shared L2
+// (typically Redis), so most requests avoid a network round trip while
+// multiple API replicas still agree on payloads and ETags through L2.
+type TieredCache struct {
+	l1 *MemoryCache
+	l2 Cache
+}
+
+// NewTiered wraps l2 with an l1 in-memory front. l1's own TTL bookkeeping is
+// reused as-is; a key simply disappears from L1 sooner than L2 on eviction
+// and the next Get backfills it from L2.
+func NewTiered(l1 *MemoryCache, l2 Cache) *TieredCache {
+	return &TieredCache{l1: l1, l2: l2}
+}
+
+func (c *TieredCache) Get(key string) (data []byte, etag string, ok bool) {
+	if data, etag, ok := c.l1.Get(key); ok {
+		return data, etag, ok
+	}
+	data, etag, ok = c.l2.Get(key)
+	if ok {
+		// Backfill L1 with a short TTL — just long enough to absorb a burst
+		// of requests for the same key without re-hitting L2 every time.
+		c.l1.put(key, data, etag, l1BackfillTTL)
+	}
+	return data, etag, ok
+}
+
+func (c *TieredCache) Set(key string, data []byte, ttl time.Duration) string {
+	etag := c.l2.Set(key, data, ttl)
+	c.l1.put(key, data, etag, ttl)
+	return etag
+}
+
+// GetSWR checks L1 first — anything still sitting in L1's short backfill
+// window is, by construction, recent enough to treat as fresh — then falls
+// through to L2's own fresh/stale bookkeeping. Only a fresh L2 hit backfills
+// L1, so a stale hit doesn't get re-served as fresh out of L1 next time.
+func (c *TieredCache) GetSWR(key string) (data []byte, etag string, fresh, ok bool) {
+	if data, etag, ok := c.l1.Get(key); ok {
+		return data, etag, true, ok
+	}
+	data, etag, fresh, ok = c.l2.GetSWR(key)
+	if ok && fresh {
+		c.l1.put(key, data, etag, l1BackfillTTL)
+	}
+	return data, etag, fresh, ok
+}
+
+func (c *TieredCache) SetWithStale(key string, data []byte, ttl time.Duration) string {
+	etag := c.l2.SetWithStale(key, data, ttl)
+	c.l1.put(key, data, etag, l1BackfillTTL)
+	return etag
+}
+
+func (c *TieredCache) Delete(key string) {
+	c.l1.Delete(key)
+	c.l2.Delete(key)
+}
+
+func (c *TieredCache) DeletePrefix(prefix string) {
+	c.l1.DeletePrefix(prefix)
+	c.l2.DeletePrefix(prefix)
+}
+
+func (c *TieredCache) Stats() map[string]interface{} {
+	return map[string]interface{}{
+		"backend": "tiered",
+		"l1":      c.l1.Stats(),
+		"l2":      c.l2.Stats(),
+	}
+}
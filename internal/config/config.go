@@ -3,6 +3,7 @@
 package config
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"strconv"
@@ -10,22 +11,6 @@ import (
 	"time"
 )
 
-// --------------------------------------------------------------------------
-// Sport registry — mirrors Python core/types.py SPORT_REGISTRY
-// --------------------------------------------------------------------------
-
-type SportConfig struct {
-	ID            string
-	Name          string
-	CurrentSeason int
-}
-
-var SportRegistry = map[string]SportConfig{
-	"NBA":      {ID: "NBA", Name: "National Basketball Association", CurrentSeason: 2025},
-	"NFL":      {ID: "NFL", Name: "National Football League", CurrentSeason: 2025},
-	"FOOTBALL": {ID: "FOOTBALL", Name: "Football (Soccer)", CurrentSeason: 2025},
-}
-
 // --------------------------------------------------------------------------
 // Table names — single source of truth, matches schema.sql
 // --------------------------------------------------------------------------
@@ -55,6 +40,19 @@ type Config struct {
 	Environment string // development, staging, production
 	Debug       bool
 
+	// Admin — metrics listener, separate from the public API port so
+	// scraping doesn't compete with user traffic.
+	AdminMetricsPort int
+
+	// Observability — see internal/observability (tracing) and
+	// internal/metrics (the Prometheus-format registry already used
+	// throughout). MetricsEnabled mounts /metrics on the public router
+	// in addition to the admin listener above; MetricsListen overrides the
+	// admin listener's address when set.
+	OTelExporterOTLPEndpoint string
+	MetricsEnabled           bool
+	MetricsListen            string
+
 	// CORS
 	CORSAllowOrigins []string
 
@@ -62,6 +60,13 @@ type Config struct {
 	RateLimitEnabled  bool
 	RateLimitRequests int
 	RateLimitWindow   time.Duration
+	// RateLimitMaxIPs bounds the per-IP limiter LRU (internal/api's
+	// ipLimiter) so unbounded unique-client traffic can't grow it forever.
+	RateLimitMaxIPs int
+	// RateLimitTiers maps an X-API-Key value to its own quota, overriding
+	// the per-IP default for whoever presents that key. See RateLimitTier
+	// and envRateLimitTiers.
+	RateLimitTiers map[string]RateLimitTier
 
 	// External API keys
 	BDLAPIKey          string
@@ -69,9 +74,59 @@ type Config struct {
 	TwitterBearerToken string
 	TwitterListID      string
 	NewsAPIKey         string
+	// NewsFeeds lists additional RSS/Atom/JSON Feed sources to register as
+	// news.FeedProvider instances, one per entry, formatted "name|url" (e.g.
+	// "nba_rumors|https://www.nba.com/rumors/rss"). Lets team/league feeds
+	// be added per deployment without a code change.
+	NewsFeeds []string
+	// NewsRulesFile points at a JSON or YAML news.RuleSet (must_include/
+	// must_exclude word lists per sport/entity) for content filtering.
+	// Empty disables filtering.
+	NewsRulesFile string
+	// NewsHTTPCacheMB bounds the in-process conditional-GET cache shared by
+	// the RSS provider and the journalist feed fetch — see
+	// internal/httpcache.
+	NewsHTTPCacheMB int
 
 	// Cache
 	CacheEnabled bool
+	CacheBackend string // "memory" (default) or "redis"
+	RedisURL     string
+
+	// Notifications — each sender is enabled independently based on whether
+	// its required config is present; see notifications.NewFCMSender,
+	// NewAPNSSender, NewWebhookSender.
+	FCMCredentialsFile string
+	APNSKeyFile        string // .p8 key path
+	APNSKeyID          string
+	APNSTeamID         string
+	APNSBundleID       string
+	APNSProduction     bool
+	WebhookURL         string
+
+	// Error reporting — batches internal errors into a periodic digest for
+	// maintainers rather than one push per failure; see internal/errreport.
+	ErrorReportWindow time.Duration
+	SMTPHost          string
+	SMTPPort          int
+	SMTPUsername      string
+	SMTPPassword      string
+	SMTPFrom          string
+	MaintainerEmail   string
+
+	// Event sink — publishes milestone/percentile-change events to an
+	// external bus for downstream consumers; see internal/eventsink.
+	EventSink        string // "kafka", "pulsar", or "none" (default)
+	KafkaBrokers     []string
+	PulsarServiceURL string
+}
+
+// RateLimitTier is one API key's rate-limit quota, overriding the default
+// per-IP limit for whoever presents that key via X-API-Key.
+type RateLimitTier struct {
+	Requests int
+	Window   time.Duration
+	Burst    int
 }
 
 // Load reads configuration from environment variables with sensible defaults.
@@ -92,6 +147,12 @@ func Load() (*Config, error) {
 		Environment: envOr("ENVIRONMENT", "development"),
 		Debug:       envBool("DEBUG", false),
 
+		AdminMetricsPort: envInt("ADMIN_METRICS_PORT", 9090),
+
+		OTelExporterOTLPEndpoint: envOr("OTEL_EXPORTER_OTLP_ENDPOINT", ""),
+		MetricsEnabled:           envBool("METRICS_ENABLED", false),
+		MetricsListen:            envOr("METRICS_LISTEN", ""),
+
 		CORSAllowOrigins: envList("CORS_ALLOW_ORIGINS", []string{
 			"http://localhost:3000",
 			"http://localhost:4321",
@@ -101,14 +162,41 @@ func Load() (*Config, error) {
 		RateLimitEnabled:  envBool("RATE_LIMIT_ENABLED", true),
 		RateLimitRequests: envInt("RATE_LIMIT_REQUESTS", 100),
 		RateLimitWindow:   time.Duration(envInt("RATE_LIMIT_WINDOW", 60)) * time.Second,
+		RateLimitMaxIPs:   envInt("RATE_LIMIT_MAX_IPS", 50000),
+		RateLimitTiers:    envRateLimitTiers("RATE_LIMIT_TIERS"),
 
 		BDLAPIKey:          envOr("BALLDONTLIE_API_KEY", ""),
 		SportMonksAPIToken: envOr("SPORTMONKS_API_TOKEN", ""),
 		TwitterBearerToken: envOr("TWITTER_BEARER_TOKEN", ""),
 		TwitterListID:      envOr("TWITTER_JOURNALIST_LIST_ID", ""),
 		NewsAPIKey:         envOr("NEWS_API_KEY", ""),
+		NewsFeeds:          envList("NEWS_FEEDS", nil),
+		NewsRulesFile:      envOr("NEWS_RULES_FILE", ""),
+		NewsHTTPCacheMB:    envInt("NEWS_HTTP_CACHE_MB", 16),
 
 		CacheEnabled: envBool("CACHE_ENABLED", true),
+		CacheBackend: envOr("CACHE_BACKEND", "memory"),
+		RedisURL:     envOr("REDIS_URL", ""),
+
+		FCMCredentialsFile: envOr("FIREBASE_CREDENTIALS_FILE", ""),
+		APNSKeyFile:        envOr("APNS_KEY_FILE", ""),
+		APNSKeyID:          envOr("APNS_KEY_ID", ""),
+		APNSTeamID:         envOr("APNS_TEAM_ID", ""),
+		APNSBundleID:       envOr("APNS_BUNDLE_ID", ""),
+		APNSProduction:     envBool("APNS_PRODUCTION", false),
+		WebhookURL:         envOr("NOTIFICATION_WEBHOOK_URL", ""),
+
+		ErrorReportWindow: time.Duration(envInt("ERROR_REPORT_WINDOW_MINUTES", 5)) * time.Minute,
+		SMTPHost:          envOr("SMTP_HOST", ""),
+		SMTPPort:          envInt("SMTP_PORT", 587),
+		SMTPUsername:      envOr("SMTP_USERNAME", ""),
+		SMTPPassword:      envOr("SMTP_PASSWORD", ""),
+		SMTPFrom:          envOr("SMTP_FROM", ""),
+		MaintainerEmail:   envOr("MAINTAINER_EMAIL", ""),
+
+		EventSink:        envOr("EVENT_SINK", "none"),
+		KafkaBrokers:     envList("KAFKA_BROKERS", nil),
+		PulsarServiceURL: envOr("PULSAR_SERVICE_URL", ""),
 	}, nil
 }
 
@@ -147,6 +235,39 @@ func envBool(key string, fallback bool) bool {
 	return fallback
 }
 
+// envRateLimitTiers parses RATE_LIMIT_TIERS, a JSON object mapping an API
+// key to its quota, e.g.:
+//
+//	{"partner-a": {"requests": 1000, "window_seconds": 60, "burst": 200}}
+//
+// Empty or malformed input yields a nil map (no tiers configured — every
+// caller falls back to the per-IP default), logged rather than fatal since a
+// typo here shouldn't take down the whole API.
+func envRateLimitTiers(key string) map[string]RateLimitTier {
+	v := os.Getenv(key)
+	if v == "" {
+		return nil
+	}
+	var raw map[string]struct {
+		Requests      int `json:"requests"`
+		WindowSeconds int `json:"window_seconds"`
+		Burst         int `json:"burst"`
+	}
+	if err := json.Unmarshal([]byte(v), &raw); err != nil {
+		fmt.Fprintf(os.Stderr, "config: ignoring invalid %s: %v\n", key, err)
+		return nil
+	}
+	tiers := make(map[string]RateLimitTier, len(raw))
+	for apiKey, t := range raw {
+		tiers[apiKey] = RateLimitTier{
+			Requests: t.Requests,
+			Window:   time.Duration(t.WindowSeconds) * time.Second,
+			Burst:    t.Burst,
+		}
+	}
+	return tiers
+}
+
 func envList(key string, fallback []string) []string {
 	if v := os.Getenv(key); v != "" {
 		parts := strings.Split(v, ",")
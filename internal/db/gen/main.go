@@ -0,0 +1,239 @@
+// Command gen reads internal/db/queries/*.sql — each file one named
+// statement in a small sqlc-style header format — and emits
+// internal/db/statements_gen.go: the prepared-statement registration map
+// plus a typed Query/QueryRow/Exec helper per statement, so a new
+// statement is a new .sql file instead of a new entry in a hand-maintained
+// Go map. Run via `go generate ./internal/db` (see the go:generate
+// directive in db.go).
+//
+// Each query file looks like:
+//
+//	-- name: get_pending_fixtures
+//	-- category: Fixtures
+//	-- kind: rows
+//	SELECT * FROM get_pending_fixtures($1, $2, $3)
+//
+// kind selects the generated helper's shape and is one of:
+//   - row  (default): QueryRow, for statements a caller immediately Scans
+//   - rows: Query, for statements a caller iterates with rows.Next()
+//   - exec: Exec, for statements with no result rows (INSERT/pg_notify)
+//
+// The helper's argument count is taken from the highest $N placeholder in
+// the SQL body, so calling it with the wrong number of arguments is a
+// compile error instead of a runtime "prepared statement requires N
+// parameters" failure.
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type statement struct {
+	name     string
+	category string
+	kind     string // "row", "rows", or "exec"
+	sql      string
+	argCount int
+}
+
+var placeholderRe = regexp.MustCompile(`\$(\d+)`)
+
+func main() {
+	if err := run(); err != nil {
+		fmt.Fprintln(os.Stderr, "gen:", err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	queriesDir := "queries"
+	entries, err := os.ReadDir(queriesDir)
+	if err != nil {
+		return fmt.Errorf("read %s: %w", queriesDir, err)
+	}
+
+	var stmts []statement
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".sql") {
+			continue
+		}
+		st, err := parseQueryFile(filepath.Join(queriesDir, e.Name()))
+		if err != nil {
+			return fmt.Errorf("%s: %w", e.Name(), err)
+		}
+		stmts = append(stmts, st)
+	}
+	sort.Slice(stmts, func(i, j int) bool { return stmts[i].name < stmts[j].name })
+
+	src := render(stmts)
+	return os.WriteFile("statements_gen.go", []byte(src), 0o644)
+}
+
+func parseQueryFile(path string) (statement, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return statement{}, err
+	}
+	defer f.Close()
+
+	st := statement{kind: "row"}
+	var bodyLines []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "-- name:"):
+			st.name = strings.TrimSpace(strings.TrimPrefix(line, "-- name:"))
+		case strings.HasPrefix(line, "-- category:"):
+			st.category = strings.TrimSpace(strings.TrimPrefix(line, "-- category:"))
+		case strings.HasPrefix(line, "-- kind:"):
+			st.kind = strings.TrimSpace(strings.TrimPrefix(line, "-- kind:"))
+		default:
+			bodyLines = append(bodyLines, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return statement{}, err
+	}
+	if st.name == "" {
+		return statement{}, fmt.Errorf("missing required \"-- name:\" header")
+	}
+	switch st.kind {
+	case "row", "rows", "exec":
+	default:
+		return statement{}, fmt.Errorf("statement %q: unknown kind %q (want row, rows, or exec)", st.name, st.kind)
+	}
+
+	st.sql = strings.TrimSpace(strings.Join(bodyLines, "\n"))
+	st.argCount = maxPlaceholder(st.sql)
+	return st, nil
+}
+
+func maxPlaceholder(sql string) int {
+	max := 0
+	for _, m := range placeholderRe.FindAllStringSubmatch(sql, -1) {
+		n, err := strconv.Atoi(m[1])
+		if err == nil && n > max {
+			max = n
+		}
+	}
+	return max
+}
+
+// helperName converts a statement's snake_case name into the exported
+// CamelCase suffix for its generated helper (e.g. "get_pending_fixtures"
+// -> "GetPendingFixtures", called as QueryGetPendingFixtures).
+func helperName(name string) string {
+	parts := strings.Split(name, "_")
+	var b strings.Builder
+	for _, p := range parts {
+		if p == "" {
+			continue
+		}
+		b.WriteString(strings.ToUpper(p[:1]))
+		b.WriteString(p[1:])
+	}
+	return b.String()
+}
+
+func render(stmts []statement) string {
+	var b strings.Builder
+
+	b.WriteString("// Code generated by internal/db/gen; DO NOT EDIT.\n")
+	b.WriteString("// Source: internal/db/queries/*.sql. Regenerate with `go generate ./internal/db`.\n")
+	b.WriteString("package db\n\n")
+	b.WriteString("import (\n\t\"context\"\n\t\"fmt\"\n\n\t\"github.com/jackc/pgx/v5\"\n\t\"github.com/jackc/pgx/v5/pgconn\"\n)\n\n")
+
+	b.WriteString("// preparedStatements is every statement from internal/db/queries, keyed by\n")
+	b.WriteString("// name. registerPreparedStatements (below) prepares each of these on every\n")
+	b.WriteString("// new connection; see Queryable and the QueryXxx/Exec functions for typed\n")
+	b.WriteString("// call sites.\n")
+	b.WriteString("var preparedStatements = map[string]string{\n")
+	for _, st := range stmts {
+		fmt.Fprintf(&b, "\t%q: %s,\n", st.name, goStringLiteral(st.sql))
+	}
+	b.WriteString("}\n\n")
+
+	b.WriteString("// registerPreparedStatements registers every statement in preparedStatements\n")
+	b.WriteString("// on conn. Prepared statements eliminate parse overhead on every request.\n")
+	b.WriteString("func registerPreparedStatements(ctx context.Context, conn *pgx.Conn) error {\n")
+	b.WriteString("\tfor name, sql := range preparedStatements {\n")
+	b.WriteString("\t\tif _, err := conn.Prepare(ctx, name, sql); err != nil {\n")
+	b.WriteString("\t\t\treturn fmt.Errorf(\"prepare %q: %w\", name, err)\n")
+	b.WriteString("\t\t}\n")
+	b.WriteString("\t}\n")
+	b.WriteString("\treturn nil\n")
+	b.WriteString("}\n\n")
+
+	b.WriteString("// Queryable is the subset of *pgxpool.Pool, *pgxpool.Conn, pgx.Tx, and\n")
+	b.WriteString("// *pgx.Conn the QueryXxx/Exec helpers below need; any of them can be\n")
+	b.WriteString("// passed as-is.\n")
+	b.WriteString("type Queryable interface {\n")
+	b.WriteString("\tQuery(ctx context.Context, sql string, args ...any) (pgx.Rows, error)\n")
+	b.WriteString("\tQueryRow(ctx context.Context, sql string, args ...any) pgx.Row\n")
+	b.WriteString("\tExec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)\n")
+	b.WriteString("}\n\n")
+
+	for _, st := range stmts {
+		writeHelper(&b, st)
+	}
+
+	return b.String()
+}
+
+func writeHelper(b *strings.Builder, st statement) {
+	name := helperName(st.name)
+	params := make([]string, st.argCount)
+	args := make([]string, st.argCount)
+	for i := 0; i < st.argCount; i++ {
+		params[i] = fmt.Sprintf("p%d any", i+1)
+		args[i] = fmt.Sprintf("p%d", i+1)
+	}
+	paramList := strings.Join(params, ", ")
+	argList := strings.Join(args, ", ")
+	if argList != "" {
+		argList = ", " + argList
+	}
+
+	switch st.kind {
+	case "rows":
+		fmt.Fprintf(b, "// Query%s runs the %q statement (%s), returning every matching row.\n", name, st.name, st.category)
+		fmt.Fprintf(b, "func Query%s(ctx context.Context, q Queryable%s) (pgx.Rows, error) {\n", name, commaPrefix(paramList))
+		fmt.Fprintf(b, "\treturn q.Query(ctx, %q%s)\n", st.name, argList)
+		b.WriteString("}\n\n")
+	case "exec":
+		fmt.Fprintf(b, "// Exec%s runs the %q statement (%s), for statements with no result rows.\n", name, st.name, st.category)
+		fmt.Fprintf(b, "func Exec%s(ctx context.Context, q Queryable%s) (pgconn.CommandTag, error) {\n", name, commaPrefix(paramList))
+		fmt.Fprintf(b, "\treturn q.Exec(ctx, %q%s)\n", st.name, argList)
+		b.WriteString("}\n\n")
+	default: // "row"
+		fmt.Fprintf(b, "// QueryRow%s runs the %q statement (%s), for the caller to Scan.\n", name, st.name, st.category)
+		fmt.Fprintf(b, "func QueryRow%s(ctx context.Context, q Queryable%s) pgx.Row {\n", name, commaPrefix(paramList))
+		fmt.Fprintf(b, "\treturn q.QueryRow(ctx, %q%s)\n", st.name, argList)
+		b.WriteString("}\n\n")
+	}
+}
+
+func commaPrefix(s string) string {
+	if s == "" {
+		return ""
+	}
+	return ", " + s
+}
+
+// goStringLiteral renders sql as a Go string literal — a backtick raw
+// string when it contains no backtick, otherwise a quoted, escaped
+// literal.
+func goStringLiteral(sql string) string {
+	if !strings.Contains(sql, "`") {
+		return "`" + sql + "`"
+	}
+	return strconv.Quote(sql)
+}
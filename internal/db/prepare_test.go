@@ -0,0 +1,43 @@
+package db
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// TestPreparedStatementsParse opens a throwaway connection and pg_prepares
+// every statement in preparedStatements (generated by internal/db/gen from
+// internal/db/queries/*.sql), catching drift between SQL functions like
+// api_entity_stats and the Go call sites without asserting anything about
+// query results. Requires a reachable Postgres with the full schema loaded
+// (same DATABASE_URL priority as config.Load); skipped when none is set,
+// since this snapshot has no such fixture available in CI.
+func TestPreparedStatementsParse(t *testing.T) {
+	dsn := envOrEmpty("NEON_DATABASE_URL_V2", envOrEmpty("DATABASE_URL", envOrEmpty("NEON_DATABASE_URL", "")))
+	if dsn == "" {
+		t.Skip("no DATABASE_URL configured, skipping live pg_prepare validation")
+	}
+
+	ctx := context.Background()
+	conn, err := pgx.Connect(ctx, dsn)
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	defer conn.Close(ctx)
+
+	for name, sql := range preparedStatements {
+		if _, err := conn.Prepare(ctx, name, sql); err != nil {
+			t.Errorf("prepare %q: %v", name, err)
+		}
+	}
+}
+
+func envOrEmpty(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
@@ -0,0 +1,162 @@
+// Package errreport batches internal errors across the process into a
+// periodic digest for maintainers, instead of each transient failure only
+// being visible in slog output (or, for send failures, generating one push
+// per failure). notifications, listener, and maintenance call Record as
+// errors occur; Start runs the background loop that flushes the digest.
+package errreport
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/smtp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/albapepper/scoracle-data/internal/db"
+)
+
+var (
+	mu     sync.Mutex
+	counts = make(map[string]int)
+)
+
+// Record increments the count for errType, to be folded into the next
+// digest. err is accepted (rather than just errType) so call sites read
+// naturally at the point they already have one, even though only the count
+// is reported today. Safe for concurrent use.
+func Record(errType string, err error) {
+	mu.Lock()
+	counts[errType]++
+	mu.Unlock()
+}
+
+// PushSender is the subset of a notifications.Sender needed to deliver a
+// digest push. Defined locally (rather than importing internal/notifications)
+// because notifications itself calls Record — importing it here would
+// create a cycle. Satisfied structurally by *notifications.FCMSender.
+type PushSender interface {
+	SendMulti(ctx context.Context, tokens []string, title, body string, data map[string]string) error
+}
+
+// SMTPConfig configures the email fallback. The zero value disables it.
+type SMTPConfig struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	From     string
+	To       string
+}
+
+func (c SMTPConfig) enabled() bool {
+	return c.Host != "" && c.To != ""
+}
+
+// Start runs the digest loop on an interval of window until ctx is
+// cancelled. push may be nil to skip the push path (e.g. FCM not
+// configured); a zero-value smtpCfg skips the email fallback. pool loads
+// maintainer device tokens for the push path. Intended to be called with
+// `go`.
+func Start(ctx context.Context, pool *pgxpool.Pool, push PushSender, smtpCfg SMTPConfig, window time.Duration, logger *slog.Logger) {
+	ticker := time.NewTicker(window)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			flush(ctx, pool, push, smtpCfg, logger)
+		}
+	}
+}
+
+// flush drains the accumulated counts and, if any were recorded since the
+// last tick, composes and dispatches a single digest.
+func flush(ctx context.Context, pool *pgxpool.Pool, push PushSender, smtpCfg SMTPConfig, logger *slog.Logger) {
+	mu.Lock()
+	if len(counts) == 0 {
+		mu.Unlock()
+		return
+	}
+	snapshot := counts
+	counts = make(map[string]int)
+	mu.Unlock()
+
+	digest := formatDigest(snapshot)
+	logger.Warn("Error digest", "summary", digest)
+
+	if push != nil {
+		tokens, err := getMaintainerTokens(ctx, pool)
+		if err != nil {
+			logger.Error("get maintainer device tokens", "error", err)
+		} else if len(tokens) > 0 {
+			if err := push.SendMulti(ctx, tokens, "Scoracle error digest", digest, nil); err != nil {
+				logger.Error("send error digest push", "error", err)
+			}
+		}
+	}
+
+	if smtpCfg.enabled() {
+		if err := sendEmail(smtpCfg, digest); err != nil {
+			logger.Error("send error digest email", "error", err)
+		}
+	}
+}
+
+// formatDigest renders counts sorted by error type, e.g.
+// "fcm_send_failed: 34, listener_reconnect: 12".
+func formatDigest(counts map[string]int) string {
+	types := make([]string, 0, len(counts))
+	for t := range counts {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+
+	parts := make([]string, 0, len(types))
+	for _, t := range types {
+		parts = append(parts, fmt.Sprintf("%s: %d", t, counts[t]))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// getMaintainerTokens returns every active maintainer device token,
+// regardless of platform — digest delivery doesn't route per-platform the
+// way user notifications do via SenderRegistry.
+func getMaintainerTokens(ctx context.Context, pool *pgxpool.Pool) ([]string, error) {
+	rows, err := db.QueryGetMaintainerDeviceTokens(ctx, pool)
+	if err != nil {
+		return nil, fmt.Errorf("get maintainer device tokens: %w", err)
+	}
+	defer rows.Close()
+
+	var tokens []string
+	for rows.Next() {
+		var token, platform string
+		if err := rows.Scan(&token, &platform); err != nil {
+			return nil, fmt.Errorf("scan maintainer device token: %w", err)
+		}
+		tokens = append(tokens, token)
+	}
+	return tokens, rows.Err()
+}
+
+// sendEmail posts the digest as a plaintext email via SMTP.
+func sendEmail(cfg SMTPConfig, digest string) error {
+	addr := fmt.Sprintf("%s:%d", cfg.Host, cfg.Port)
+	var auth smtp.Auth
+	if cfg.Username != "" {
+		auth = smtp.PlainAuth("", cfg.Username, cfg.Password, cfg.Host)
+	}
+	msg := fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: Scoracle error digest\r\n\r\n%s\r\n",
+		cfg.From, cfg.To, digest)
+	if err := smtp.SendMail(addr, auth, cfg.From, []string{cfg.To}, []byte(msg)); err != nil {
+		return fmt.Errorf("smtp send: %w", err)
+	}
+	return nil
+}
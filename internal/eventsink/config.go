@@ -0,0 +1,36 @@
+package eventsink
+
+import (
+	"log/slog"
+
+	"github.com/albapepper/scoracle-data/internal/config"
+)
+
+// New selects the event sink backend per config.EventSink: "kafka", "pulsar",
+// or anything else (including "none", the default) for a NoopSink. Never
+// fails the caller — a broken Pulsar connection falls back to NoopSink so a
+// sink outage degrades to "events aren't published downstream", not
+// availability of the notification/milestone pipeline itself.
+func New(cfg *config.Config, logger *slog.Logger) Sink {
+	switch cfg.EventSink {
+	case "kafka":
+		if len(cfg.KafkaBrokers) == 0 {
+			logger.Warn("EVENT_SINK=kafka but no brokers configured, falling back to noop")
+			return NoopSink{}
+		}
+		return NewRetrying(NewKafkaSink(cfg.KafkaBrokers), logger)
+	case "pulsar":
+		if cfg.PulsarServiceURL == "" {
+			logger.Warn("EVENT_SINK=pulsar but no service URL configured, falling back to noop")
+			return NoopSink{}
+		}
+		sink, err := NewPulsarSink(cfg.PulsarServiceURL)
+		if err != nil {
+			logger.Error("init pulsar sink, falling back to noop", "error", err)
+			return NoopSink{}
+		}
+		return NewRetrying(sink, logger)
+	default:
+		return NoopSink{}
+	}
+}
@@ -0,0 +1,44 @@
+package eventsink
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// EnvelopeSchemaVersion is bumped whenever Envelope's shape changes in a way
+// consumers need to branch on. Carried in every published message so
+// consumers can detect and handle older/newer producers during a rollout.
+const EnvelopeSchemaVersion = 1
+
+// Envelope wraps every event published to a Sink in a stable, versioned
+// shape, regardless of the concrete event type in Data.
+type Envelope struct {
+	SchemaVersion  int             `json:"schema_version"`
+	EventType      string          `json:"event_type"` // "milestone_reached" | "percentile_change"
+	IdempotencyKey string          `json:"idempotency_key"`
+	OccurredAt     time.Time       `json:"occurred_at"`
+	Data           json.RawMessage `json:"data"`
+}
+
+// IdempotencyKey builds the (fixture_id, entity_id, stat_key) key consumers
+// use to de-duplicate under at-least-once delivery.
+func IdempotencyKey(fixtureID, entityID int, statKey string) string {
+	return fmt.Sprintf("%d:%d:%s", fixtureID, entityID, statKey)
+}
+
+// NewEnvelope marshals data and wraps it with the current schema version and
+// an idempotency key, ready to hand to a Sink's Publish.
+func NewEnvelope(eventType string, idempotencyKey string, occurredAt time.Time, data any) (Envelope, error) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return Envelope{}, fmt.Errorf("marshal %s event data: %w", eventType, err)
+	}
+	return Envelope{
+		SchemaVersion:  EnvelopeSchemaVersion,
+		EventType:      eventType,
+		IdempotencyKey: idempotencyKey,
+		OccurredAt:     occurredAt,
+		Data:           raw,
+	}, nil
+}
@@ -0,0 +1,22 @@
+// Package eventsink publishes milestone and percentile-change events to an
+// external message bus (Kafka or Pulsar) so downstream consumers — dashboards,
+// ML training pipelines, third-party integrations — can subscribe instead of
+// polling Postgres. The backend is a pure config-time choice (see New),
+// mirroring how internal/cache.New picks a cache backend.
+package eventsink
+
+import "context"
+
+// Sink publishes one message to topic. key is used for partitioning/log
+// compaction by brokers that support it (e.g. Kafka's hash-by-key); payload
+// is the JSON-encoded Envelope.
+type Sink interface {
+	Publish(ctx context.Context, topic string, key, payload []byte) error
+}
+
+// NoopSink discards every publish, for EVENT_SINK=none (the default) so
+// callers don't need to nil-check before publishing.
+type NoopSink struct{}
+
+// Publish is a no-op.
+func (NoopSink) Publish(ctx context.Context, topic string, key, payload []byte) error { return nil }
@@ -0,0 +1,43 @@
+package eventsink
+
+import (
+	"context"
+	"fmt"
+
+	kafka "github.com/segmentio/kafka-go"
+)
+
+// KafkaSink publishes to a Kafka cluster. One Writer is shared across every
+// topic — kafka-go dispatches to the right partition per message, so there's
+// no need for a per-topic writer the way Pulsar needs a per-topic producer.
+type KafkaSink struct {
+	writer *kafka.Writer
+}
+
+// NewKafkaSink creates a Kafka sink over the given broker addresses.
+func NewKafkaSink(brokers []string) *KafkaSink {
+	return &KafkaSink{
+		writer: &kafka.Writer{
+			Addr:     kafka.TCP(brokers...),
+			Balancer: &kafka.Hash{},
+		},
+	}
+}
+
+// Publish writes one message to topic, keyed for partition affinity.
+func (s *KafkaSink) Publish(ctx context.Context, topic string, key, payload []byte) error {
+	err := s.writer.WriteMessages(ctx, kafka.Message{
+		Topic: topic,
+		Key:   key,
+		Value: payload,
+	})
+	if err != nil {
+		return fmt.Errorf("kafka publish to %s: %w", topic, err)
+	}
+	return nil
+}
+
+// Close flushes and closes the underlying writer.
+func (s *KafkaSink) Close() error {
+	return s.writer.Close()
+}
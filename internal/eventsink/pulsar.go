@@ -0,0 +1,69 @@
+package eventsink
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/apache/pulsar-client-go/pulsar"
+)
+
+// PulsarSink publishes to an Apache Pulsar cluster. Unlike Kafka, Pulsar
+// producers are bound to a single topic, so producers are created lazily
+// per topic and cached.
+type PulsarSink struct {
+	client pulsar.Client
+
+	mu        sync.Mutex
+	producers map[string]pulsar.Producer
+}
+
+// NewPulsarSink connects to the Pulsar cluster at serviceURL (e.g.
+// "pulsar://localhost:6650").
+func NewPulsarSink(serviceURL string) (*PulsarSink, error) {
+	client, err := pulsar.NewClient(pulsar.ClientOptions{URL: serviceURL})
+	if err != nil {
+		return nil, fmt.Errorf("pulsar client: %w", err)
+	}
+	return &PulsarSink{client: client, producers: make(map[string]pulsar.Producer)}, nil
+}
+
+// producerFor returns the cached producer for topic, creating one if this is
+// the first publish to it.
+func (s *PulsarSink) producerFor(topic string) (pulsar.Producer, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if p, ok := s.producers[topic]; ok {
+		return p, nil
+	}
+	p, err := s.client.CreateProducer(pulsar.ProducerOptions{Topic: topic})
+	if err != nil {
+		return nil, fmt.Errorf("create producer for %s: %w", topic, err)
+	}
+	s.producers[topic] = p
+	return p, nil
+}
+
+// Publish sends one message to topic.
+func (s *PulsarSink) Publish(ctx context.Context, topic string, key, payload []byte) error {
+	p, err := s.producerFor(topic)
+	if err != nil {
+		return err
+	}
+	if _, err := p.Send(ctx, &pulsar.ProducerMessage{Key: string(key), Payload: payload}); err != nil {
+		return fmt.Errorf("pulsar publish to %s: %w", topic, err)
+	}
+	return nil
+}
+
+// Close closes every cached producer and the underlying client.
+func (s *PulsarSink) Close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, p := range s.producers {
+		p.Close()
+	}
+	s.client.Close()
+}
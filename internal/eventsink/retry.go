@@ -0,0 +1,103 @@
+package eventsink
+
+import (
+	"context"
+	"log/slog"
+	"math"
+	"time"
+
+	"github.com/albapepper/scoracle-data/internal/errreport"
+)
+
+// Retry tuning: a publish that fails outright is queued for up to
+// maxRetryAttempts more tries on exponential backoff before being dropped,
+// giving at-least-once delivery across a transient broker blip without an
+// unbounded queue backing up memory.
+const (
+	retryQueueSize   = 1000
+	maxRetryAttempts = 5
+	retryBaseDelay   = time.Second
+	retryMaxDelay    = time.Minute
+)
+
+// retryJob is one queued re-publish attempt.
+type retryJob struct {
+	topic   string
+	key     []byte
+	payload []byte
+	attempt int
+}
+
+// RetryingSink wraps another Sink with a bounded in-memory retry queue: a
+// publish failure is queued instead of returned to the caller, and a single
+// background goroutine retries it on exponential backoff. A full queue
+// drops the message (and records it via errreport) rather than blocking the
+// caller — callers care about "accepted for delivery", not "delivered".
+type RetryingSink struct {
+	inner  Sink
+	queue  chan retryJob
+	logger *slog.Logger
+}
+
+// NewRetrying wraps inner with a retry queue of retryQueueSize and starts
+// its background retrier. Intended to be called once per configured sink.
+func NewRetrying(inner Sink, logger *slog.Logger) *RetryingSink {
+	s := &RetryingSink{
+		inner:  inner,
+		queue:  make(chan retryJob, retryQueueSize),
+		logger: logger,
+	}
+	go s.run()
+	return s
+}
+
+// Publish attempts inner.Publish once; on failure it queues the message for
+// retry and returns nil, since at-least-once semantics mean the caller's
+// job (getting the message accepted for eventual delivery) succeeded even
+// though the first attempt didn't land.
+func (s *RetryingSink) Publish(ctx context.Context, topic string, key, payload []byte) error {
+	if err := s.inner.Publish(ctx, topic, key, payload); err != nil {
+		s.enqueue(retryJob{topic: topic, key: key, payload: payload, attempt: 1}, err)
+	}
+	return nil
+}
+
+func (s *RetryingSink) enqueue(job retryJob, cause error) {
+	select {
+	case s.queue <- job:
+	default:
+		s.logger.Warn("eventsink retry queue full, dropping message", "topic", job.topic, "error", cause)
+		errreport.Record("eventsink_queue_full", cause)
+	}
+}
+
+// run drains the retry queue, sleeping out each job's backoff before
+// re-attempting. Runs for the lifetime of the process (the queue is never
+// closed) — matches how other package-level background loops in this repo
+// (e.g. notifications.Dispatcher.Run) run until ctx cancellation, except here
+// there's no ctx to hand a queue-draining goroutine started from New.
+func (s *RetryingSink) run() {
+	for job := range s.queue {
+		time.Sleep(retryBackoff(job.attempt))
+
+		if err := s.inner.Publish(context.Background(), job.topic, job.key, job.payload); err != nil {
+			if job.attempt >= maxRetryAttempts {
+				s.logger.Warn("eventsink publish dropped after max retries", "topic", job.topic, "attempts", job.attempt, "error", err)
+				errreport.Record("eventsink_publish_dropped", err)
+				continue
+			}
+			job.attempt++
+			s.enqueue(job, err)
+		}
+	}
+}
+
+// retryBackoff returns exponential backoff (base retryBaseDelay, capped at
+// retryMaxDelay) for the given attempt count (1-indexed).
+func retryBackoff(attempt int) time.Duration {
+	d := time.Duration(float64(retryBaseDelay) * math.Pow(2, float64(attempt-1)))
+	if d > retryMaxDelay {
+		return retryMaxDelay
+	}
+	return d
+}
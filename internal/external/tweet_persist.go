@@ -0,0 +1,140 @@
+package external
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/albapepper/scoracle-data/internal/db"
+)
+
+// entityNameRefreshInterval bounds how stale the tagger's player/team name
+// list can get. Entities are added rarely (roster moves, expansion), so a
+// coarse refresh is fine — this isn't on the request hot path.
+const entityNameRefreshInterval = 1 * time.Hour
+
+// persistTimeout bounds the upsert so a slow or unreachable database never
+// blocks the foreground feed refresh that triggered it.
+const persistTimeout = 5 * time.Second
+
+// tweetPersister durably stores every tweet returned by a feed refresh, so
+// they remain searchable (via api_tweet_search) after they scroll out of the
+// X List and the in-memory cache. It also tags each tweet with the sport its
+// text most plausibly belongs to, using a regex built from known player/team
+// names.
+type tweetPersister struct {
+	pool *pgxpool.Pool
+
+	mu       sync.RWMutex
+	tagger   *regexp.Regexp
+	sportOf  map[string]string // lowercased matched name -> sport
+	loadedAt time.Time
+}
+
+// newTweetPersister creates a persister bound to pool. The name tagger is
+// lazily loaded on first use rather than in the constructor, since pool may
+// not be reachable yet at startup.
+func newTweetPersister(pool *pgxpool.Pool) *tweetPersister {
+	return &tweetPersister{pool: pool}
+}
+
+// Persist upserts tweets and returns immediately; the actual write runs in a
+// background goroutine with its own bounded timeout, so a slow database
+// never delays the feed response that triggered the refresh.
+func (p *tweetPersister) Persist(tweets []Tweet) {
+	if p == nil || len(tweets) == 0 {
+		return
+	}
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), persistTimeout)
+		defer cancel()
+
+		for _, t := range tweets {
+			sport := p.tagSport(ctx, t.Text)
+			metricsJSON, err := json.Marshal(t.Metrics)
+			if err != nil {
+				log.Printf("[tweet persist] marshal metrics for %s: %v", t.ID, err)
+				continue
+			}
+			var sportArg interface{}
+			if sport != "" {
+				sportArg = sport
+			}
+			_, err = db.ExecTweetUpsert(ctx, p.pool,
+				t.ID, t.Author.ID, t.Author.Username, t.Text, t.CreatedAt, metricsJSON, sportArg)
+			if err != nil {
+				log.Printf("[tweet persist] upsert %s: %v", t.ID, err)
+			}
+		}
+	}()
+}
+
+// tagSport returns the sport whose player/team names best match text, or ""
+// if none match. Matching is a case-insensitive substring search over names
+// loaded from mv_autofill_entities, refreshed at most once per
+// entityNameRefreshInterval.
+func (p *tweetPersister) tagSport(ctx context.Context, text string) string {
+	p.ensureNamesLoaded(ctx)
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.tagger == nil {
+		return ""
+	}
+	match := p.tagger.FindString(strings.ToLower(text))
+	if match == "" {
+		return ""
+	}
+	return p.sportOf[match]
+}
+
+func (p *tweetPersister) ensureNamesLoaded(ctx context.Context) {
+	p.mu.RLock()
+	stale := time.Since(p.loadedAt) >= entityNameRefreshInterval
+	p.mu.RUnlock()
+	if !stale {
+		return
+	}
+
+	var raw []byte
+	if err := db.QueryRowEntityNamesForTagging(ctx, p.pool).Scan(&raw); err != nil {
+		log.Printf("[tweet persist] load entity names for tagging: %v", err)
+		return
+	}
+
+	var rows []struct {
+		Name  string `json:"name"`
+		Sport string `json:"sport"`
+	}
+	if err := json.Unmarshal(raw, &rows); err != nil {
+		log.Printf("[tweet persist] decode entity names for tagging: %v", err)
+		return
+	}
+
+	sportOf := make(map[string]string, len(rows))
+	patterns := make([]string, 0, len(rows))
+	for _, row := range rows {
+		name := strings.ToLower(strings.TrimSpace(row.Name))
+		if name == "" {
+			continue
+		}
+		sportOf[name] = row.Sport
+		patterns = append(patterns, regexp.QuoteMeta(name))
+	}
+	if len(patterns) == 0 {
+		return
+	}
+	tagger := regexp.MustCompile(strings.Join(patterns, "|"))
+
+	p.mu.Lock()
+	p.tagger = tagger
+	p.sportOf = sportOf
+	p.loadedAt = time.Now()
+	p.mu.Unlock()
+}
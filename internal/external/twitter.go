@@ -1,6 +1,7 @@
 package external
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -8,6 +9,11 @@ import (
 	"strings"
 	"sync"
 	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/albapepper/scoracle-data/internal/httpcache"
+	"github.com/albapepper/scoracle-data/internal/metrics"
 )
 
 // ---------------------------------------------------------------------------
@@ -19,6 +25,10 @@ const (
 	twitterTimeout    = 15 * time.Second
 	twitterCacheTTL   = 1 * time.Hour // default feed cache TTL
 	twitterMaxResults = 100
+
+	// backgroundRefreshLead is how far ahead of TTL expiry
+	// StartBackgroundRefresh re-fetches the feed.
+	backgroundRefreshLead = 5 * time.Minute
 )
 
 // ---------------------------------------------------------------------------
@@ -27,6 +37,7 @@ const (
 
 // TweetAuthor is the author of a tweet.
 type TweetAuthor struct {
+	ID              string  `json:"id"`
 	Username        string  `json:"username"`
 	Name            string  `json:"name"`
 	Verified        bool    `json:"verified"`
@@ -60,22 +71,106 @@ type TwitterService struct {
 	listID      string
 	cacheTTL    time.Duration
 	httpClient  *http.Client
+	metrics     *metrics.Registry
+	persister   *tweetPersister
 
 	mu             sync.RWMutex
+	cond           *sync.Cond
 	cachedTweets   []Tweet
 	cacheTimestamp time.Time
+	latestTweetID  string
 }
 
-// NewTwitterService creates a twitter service. bearerToken and listID may be empty.
-func NewTwitterService(bearerToken, listID string) *TwitterService {
-	return &TwitterService{
+// NewTwitterService creates a twitter service. bearerToken and listID may be
+// empty. reg records feed cache age and refresh outcomes; pass metrics.New()
+// if the caller doesn't already have a shared registry. pool, if non-nil,
+// enables durable persistence of every refreshed tweet (see tweet_persist.go);
+// pass nil to keep the service purely in-memory. cacheTransport, if non-nil,
+// makes fetchListTweets' requests conditional-GET aware — the journalist
+// feed's 1-hour TTL (twitterCacheTTL) then costs a 304 instead of a full
+// re-download whenever the upstream content hasn't changed. Pass nil to
+// fetch uncached (the API rarely returns ETag/Last-Modified anyway).
+func NewTwitterService(bearerToken, listID string, reg *metrics.Registry, pool *pgxpool.Pool, cacheTransport *httpcache.Transport) *TwitterService {
+	var transport http.RoundTripper
+	if cacheTransport != nil {
+		transport = cacheTransport
+	}
+	s := &TwitterService{
 		bearerToken: bearerToken,
 		listID:      listID,
 		cacheTTL:    twitterCacheTTL,
 		httpClient: &http.Client{
-			Timeout: twitterTimeout,
+			Timeout:   twitterTimeout,
+			Transport: transport,
 		},
+		metrics: reg,
+	}
+	if pool != nil {
+		s.persister = newTweetPersister(pool)
+	}
+	s.cond = sync.NewCond(&s.mu)
+	return s
+}
+
+// StartBackgroundRefresh proactively refreshes the journalist feed shortly
+// before its TTL expires, so RSS/JSON-Feed readers and long-poll clients
+// never observe a cold cache. Call once at startup; it runs until ctx is
+// canceled. No-op if the service isn't configured.
+func (s *TwitterService) StartBackgroundRefresh(ctx context.Context) {
+	if !s.IsConfigured() {
+		return
+	}
+	interval := s.cacheTTL - backgroundRefreshLead
+	if interval <= 0 {
+		interval = s.cacheTTL / 2
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if _, _, err := s.getOrRefreshFeed(); err != nil {
+					// Best-effort: the next scheduled tick (or the next
+					// foreground request) will retry.
+					continue
+				}
+			}
+		}
+	}()
+}
+
+// WaitForNewTweets blocks until the feed's newest tweet ID differs from
+// afterID (i.e. a refresh produced new tweets), ctx is canceled, or timeout
+// elapses — whichever comes first. Used by the long-poll stream endpoint.
+func (s *TwitterService) WaitForNewTweets(ctx context.Context, afterID string, timeout time.Duration) ([]Tweet, error) {
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	// cond.Wait() has no context support, so a side goroutine translates
+	// waitCtx cancellation (explicit cancel or timeout) into a broadcast.
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-waitCtx.Done():
+			s.cond.Broadcast()
+		case <-done:
+		}
+	}()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for s.latestTweetID == afterID {
+		if err := waitCtx.Err(); err != nil {
+			return s.cachedTweets, err
+		}
+		s.cond.Wait()
 	}
+	return s.cachedTweets, nil
 }
 
 // IsConfigured reports whether bearer token and list ID are both set.
@@ -121,18 +216,7 @@ func (s *TwitterService) GetJournalistFeed(query, sport string, limit int) (map[
 		return nil, err
 	}
 
-	// Filter for query matches (case-insensitive substring).
-	queryLower := strings.ToLower(query)
-	var filtered []Tweet
-	for _, t := range allTweets {
-		if strings.Contains(strings.ToLower(t.Text), queryLower) {
-			filtered = append(filtered, t)
-		}
-	}
-
-	if len(filtered) > limit {
-		filtered = filtered[:limit]
-	}
+	filtered := FilterTweets(allTweets, query, limit)
 
 	var sportVal interface{} = sport
 	if sport == "" {
@@ -152,6 +236,40 @@ func (s *TwitterService) GetJournalistFeed(query, sport string, limit int) (map[
 	}, nil
 }
 
+// FilterTweets returns the tweets whose text contains query (case-insensitive
+// substring match), capped at limit. Shared by GetJournalistFeed and the
+// RSS/JSON-Feed/stream handlers so all four surfaces filter identically.
+func FilterTweets(tweets []Tweet, query string, limit int) []Tweet {
+	queryLower := strings.ToLower(query)
+	var filtered []Tweet
+	for _, t := range tweets {
+		if strings.Contains(strings.ToLower(t.Text), queryLower) {
+			filtered = append(filtered, t)
+		}
+	}
+	if limit > 0 && len(filtered) > limit {
+		filtered = filtered[:limit]
+	}
+	return filtered
+}
+
+// LatestTweetID returns the ID of the newest tweet in the cached feed, or ""
+// if the feed hasn't been fetched yet. Used to seed the long-poll stream's
+// initial "afterID" cursor.
+func (s *TwitterService) LatestTweetID() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.latestTweetID
+}
+
+// Feed returns the current cached journalist feed, refreshing it first if
+// stale. Used by the RSS and JSON-Feed handlers, which serve the whole feed
+// rather than a search.
+func (s *TwitterService) Feed() ([]Tweet, error) {
+	tweets, _, err := s.getOrRefreshFeed()
+	return tweets, err
+}
+
 // ---------------------------------------------------------------------------
 // Internal — feed fetch + cache
 // ---------------------------------------------------------------------------
@@ -162,25 +280,71 @@ func (s *TwitterService) getOrRefreshFeed() ([]Tweet, bool, error) {
 	s.mu.RLock()
 	if s.cachedTweets != nil && time.Since(s.cacheTimestamp) < s.cacheTTL {
 		tweets := s.cachedTweets
+		age := time.Since(s.cacheTimestamp)
 		s.mu.RUnlock()
+		s.recordCacheAge(age)
+		s.recordFeedCache("hit")
 		return tweets, true, nil
 	}
 	s.mu.RUnlock()
 
 	// Fetch fresh feed.
 	tweets, err := s.fetchListTweets()
+	s.recordRefresh(err)
 	if err != nil {
+		s.recordFeedCache("error")
 		return nil, false, err
 	}
+	s.recordFeedCache("miss")
 
 	s.mu.Lock()
 	s.cachedTweets = tweets
 	s.cacheTimestamp = time.Now()
+	if len(tweets) > 0 {
+		s.latestTweetID = tweets[0].ID
+	}
 	s.mu.Unlock()
+	s.cond.Broadcast()
+	s.persister.Persist(tweets)
 
+	s.recordCacheAge(0)
 	return tweets, false, nil
 }
 
+// recordCacheAge reports how stale the currently-served feed is, in seconds.
+func (s *TwitterService) recordCacheAge(age time.Duration) {
+	if s.metrics == nil {
+		return
+	}
+	s.metrics.ObserveHistogram("twitter_feed_cache_age_seconds",
+		"Age of the journalist feed served from cache, in seconds.", nil, age.Seconds())
+}
+
+// recordRefresh counts feed refresh attempts, labeled by outcome.
+func (s *TwitterService) recordRefresh(err error) {
+	if s.metrics == nil {
+		return
+	}
+	outcome := "success"
+	if err != nil {
+		outcome = "error"
+	}
+	s.metrics.IncCounter("twitter_feed_refresh_total", "Journalist feed refresh attempts, labeled by outcome.",
+		map[string]string{"outcome": outcome})
+}
+
+// recordFeedCache counts scoracle_twitter_feed_cache_total, labeled by
+// result: "hit" when getOrRefreshFeed served the in-memory cache within
+// cacheTTL, "miss" when it fetched a fresh feed, or "error" if that fetch
+// failed.
+func (s *TwitterService) recordFeedCache(result string) {
+	if s.metrics == nil {
+		return
+	}
+	s.metrics.IncCounter("scoracle_twitter_feed_cache_total", "Journalist feed cache outcomes, labeled by result.",
+		map[string]string{"result": result})
+}
+
 // fetchListTweets calls GET /2/lists/{id}/tweets.
 func (s *TwitterService) fetchListTweets() ([]Tweet, error) {
 	params := url.Values{}
@@ -263,11 +427,13 @@ func formatTweets(resp *twitterAPIResponse) []Tweet {
 	for _, td := range resp.Data {
 		user := usersMap[td.AuthorID]
 		author := TweetAuthor{
+			ID:       td.AuthorID,
 			Username: "unknown",
 			Name:     "Unknown",
 		}
 		if user != nil {
 			author = TweetAuthor{
+				ID:              user.ID,
 				Username:        user.Username,
 				Name:            user.Name,
 				Verified:        user.Verified,
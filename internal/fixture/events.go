@@ -0,0 +1,43 @@
+package fixture
+
+// EventType identifies which stage of a ProcessPending run an Event reports.
+type EventType string
+
+const (
+	EventGroupStarted   EventType = "group_started"
+	EventGroupCompleted EventType = "group_completed"
+	EventFixtureSeeded  EventType = "fixture_seeded"
+	EventRunComplete    EventType = "run_complete"
+)
+
+// Event is one progress update from ProcessPending, published to the
+// caller's optional events channel so a long-running backfill can be
+// observed (e.g. streamed over SSE) instead of only logged at the end.
+// At most one of Fixture/Summary is set, matching Type; neither is set on
+// group_started/group_completed.
+type Event struct {
+	Type EventType `json:"type"`
+
+	// Group identity — set on group_started/group_completed.
+	Sport        string `json:"sport,omitempty"`
+	Season       int    `json:"season,omitempty"`
+	LeagueID     int    `json:"league_id,omitempty"`
+	FixtureCount int    `json:"fixture_count,omitempty"`
+
+	// Fixture carries the per-fixture outcome on fixture_seeded.
+	Fixture *Result `json:"fixture,omitempty"`
+
+	// Summary carries the final tally on run_complete.
+	Summary *SchedulerResult `json:"summary,omitempty"`
+}
+
+// publish sends an event if events is non-nil, never blocking callers that
+// didn't ask for progress updates. events is buffered generously by the
+// caller (see handler.StreamFixturesProcess), so this does not drop events
+// under normal load; a full channel would mean the consumer has stalled.
+func publish(events chan<- Event, e Event) {
+	if events == nil {
+		return
+	}
+	events <- e
+}
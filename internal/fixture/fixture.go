@@ -25,16 +25,18 @@ const (
 
 // Row represents a fixture row from the database.
 type Row struct {
-	ID             int
-	Sport          string
-	LeagueID       *int
-	Season         int
-	HomeTeamID     int
-	AwayTeamID     int
-	StartTime      time.Time
-	SeedDelayHours int
-	SeedAttempts   int
-	ExternalID     *int
+	ID              int
+	Sport           string
+	LeagueID        *int
+	Season          int
+	HomeTeamID      int
+	AwayTeamID      int
+	StartTime       time.Time
+	SeedDelayHours  int
+	SeedAttempts    int
+	ExternalID      *int
+	LastAttemptedAt *time.Time
+	LastSeedError   *string
 }
 
 // Result tracks the outcome of seeding a single fixture.
@@ -0,0 +1,54 @@
+package fixture
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/albapepper/scoracle-data/internal/metrics"
+)
+
+// reg is the process-wide metrics registry for fixture processing, set once
+// at startup via SetMetrics. A nil reg (the default) makes every
+// instrumented call a no-op. See internal/seed.SetMetrics for why this is a
+// package-level var rather than a parameter threaded through every call.
+var reg *metrics.Registry
+
+// backlog and workerDepth back the gauges registered in SetMetrics — a
+// plain int64 sampled on scrape, mirroring how internal/api/server.go samples
+// pool.Stat() via RegisterGaugeFunc rather than pushing gauge updates in.
+var (
+	backlog     int64
+	workerDepth int64
+)
+
+// SetMetrics wires a metrics registry into subsequent ProcessPending runs
+// and registers its gauges. Call once from cmd/ingest/main.go before
+// processing fixtures.
+func SetMetrics(r *metrics.Registry) {
+	reg = r
+	r.RegisterGaugeFunc("scoracle_fixture_pending_backlog",
+		"Pending fixtures found by the most recent ProcessPending run.",
+		func() float64 { return float64(atomic.LoadInt64(&backlog)) })
+	r.RegisterGaugeFunc("scoracle_fixture_worker_pool_depth",
+		"Worker goroutines active in the most recent ProcessPending run.",
+		func() float64 { return float64(atomic.LoadInt64(&workerDepth)) })
+}
+
+func setBacklog(n int)     { atomic.StoreInt64(&backlog, int64(n)) }
+func setWorkerDepth(n int) { atomic.StoreInt64(&workerDepth, int64(n)) }
+
+// observeGroup records scoracle_fixture_groups_processed_total{sport,result}
+// and scoracle_fixture_group_duration_seconds for one group's seed.
+func observeGroup(sport string, success bool, start time.Time) {
+	if reg == nil {
+		return
+	}
+	result := "success"
+	if !success {
+		result = "failure"
+	}
+	reg.IncCounter("scoracle_fixture_groups_processed_total", "Fixture groups seeded, labeled by sport and result.",
+		map[string]string{"sport": sport, "result": result})
+	reg.ObserveDuration("scoracle_fixture_group_duration_seconds", "Fixture group seed latency in seconds.",
+		nil, start)
+}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 
 	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/albapepper/scoracle-data/internal/db"
 )
 
 // GetPending returns fixtures ready for seeding.
@@ -23,7 +25,7 @@ func GetPending(ctx context.Context, pool *pgxpool.Pool, sport string, limit, ma
 		sportParam = nil
 	}
 
-	rows, err := pool.Query(ctx, "get_pending_fixtures", sportParam, limit, maxRetries)
+	rows, err := db.QueryGetPendingFixtures(ctx, pool, sportParam, limit, maxRetries)
 	if err != nil {
 		return nil, fmt.Errorf("get pending fixtures: %w", err)
 	}
@@ -47,7 +49,7 @@ func GetPending(ctx context.Context, pool *pgxpool.Pool, sport string, limit, ma
 // GetByID returns a single fixture row.
 func GetByID(ctx context.Context, pool *pgxpool.Pool, id int) (*Row, error) {
 	var f Row
-	err := pool.QueryRow(ctx, "fixture_by_id", id).Scan(
+	err := db.QueryRowFixtureById(ctx, pool, id).Scan(
 		&f.ID, &f.Sport, &f.LeagueID, &f.Season,
 		&f.HomeTeamID, &f.AwayTeamID, &f.StartTime,
 		&f.SeedDelayHours, &f.SeedAttempts, &f.ExternalID,
@@ -64,12 +66,14 @@ func MarkSeeded(ctx context.Context, pool *pgxpool.Pool, id int) error {
 	return err
 }
 
-// RecordFailure increments seed_attempts and records the error.
+// RecordFailure increments seed_attempts and records the error and attempt
+// timestamp, which the retry queue uses to schedule the next backoff.
 func RecordFailure(ctx context.Context, pool *pgxpool.Pool, id int, errMsg string) error {
 	_, err := pool.Exec(ctx, `
 		UPDATE fixtures
 		SET seed_attempts = seed_attempts + 1,
 			last_seed_error = $2,
+			last_attempted_at = NOW(),
 			updated_at = NOW()
 		WHERE id = $1`, id, errMsg)
 	return err
@@ -0,0 +1,254 @@
+package fixture
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"math/rand"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/albapepper/scoracle-data/internal/db"
+	"github.com/albapepper/scoracle-data/internal/provider/bdl"
+	"github.com/albapepper/scoracle-data/internal/provider/breaker"
+	"github.com/albapepper/scoracle-data/internal/provider/registry"
+)
+
+// --------------------------------------------------------------------------
+// Retry policy
+// --------------------------------------------------------------------------
+
+const (
+	retryBaseDelay    = 30 * time.Second
+	retryMaxDelay     = 6 * time.Hour
+	retryMaxAttempts  = 10 // attempts after which a transient failure is given up on
+	retryLoopInterval = time.Minute
+)
+
+// errorClass categorizes a seed failure for retry purposes.
+type errorClass int
+
+const (
+	classTransient errorClass = iota
+	classPermanent
+)
+
+// classifyError decides whether a seed failure is worth retrying.
+// Rate limits, server errors, and network failures are transient; unknown
+// sports, unconfigured handlers, and 404s are permanent — retrying them
+// would never succeed.
+func classifyError(err error) errorClass {
+	if err == nil {
+		return classTransient
+	}
+	if errors.Is(err, bdl.ErrRateLimited) || errors.Is(err, bdl.ErrServerError) || errors.Is(err, breaker.ErrOpen) {
+		return classTransient
+	}
+	if errors.Is(err, bdl.ErrNotFound) {
+		return classPermanent
+	}
+	msg := strings.ToLower(err.Error())
+	switch {
+	case strings.Contains(msg, "unknown sport"),
+		strings.Contains(msg, "handler not configured"),
+		strings.Contains(msg, "no sportmonks_id"),
+		strings.Contains(msg, "no provider season found"):
+		return classPermanent
+	default:
+		return classTransient
+	}
+}
+
+// backoffDelay returns the exponential backoff (base 30s, cap 6h) with full
+// jitter for the given attempt count (1-indexed).
+func backoffDelay(attempt int) time.Duration {
+	if attempt < 1 {
+		attempt = 1
+	}
+	raw := float64(retryBaseDelay) * math.Pow(2, float64(attempt-1))
+	if raw > float64(retryMaxDelay) {
+		raw = float64(retryMaxDelay)
+	}
+	return time.Duration(rand.Int63n(int64(raw) + 1))
+}
+
+// --------------------------------------------------------------------------
+// Metrics
+// --------------------------------------------------------------------------
+
+// RetryMetrics holds Prometheus-style counters for the retry loop, keyed by
+// sport. Exported via Snapshot so a /metrics handler can render them.
+type RetryMetrics struct {
+	mu           sync.Mutex
+	successTotal map[string]int64
+	giveupTotal  map[string]int64
+}
+
+// NewRetryMetrics creates an empty metrics set.
+func NewRetryMetrics() *RetryMetrics {
+	return &RetryMetrics{
+		successTotal: make(map[string]int64),
+		giveupTotal:  make(map[string]int64),
+	}
+}
+
+func (m *RetryMetrics) recordSuccess(sport string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.successTotal[sport]++
+}
+
+func (m *RetryMetrics) recordGiveup(sport string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.giveupTotal[sport]++
+}
+
+// Snapshot returns copies of the counters as
+// seed_retry_success_total{sport=...} / seed_retry_giveup_total{sport=...}.
+func (m *RetryMetrics) Snapshot() (success, giveup map[string]int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	success = make(map[string]int64, len(m.successTotal))
+	giveup = make(map[string]int64, len(m.giveupTotal))
+	for k, v := range m.successTotal {
+		success[k] = v
+	}
+	for k, v := range m.giveupTotal {
+		giveup[k] = v
+	}
+	return success, giveup
+}
+
+// --------------------------------------------------------------------------
+// Retrier
+// --------------------------------------------------------------------------
+
+// Retrier re-attempts failed fixture seeds on an exponential backoff
+// schedule and dead-letters permanent failures.
+type Retrier struct {
+	Metrics *RetryMetrics
+}
+
+// NewRetrier creates a Retrier with fresh metrics.
+func NewRetrier() *Retrier {
+	return &Retrier{Metrics: NewRetryMetrics()}
+}
+
+// RunRetryLoop polls for retryable fixtures every retryLoopInterval until ctx
+// is canceled. Intended to run as a background goroutine alongside the
+// scheduler.
+func (rt *Retrier) RunRetryLoop(ctx context.Context, pool *pgxpool.Pool, reg *registry.Registry, logger *slog.Logger) {
+	ticker := time.NewTicker(retryLoopInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			logger.Info("Retry loop stopped")
+			return
+		case <-ticker.C:
+			if err := rt.runOnce(ctx, pool, reg, logger); err != nil {
+				logger.Warn("Retry loop iteration failed", "error", err)
+			}
+		}
+	}
+}
+
+// runOnce processes all fixtures currently due for retry.
+func (rt *Retrier) runOnce(ctx context.Context, pool *pgxpool.Pool, reg *registry.Registry, logger *slog.Logger) error {
+	candidates, err := GetRetryCandidates(ctx, pool, defaultMaxFixtures)
+	if err != nil {
+		return fmt.Errorf("get retry candidates: %w", err)
+	}
+
+	for _, f := range candidates {
+		due := f.LastAttemptedAt == nil
+		if f.LastAttemptedAt != nil {
+			due = time.Since(*f.LastAttemptedAt) >= backoffDelay(f.SeedAttempts)
+		}
+		if !due {
+			continue
+		}
+
+		logger.Info("Retrying fixture seed", "fixture_id", f.ID, "sport", f.Sport, "attempt", f.SeedAttempts+1)
+		result := SeedFixture(ctx, pool, reg, f.ID, true, logger)
+
+		if result.Success {
+			rt.Metrics.recordSuccess(f.Sport)
+			continue
+		}
+
+		class := classifyError(errors.New(result.Error))
+		if class == classPermanent || f.SeedAttempts+1 >= retryMaxAttempts {
+			if err := MoveToDeadLetter(ctx, pool, f.ID, result.Error); err != nil {
+				logger.Warn("Failed to dead-letter fixture", "fixture_id", f.ID, "error", err)
+				continue
+			}
+			rt.Metrics.recordGiveup(f.Sport)
+			logger.Warn("Fixture moved to dead letter", "fixture_id", f.ID, "sport", f.Sport, "reason", result.Error)
+		}
+	}
+
+	return nil
+}
+
+// --------------------------------------------------------------------------
+// Queries
+// --------------------------------------------------------------------------
+
+// GetRetryCandidates returns unseeded fixtures that have failed at least
+// once, least-recently-attempted first (rows with no recorded attempt time
+// lead).
+func GetRetryCandidates(ctx context.Context, pool *pgxpool.Pool, limit int) ([]Row, error) {
+	if limit == 0 {
+		limit = defaultMaxFixtures
+	}
+
+	rows, err := db.QueryGetRetryCandidateFixtures(ctx, pool, limit)
+	if err != nil {
+		return nil, fmt.Errorf("get retry candidates: %w", err)
+	}
+	defer rows.Close()
+
+	var fixtures []Row
+	for rows.Next() {
+		var f Row
+		if err := rows.Scan(
+			&f.ID, &f.Sport, &f.LeagueID, &f.Season,
+			&f.HomeTeamID, &f.AwayTeamID, &f.StartTime,
+			&f.SeedDelayHours, &f.SeedAttempts, &f.ExternalID,
+			&f.LastAttemptedAt, &f.LastSeedError,
+		); err != nil {
+			return nil, fmt.Errorf("scan retry candidate: %w", err)
+		}
+		fixtures = append(fixtures, f)
+	}
+	return fixtures, rows.Err()
+}
+
+// MoveToDeadLetter records a permanently-failed fixture in seed_dead_letter
+// and marks it so the scheduler stops retrying it.
+func MoveToDeadLetter(ctx context.Context, pool *pgxpool.Pool, fixtureID int, reason string) error {
+	_, err := pool.Exec(ctx, `
+		INSERT INTO seed_dead_letter (fixture_id, reason, dead_lettered_at)
+		VALUES ($1, $2, NOW())
+		ON CONFLICT (fixture_id) DO UPDATE SET
+			reason = EXCLUDED.reason,
+			dead_lettered_at = EXCLUDED.dead_lettered_at`,
+		fixtureID, reason)
+	if err != nil {
+		return fmt.Errorf("insert dead letter: %w", err)
+	}
+
+	_, err = pool.Exec(ctx, `UPDATE fixtures SET status = 'dead_letter' WHERE id = $1`, fixtureID)
+	if err != nil {
+		return fmt.Errorf("mark fixture dead_letter: %w", err)
+	}
+	return nil
+}
@@ -8,22 +8,32 @@ import (
 	"time"
 
 	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/albapepper/scoracle-data/internal/provider/registry"
+	"github.com/albapepper/scoracle-data/internal/webhook"
 )
 
 // ProcessPending finds pending fixtures and seeds them.
 // Groups by (sport, season, league_id) to deduplicate API calls — one seed
 // per group instead of per fixture. Uses a worker pool for concurrency
-// across groups.
+// across groups. events receives group_started/group_completed/
+// fixture_seeded/run_complete progress updates as the run proceeds; pass
+// nil for the original fire-and-log-at-the-end behavior. Independent of
+// events, every fixture_seeded and the final run_complete also fan out to
+// webhook.Notify, so external subscribers (see internal/webhook) hear about
+// fresh stats without polling. A run that returns early (no pending
+// fixtures, or the lookup failed) sends its run_complete to events only.
 func ProcessPending(
 	ctx context.Context,
 	pool *pgxpool.Pool,
-	deps *Deps,
+	reg *registry.Registry,
 	sport string,
 	maxFixtures int,
 	maxRetries int,
 	workers int,
 	recalcPercentiles bool,
 	logger *slog.Logger,
+	events chan<- Event,
 ) SchedulerResult {
 	start := time.Now()
 	var result SchedulerResult
@@ -32,13 +42,16 @@ func ProcessPending(
 	if err != nil {
 		result.Errors = append(result.Errors, err.Error())
 		result.Duration = time.Since(start)
+		publish(events, Event{Type: EventRunComplete, Summary: &result})
 		return result
 	}
 
 	result.FixturesFound = len(pending)
+	setBacklog(len(pending))
 	if len(pending) == 0 {
 		logger.Info("No pending fixtures to seed")
 		result.Duration = time.Since(start)
+		publish(events, Event{Type: EventRunComplete, Summary: &result})
 		return result
 	}
 
@@ -67,6 +80,7 @@ func ProcessPending(
 	if workers > len(groups) {
 		workers = len(groups)
 	}
+	setWorkerDepth(workers)
 
 	type groupWork struct {
 		key      groupKey
@@ -89,7 +103,17 @@ func ProcessPending(
 			for work := range ch {
 				// Seed using the first fixture as representative
 				representative := work.fixtures[0]
-				seedResult := SeedFixture(ctx, pool, deps, representative.ID, recalcPercentiles, logger)
+				publish(events, Event{
+					Type:         EventGroupStarted,
+					Sport:        work.key.Sport,
+					Season:       work.key.Season,
+					LeagueID:     work.key.LeagueID,
+					FixtureCount: len(work.fixtures),
+				})
+
+				groupStart := time.Now()
+				seedResult := SeedFixture(ctx, pool, reg, representative.ID, recalcPercentiles, logger)
+				observeGroup(representative.Sport, seedResult.Success, groupStart)
 
 				mu.Lock()
 				for _, f := range work.fixtures {
@@ -124,7 +148,17 @@ func ProcessPending(
 						result.FixturesFailed++
 						result.Errors = append(result.Errors, fmt.Sprintf("fixture %d: %s", f.ID, r.Error))
 					}
+
+					publish(events, Event{Type: EventFixtureSeeded, Fixture: &r})
+					webhook.Notify(ctx, pool, logger, webhook.EventFixtureSeeded, r)
 				}
+				publish(events, Event{
+					Type:         EventGroupCompleted,
+					Sport:        work.key.Sport,
+					Season:       work.key.Season,
+					LeagueID:     work.key.LeagueID,
+					FixtureCount: len(work.fixtures),
+				})
 				mu.Unlock()
 			}
 		}()
@@ -134,5 +168,7 @@ func ProcessPending(
 	result.Duration = time.Since(start)
 
 	logger.Info("Scheduler run complete", "summary", result.Summary())
+	publish(events, Event{Type: EventRunComplete, Summary: &result})
+	webhook.Notify(ctx, pool, logger, webhook.EventRunComplete, result)
 	return result
 }
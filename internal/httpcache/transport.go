@@ -0,0 +1,283 @@
+// Package httpcache provides an http.RoundTripper that caches GET responses
+// in an in-process, byte-bounded LRU, honoring ETag/If-None-Match and
+// Last-Modified/If-Modified-Since so repeat fetches of the same upstream
+// URL (Google News RSS windows, the journalist feed) cost a 304 instead of
+// a full re-download once their Cache-Control freshness window expires.
+package httpcache
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CacheStatusHeader is set on every response Transport returns, so callers
+// can read resp.Header.Get(CacheStatusHeader) and surface it (e.g. into a
+// JSON response's meta) without threading cache state through separately.
+const CacheStatusHeader = "X-Upstream-Cache"
+
+// Cache status values for CacheStatusHeader.
+const (
+	StatusHit         = "HIT"         // served from cache, no request made
+	StatusMiss        = "MISS"        // no usable cache entry; fetched in full
+	StatusRevalidated = "REVALIDATED" // conditional GET came back 304
+)
+
+// statusSinkKey is the context key WithStatusSink stores a *string under.
+type statusSinkKey struct{}
+
+// WithStatusSink returns a context that makes Transport write the cache
+// status (StatusHit/StatusMiss/StatusRevalidated) of any request issued
+// with it into *status, so a caller several layers above the http.Client
+// (e.g. news.Service.GetEntityNews building its response meta) can observe
+// it without Provider.Fetch's signature carrying cache-transport details.
+func WithStatusSink(ctx context.Context, status *string) context.Context {
+	return context.WithValue(ctx, statusSinkKey{}, status)
+}
+
+func reportStatus(req *http.Request, status string) {
+	if sink, ok := req.Context().Value(statusSinkKey{}).(*string); ok && sink != nil {
+		*sink = status
+	}
+}
+
+// entry is one cached response, keyed by request URL.
+type entry struct {
+	status       int
+	header       http.Header
+	body         []byte
+	etag         string
+	lastModified string
+	fetchedAt    time.Time
+	maxAge       time.Duration // 0 means always revalidate, never serve a bare HIT
+	size         int64
+}
+
+func (e *entry) fresh() bool {
+	return e.maxAge > 0 && time.Since(e.fetchedAt) < e.maxAge
+}
+
+func (e *entry) toResponse(req *http.Request, cacheStatus string) *http.Response {
+	header := e.header.Clone()
+	header.Set(CacheStatusHeader, cacheStatus)
+	return &http.Response{
+		Status:        http.StatusText(e.status),
+		StatusCode:    e.status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(e.body)),
+		ContentLength: int64(len(e.body)),
+		Request:       req,
+	}
+}
+
+// Transport wraps base (http.DefaultTransport if nil) with an in-process
+// LRU cache bounded by maxBytes total response size. Only GET responses
+// carrying an ETag or Last-Modified header are cached; everything else
+// passes through untouched (but still gets CacheStatusHeader set to MISS).
+type Transport struct {
+	base     http.RoundTripper
+	maxBytes int64
+
+	mu       sync.Mutex
+	order    *list.List // front = most recently used
+	items    map[string]*list.Element
+	curBytes int64
+
+	hits, misses, revalidations int64
+}
+
+// NewTransport returns a Transport bounded to maxMB megabytes of cached
+// response bodies. base may be nil to use http.DefaultTransport.
+func NewTransport(base http.RoundTripper, maxMB int) *Transport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &Transport{
+		base:     base,
+		maxBytes: int64(maxMB) * 1024 * 1024,
+		order:    list.New(),
+		items:    make(map[string]*list.Element),
+	}
+}
+
+// Stats reports cache hit/miss/revalidation counts and current byte usage,
+// for NewsService.Status() and similar.
+func (t *Transport) Stats() map[string]interface{} {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return map[string]interface{}{
+		"hits":         t.hits,
+		"misses":       t.misses,
+		"revalidated":  t.revalidations,
+		"bytes":        t.curBytes,
+		"max_bytes":    t.maxBytes,
+		"cached_items": t.order.Len(),
+	}
+}
+
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet {
+		resp, err := t.base.RoundTrip(req)
+		if err != nil {
+			return nil, err
+		}
+		resp.Header.Set(CacheStatusHeader, StatusMiss)
+		return resp, nil
+	}
+	key := req.URL.String()
+
+	t.mu.Lock()
+	el, ok := t.items[key]
+	t.mu.Unlock()
+
+	if ok {
+		cached := el.Value.(*entry)
+		if cached.fresh() {
+			t.mu.Lock()
+			t.hits++
+			t.order.MoveToFront(el)
+			t.mu.Unlock()
+			reportStatus(req, StatusHit)
+			return cached.toResponse(req, StatusHit), nil
+		}
+
+		creq := req.Clone(req.Context())
+		if cached.etag != "" {
+			creq.Header.Set("If-None-Match", cached.etag)
+		}
+		if cached.lastModified != "" {
+			creq.Header.Set("If-Modified-Since", cached.lastModified)
+		}
+
+		resp, err := t.base.RoundTrip(creq)
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode == http.StatusNotModified {
+			resp.Body.Close()
+			cached.fetchedAt = time.Now()
+			cached.maxAge = maxAgeOf(resp.Header)
+			t.mu.Lock()
+			t.revalidations++
+			t.order.MoveToFront(el)
+			t.mu.Unlock()
+			reportStatus(req, StatusRevalidated)
+			return cached.toResponse(req, StatusRevalidated), nil
+		}
+		return t.storeAndReturn(req, key, resp, StatusMiss)
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	return t.storeAndReturn(req, key, resp, StatusMiss)
+}
+
+// storeAndReturn caches resp under key if it's a cacheable 200 (carries an
+// ETag or Last-Modified), then returns it with CacheStatusHeader set to
+// status. Uncacheable or non-200 responses pass through untouched besides
+// the header.
+func (t *Transport) storeAndReturn(req *http.Request, key string, resp *http.Response, status string) (*http.Response, error) {
+	reportStatus(req, status)
+	if resp.StatusCode != http.StatusOK {
+		resp.Header.Set(CacheStatusHeader, status)
+		t.mu.Lock()
+		t.misses++
+		t.mu.Unlock()
+		return resp, nil
+	}
+
+	etag := resp.Header.Get("ETag")
+	lastMod := resp.Header.Get("Last-Modified")
+	if etag == "" && lastMod == "" {
+		resp.Header.Set(CacheStatusHeader, status)
+		t.mu.Lock()
+		t.misses++
+		t.mu.Unlock()
+		return resp, nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	e := &entry{
+		status:       resp.StatusCode,
+		header:       resp.Header.Clone(),
+		body:         body,
+		etag:         etag,
+		lastModified: lastMod,
+		fetchedAt:    time.Now(),
+		maxAge:       maxAgeOf(resp.Header),
+		size:         int64(len(body)),
+	}
+	t.store(key, e)
+
+	t.mu.Lock()
+	t.misses++
+	t.mu.Unlock()
+
+	return e.toResponse(req, status), nil
+}
+
+// store inserts or replaces key's entry, evicting least-recently-used
+// entries until curBytes fits within maxBytes.
+func (t *Transport) store(key string, e *entry) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if el, ok := t.items[key]; ok {
+		t.curBytes -= el.Value.(*entry).size
+		el.Value = e
+		t.order.MoveToFront(el)
+	} else {
+		el := t.order.PushFront(e)
+		t.items[key] = el
+	}
+	t.curBytes += e.size
+
+	for t.curBytes > t.maxBytes && t.order.Len() > 1 {
+		oldest := t.order.Back()
+		if oldest == nil {
+			break
+		}
+		oe := t.order.Remove(oldest).(*entry)
+		t.curBytes -= oe.size
+		for k, el := range t.items {
+			if el == oldest {
+				delete(t.items, k)
+				break
+			}
+		}
+	}
+}
+
+// maxAgeOf parses Cache-Control: max-age=N from header, returning 0 (always
+// revalidate) if absent or unparsable.
+func maxAgeOf(header http.Header) time.Duration {
+	cc := header.Get("Cache-Control")
+	for _, directive := range strings.Split(cc, ",") {
+		directive = strings.TrimSpace(directive)
+		if !strings.HasPrefix(directive, "max-age=") {
+			continue
+		}
+		n, err := strconv.Atoi(strings.TrimPrefix(directive, "max-age="))
+		if err != nil || n <= 0 {
+			return 0
+		}
+		return time.Duration(n) * time.Second
+	}
+	return 0
+}
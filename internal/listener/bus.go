@@ -0,0 +1,120 @@
+package listener
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sync"
+)
+
+// subscriberBuffer bounds how many un-handled notifications a single
+// subscriber can queue before newer ones on that channel are dropped — a
+// slow handler shouldn't stall the shared LISTEN connection or other
+// subscribers on the same channel.
+const subscriberBuffer = 32
+
+// Handler processes one notification's raw JSON payload. Returned errors
+// are logged, not retried — Postgres doesn't replay NOTIFY payloads, so
+// there's nothing to retry against.
+type Handler func(ctx context.Context, payload []byte) error
+
+// subscription is one Subscribe call: a buffered mailbox plus the handler
+// that drains it, so a slow handler backs up its own queue instead of
+// blocking notification delivery to other subscribers.
+type subscription struct {
+	ch      chan []byte
+	handler Handler
+}
+
+// Bus fans Postgres NOTIFY payloads out to in-process subscribers keyed by
+// channel name, over a single dedicated pgx connection — one reconnecting
+// LISTEN loop instead of one per event type. Subscribers register with
+// Subscribe (raw payload) or RegisterCodec (JSON-decoded into T). Start
+// issues LISTEN only for the channels subscribed when it (re)connects, so a
+// channel first subscribed after Start receives nothing until a reconnect.
+type Bus struct {
+	mu     sync.RWMutex
+	subs   map[string][]*subscription
+	logger *slog.Logger
+}
+
+// NewBus creates an empty Bus.
+func NewBus(logger *slog.Logger) *Bus {
+	return &Bus{subs: make(map[string][]*subscription), logger: logger}
+}
+
+// Subscribe registers handler to run for every notification on channel.
+// Each subscription gets its own buffered mailbox and drain goroutine, so
+// one slow or erroring subscriber doesn't affect others on the same
+// channel. The drain goroutine runs until ctx is cancelled.
+func (b *Bus) Subscribe(ctx context.Context, channel string, handler Handler) {
+	sub := &subscription{ch: make(chan []byte, subscriberBuffer), handler: handler}
+
+	b.mu.Lock()
+	b.subs[channel] = append(b.subs[channel], sub)
+	b.mu.Unlock()
+
+	go b.drain(ctx, channel, sub)
+}
+
+// drain invokes sub.handler for every payload delivered to sub.ch until ctx
+// is cancelled or the bus shuts the mailbox down.
+func (b *Bus) drain(ctx context.Context, channel string, sub *subscription) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case payload, ok := <-sub.ch:
+			if !ok {
+				return
+			}
+			if err := sub.handler(ctx, payload); err != nil {
+				b.logger.Warn("notification handler failed", "channel", channel, "error", err)
+			}
+		}
+	}
+}
+
+// RegisterCodec subscribes a typed handler on channel: each notification's
+// JSON payload is unmarshalled into T before handle runs, so most
+// subscribers never touch raw bytes. It's a thin wrapper over Subscribe —
+// Subscribe itself does no decoding.
+func RegisterCodec[T any](ctx context.Context, b *Bus, channel string, handle func(ctx context.Context, event T) error) {
+	b.Subscribe(ctx, channel, func(ctx context.Context, payload []byte) error {
+		var event T
+		if err := json.Unmarshal(payload, &event); err != nil {
+			return fmt.Errorf("decode %s payload: %w", channel, err)
+		}
+		return handle(ctx, event)
+	})
+}
+
+// channels returns the distinct channel names with at least one subscriber,
+// for issuing LISTEN on (re)connect.
+func (b *Bus) channels() []string {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	names := make([]string, 0, len(b.subs))
+	for name := range b.subs {
+		names = append(names, name)
+	}
+	return names
+}
+
+// dispatch fans payload out to every subscriber on channel. A full mailbox
+// drops the notification for that subscriber rather than blocking the
+// shared LISTEN connection.
+func (b *Bus) dispatch(channel string, payload []byte) {
+	b.mu.RLock()
+	subs := b.subs[channel]
+	b.mu.RUnlock()
+
+	for _, sub := range subs {
+		select {
+		case sub.ch <- payload:
+		default:
+			b.logger.Warn("subscriber mailbox full, dropping notification", "channel", channel)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package listener
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/albapepper/scoracle-data/internal/notifications"
+)
+
+// MilestoneChannel is the Postgres NOTIFY channel milestone crossings are
+// published on. Exported so other subscribers (e.g. wsnotify.Hub) can
+// register against it by name without guessing the literal.
+const MilestoneChannel = "milestone_reached"
+
+// MilestoneEvent is the JSON payload from pg_notify('milestone_reached', ...).
+type MilestoneEvent struct {
+	EntityType string  `json:"entity_type"`
+	EntityID   int     `json:"entity_id"`
+	Sport      string  `json:"sport"`
+	Season     int     `json:"season"`
+	StatKey    string  `json:"stat_key"`
+	Percentile float64 `json:"percentile"`
+	Timestamp  int64   `json:"ts"`
+}
+
+// RegisterMilestoneHandler subscribes the milestone_reached channel on bus:
+// when a percentile milestone is reached (>= 90th), the Postgres trigger
+// fires pg_notify and this handler resolves followers and dispatches
+// push/webhook notifications via senders. Call before bus.Start.
+func RegisterMilestoneHandler(ctx context.Context, bus *Bus, pool *pgxpool.Pool, senders *notifications.SenderRegistry, logger *slog.Logger) {
+	RegisterCodec(ctx, bus, MilestoneChannel, func(ctx context.Context, event MilestoneEvent) error {
+		handleMilestone(ctx, pool, senders, event, logger)
+		return nil
+	})
+}
+
+// handleMilestone resolves followers for the entity and dispatches push
+// notifications for the milestone crossing, routed per follower device to
+// whichever Sender in senders matches its platform.
+func handleMilestone(ctx context.Context, pool *pgxpool.Pool, senders *notifications.SenderRegistry, event MilestoneEvent, logger *slog.Logger) {
+	publishMilestone(ctx, event, logger)
+
+	// Find followers for this entity
+	followers, err := notifications.GetFollowers(ctx, pool, event.EntityType, event.EntityID, event.Sport)
+	if err != nil {
+		logger.Warn("Failed to get followers for milestone",
+			"entity_type", event.EntityType, "entity_id", event.EntityID, "error", err)
+		return
+	}
+	if len(followers) == 0 {
+		return
+	}
+
+	// Resolve entity name and stat display name for the message
+	entityName, _ := notifications.GetEntityName(ctx, pool, event.EntityType, event.EntityID, event.Sport)
+	statDisplay, _ := notifications.GetStatDisplayName(ctx, pool, event.Sport, event.StatKey, event.EntityType)
+
+	pctile := int(event.Percentile)
+	suffix := ordinalSuffix(pctile)
+	message := fmt.Sprintf("%s is now %d%s percentile in %s", entityName, pctile, suffix, statDisplay)
+
+	data := map[string]string{
+		"entity_type": event.EntityType,
+		"entity_id":   fmt.Sprintf("%d", event.EntityID),
+		"sport":       event.Sport,
+		"stat_key":    event.StatKey,
+		"percentile":  fmt.Sprintf("%.1f", event.Percentile),
+	}
+
+	if senders == nil {
+		logger.Info("Milestone notification (push disabled)",
+			"message", message, "followers", len(followers))
+		return
+	}
+
+	// Dispatch to each follower's devices
+	sent, failed := 0, 0
+	for _, f := range followers {
+		tokens, err := notifications.GetDeviceTokens(ctx, pool, f.UserID)
+		if err != nil || len(tokens) == 0 {
+			continue
+		}
+
+		if ok, failedPlatforms := senders.Dispatch(ctx, tokens, "Scoracle", message, data); ok {
+			sent++
+			if len(failedPlatforms) > 0 {
+				logger.Warn("partial milestone send failure", "user_id", f.UserID, "failed_platforms", failedPlatforms)
+			}
+		} else {
+			logger.Warn("milestone send failed", "user_id", f.UserID, "failed_platforms", failedPlatforms)
+			failed++
+		}
+	}
+
+	if sent+failed > 0 {
+		logger.Info("Milestone notifications dispatched",
+			"message", message, "sent", sent, "failed", failed)
+	}
+}
+
+func ordinalSuffix(n int) string {
+	if n%100 >= 11 && n%100 <= 13 {
+		return "th"
+	}
+	switch n % 10 {
+	case 1:
+		return "st"
+	case 2:
+		return "nd"
+	case 3:
+		return "rd"
+	default:
+		return "th"
+	}
+}
@@ -0,0 +1,46 @@
+package listener
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"time"
+
+	"github.com/albapepper/scoracle-data/internal/eventsink"
+)
+
+// sink is the process-wide event sink for publishing MilestoneEvents
+// alongside dispatching push notifications, set once at startup via
+// SetEventSink. A nil sink (the default) makes publishing a no-op.
+var sink eventsink.Sink
+
+// SetEventSink wires an event sink into subsequent handleMilestone calls.
+func SetEventSink(s eventsink.Sink) {
+	sink = s
+}
+
+// publishMilestone publishes event to the configured sink as a
+// schema-versioned envelope, keyed by its (fixture_id, entity_id, stat_key)
+// idempotency key — fixture_id is unknown at the milestone layer, so entity
+// and stat alone key it, same as EntityID/StatKey already uniquely identify
+// a percentile crossing within a sport/season. Errors are logged, not
+// returned — a sink outage shouldn't block push delivery.
+func publishMilestone(ctx context.Context, event MilestoneEvent, logger *slog.Logger) {
+	if sink == nil {
+		return
+	}
+	idempotencyKey := eventsink.IdempotencyKey(0, event.EntityID, event.StatKey)
+	env, err := eventsink.NewEnvelope("milestone_reached", idempotencyKey, time.Now(), event)
+	if err != nil {
+		logger.Warn("build milestone envelope", "error", err)
+		return
+	}
+	payload, err := json.Marshal(env)
+	if err != nil {
+		logger.Warn("marshal milestone envelope", "error", err)
+		return
+	}
+	if err := sink.Publish(ctx, "milestone_reached", []byte(idempotencyKey), payload); err != nil {
+		logger.Warn("publish milestone event", "error", err)
+	}
+}
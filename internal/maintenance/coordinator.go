@@ -0,0 +1,157 @@
+package maintenance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Coordinator elects a single leader per named task across replicas, so
+// running more than one Go process for HA (required for LISTEN/NOTIFY
+// availability) doesn't double-execute maintenance work — duplicate
+// notification rows from two catch-up sweeps, racing DELETE storms from two
+// cleanup passes. See PostgresCoordinator for the production implementation
+// and NoopCoordinator for single-node dev, or to substitute in tests.
+type Coordinator interface {
+	// TryAcquire attempts to acquire task's lease for ttl. Returns whether
+	// this replica won it — false either means an unexpired lease already
+	// exists (another replica's, or this replica's own from an earlier call,
+	// which is not extended), or the attempt itself failed (check err).
+	TryAcquire(ctx context.Context, task string, ttl time.Duration) (bool, error)
+
+	// WithLease is like TryAcquire, but fn only runs if this replica becomes
+	// leader, and runs inside the same transaction that wrote the lease
+	// row — so the row's lock holds the lease for fn's entire duration,
+	// rather than just the instant of acquisition. Use this instead of a
+	// bare TryAcquire check whenever fn's writes must not overlap with
+	// another replica's (the catch-up sweep's INSERT-SELECT, in particular:
+	// two leaders racing it would insert duplicate notification rows).
+	WithLease(ctx context.Context, task string, ttl time.Duration, fn func(ctx context.Context, tx pgx.Tx) error) (ran bool, err error)
+}
+
+// leaseUpsert is the lease acquisition/renewal query shared by TryAcquire
+// and WithLease: it only touches an existing row if that row's lease has
+// already expired, so a live holder is never preempted. RETURNING holder
+// tells the caller who won — always holder itself when the upsert actually
+// ran, since no other writer could have raced in and changed it — but the
+// row may also simply not come back at all (zero rows), meaning an
+// unexpired lease (another replica's, or this one's own) blocked the write
+// entirely.
+const leaseUpsert = `
+	INSERT INTO maintenance_leases (task_name, holder, expires_at)
+	VALUES ($1, $2, NOW() + $3 * INTERVAL '1 second')
+	ON CONFLICT (task_name) DO UPDATE
+		SET holder = EXCLUDED.holder, expires_at = EXCLUDED.expires_at
+		WHERE maintenance_leases.expires_at < NOW()
+	RETURNING holder`
+
+// PostgresCoordinator elects leaders via a maintenance_leases table:
+//
+//	CREATE TABLE maintenance_leases (
+//	    task_name  text PRIMARY KEY,
+//	    holder     uuid NOT NULL,
+//	    expires_at timestamptz NOT NULL
+//	);
+//
+// Each replica holds a random UUID for its process lifetime; the replica
+// whose UUID is in task_name's row when the lease is current is the leader.
+type PostgresCoordinator struct {
+	pool   *pgxpool.Pool
+	holder uuid.UUID
+}
+
+// NewPostgresCoordinator creates a PostgresCoordinator with a fresh,
+// process-lifetime holder UUID.
+func NewPostgresCoordinator(pool *pgxpool.Pool) *PostgresCoordinator {
+	return &PostgresCoordinator{pool: pool, holder: uuid.New()}
+}
+
+// TryAcquire attempts to acquire task's lease via leaseUpsert, outside any
+// caller-visible transaction.
+func (c *PostgresCoordinator) TryAcquire(ctx context.Context, task string, ttl time.Duration) (bool, error) {
+	var holder uuid.UUID
+	err := c.pool.QueryRow(ctx, leaseUpsert, task, c.holder, ttl.Seconds()).Scan(&holder)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, nil
+		}
+		return false, fmt.Errorf("acquire lease %s: %w", task, err)
+	}
+	return holder == c.holder, nil
+}
+
+// WithLease acquires task's lease inside a transaction and, only if this
+// replica wins it, runs fn in that same transaction before committing — so
+// the lease row stays locked (and thus the lease held) for fn's whole
+// duration, not just the moment of acquisition. Any other replica's
+// concurrent WithLease/TryAcquire for the same task blocks on that row lock
+// until this transaction commits or rolls back.
+func (c *PostgresCoordinator) WithLease(ctx context.Context, task string, ttl time.Duration, fn func(ctx context.Context, tx pgx.Tx) error) (bool, error) {
+	tx, err := c.pool.Begin(ctx)
+	if err != nil {
+		return false, fmt.Errorf("begin lease tx for %s: %w", task, err)
+	}
+	defer tx.Rollback(ctx) // no-op once Commit has succeeded
+
+	var holder uuid.UUID
+	err = tx.QueryRow(ctx, leaseUpsert, task, c.holder, ttl.Seconds()).Scan(&holder)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, nil
+		}
+		return false, fmt.Errorf("acquire lease %s: %w", task, err)
+	}
+	if holder != c.holder {
+		return false, nil
+	}
+
+	if err := fn(ctx, tx); err != nil {
+		return false, fmt.Errorf("run leased task %s: %w", task, err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return false, fmt.Errorf("commit leased task %s: %w", task, err)
+	}
+	return true, nil
+}
+
+// NoopCoordinator is always the leader for every task — the default for
+// single-node dev, where there's no other replica to contend with, and a
+// natural substitute in tests.
+type NoopCoordinator struct {
+	pool *pgxpool.Pool
+}
+
+// NewNoopCoordinator creates a NoopCoordinator. pool is only used to give
+// WithLease's fn a real transaction to run in, consistent with the
+// PostgresCoordinator's contract.
+func NewNoopCoordinator(pool *pgxpool.Pool) *NoopCoordinator {
+	return &NoopCoordinator{pool: pool}
+}
+
+// TryAcquire always succeeds.
+func (c *NoopCoordinator) TryAcquire(ctx context.Context, task string, ttl time.Duration) (bool, error) {
+	return true, nil
+}
+
+// WithLease always runs fn, inside a plain transaction (no lease row is
+// written — there's nothing to coordinate against on a single node).
+func (c *NoopCoordinator) WithLease(ctx context.Context, task string, ttl time.Duration, fn func(ctx context.Context, tx pgx.Tx) error) (bool, error) {
+	tx, err := c.pool.Begin(ctx)
+	if err != nil {
+		return false, fmt.Errorf("begin tx for %s: %w", task, err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := fn(ctx, tx); err != nil {
+		return false, fmt.Errorf("run task %s: %w", task, err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return false, fmt.Errorf("commit task %s: %w", task, err)
+	}
+	return true, nil
+}
@@ -9,6 +9,13 @@ import (
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+// viewCachePrefixes maps a materialized view to the response-cache key
+// prefix it backs, so a refresh can purge the now-stale cached payloads
+// instead of waiting out their TTL — see appCache/SetCache.
+var viewCachePrefixes = map[string]string{
+	"mv_autofill_entities": "autofill:",
+}
+
 // RefreshMaterializedViews refreshes all materialized views after ingestion.
 // Uses CONCURRENTLY so reads are not blocked during refresh.
 // Call this after a successful seed or fixture processing cycle.
@@ -21,6 +28,7 @@ func RefreshMaterializedViews(ctx context.Context, pool *pgxpool.Pool, logger *s
 		start := time.Now()
 		_, err := pool.Exec(ctx, fmt.Sprintf("REFRESH MATERIALIZED VIEW CONCURRENTLY %s", v))
 		dur := time.Since(start).Round(time.Millisecond)
+		observeViewRefresh(v, start)
 
 		if err != nil {
 			logger.Warn("Failed to refresh materialized view",
@@ -28,6 +36,12 @@ func RefreshMaterializedViews(ctx context.Context, pool *pgxpool.Pool, logger *s
 			return fmt.Errorf("refresh %s: %w", v, err)
 		}
 		logger.Info("Refreshed materialized view", "view", v, "duration", dur)
+
+		if appCache != nil {
+			if prefix, ok := viewCachePrefixes[v]; ok {
+				appCache.DeletePrefix(prefix)
+			}
+		}
 	}
 	return nil
 }
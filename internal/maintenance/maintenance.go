@@ -5,10 +5,17 @@ package maintenance
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
+	"math"
+	randv2 "math/rand/v2"
 	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/albapepper/scoracle-data/internal/errreport"
+	"github.com/albapepper/scoracle-data/internal/observability"
 )
 
 // Config controls maintenance task intervals. Zero duration disables a task.
@@ -16,6 +23,11 @@ type Config struct {
 	CleanupInterval time.Duration // Expired notifications + stale cache rows
 	DigestInterval  time.Duration // Batch digest generation
 	CatchUpInterval time.Duration // Sweep for missed NOTIFY events
+
+	StuckInterval  time.Duration // How often the stuck-notification reaper runs
+	StuckThreshold time.Duration // How long a row may sit in "sending" before it's considered stuck
+	MaxAttempts    int           // Attempts after which a stuck row is marked "failed" instead of re-queued
+	BackoffBase    time.Duration // Base delay for the reaper's exponential backoff
 }
 
 // DefaultConfig returns sensible production defaults.
@@ -24,12 +36,26 @@ func DefaultConfig() Config {
 		CleanupInterval: 30 * time.Minute,
 		DigestInterval:  1 * time.Hour,
 		CatchUpInterval: 15 * time.Minute,
+
+		StuckInterval:  5 * time.Minute,
+		StuckThreshold: 10 * time.Minute,
+		MaxAttempts:    5,
+		BackoffBase:    time.Minute,
 	}
 }
 
 // Start launches all configured maintenance tickers. Blocks until ctx is
 // cancelled. Intended to be called with `go`.
-func Start(ctx context.Context, pool *pgxpool.Pool, cfg Config, logger *slog.Logger) {
+//
+// coordinator elects a single leader per task across replicas, so running
+// more than one process for HA (required for LISTEN/NOTIFY availability)
+// doesn't double-execute this work. Pass nil for single-node dev, which
+// defaults to a NoopCoordinator (every tick runs locally, as before).
+func Start(ctx context.Context, pool *pgxpool.Pool, cfg Config, coordinator Coordinator, logger *slog.Logger) {
+	if coordinator == nil {
+		coordinator = NewNoopCoordinator(pool)
+	}
+
 	logger.Info("Maintenance tickers started",
 		"cleanup", cfg.CleanupInterval,
 		"digest", cfg.DigestInterval,
@@ -42,32 +68,51 @@ func Start(ctx context.Context, pool *pgxpool.Pool, cfg Config, logger *slog.Log
 		}
 	}()
 
-	// Cleanup: remove old sent/failed notifications and expired cache rows
+	// Cleanup: remove old sent/failed notifications and expired cache rows.
+	// Overlapping runs are harmless (DELETE is idempotent), so a plain
+	// TryAcquire gate is enough — no need to hold the lease for the
+	// duration of the deletes.
 	if cfg.CleanupInterval > 0 {
 		t := time.NewTicker(cfg.CleanupInterval)
 		tickers = append(tickers, t)
-		go runLoop(ctx, t.C, "cleanup", func() { cleanup(ctx, pool, logger) })
+		go runLoop(ctx, t.C, func() { runGated(ctx, coordinator, "cleanup", cfg.CleanupInterval, logger, pool, cleanup) })
 	}
 
-	// Digest: generate batch notification records for digest delivery
+	// Digest: generate batch notification records for digest delivery.
 	if cfg.DigestInterval > 0 {
 		t := time.NewTicker(cfg.DigestInterval)
 		tickers = append(tickers, t)
-		go runLoop(ctx, t.C, "digest", func() { generateDigests(ctx, pool, logger) })
+		go runLoop(ctx, t.C, func() { runGated(ctx, coordinator, "digest", cfg.DigestInterval, logger, pool, generateDigests) })
 	}
 
-	// Catch-up: sweep for NOTIFY events missed during downtime
+	// Catch-up: sweep for NOTIFY events missed during downtime. Two leaders
+	// racing this would insert duplicate notification rows, so it runs
+	// inside the lease-held transaction rather than behind a bare gate.
 	if cfg.CatchUpInterval > 0 {
 		t := time.NewTicker(cfg.CatchUpInterval)
 		tickers = append(tickers, t)
-		go runLoop(ctx, t.C, "catchup", func() { catchUpSweep(ctx, pool, logger) })
+		go runLoop(ctx, t.C, func() { runLeased(ctx, coordinator, "catchup", cfg.CatchUpInterval, logger, catchUpSweep) })
+	}
+
+	// Stuck-notification reaper: re-queue (or give up on) rows the dispatch
+	// worker claimed but never finished sending. Two leaders incrementing
+	// the same row's attempts independently would double-count retries, so
+	// this also runs inside the lease-held transaction.
+	if cfg.StuckInterval > 0 {
+		t := time.NewTicker(cfg.StuckInterval)
+		tickers = append(tickers, t)
+		go runLoop(ctx, t.C, func() {
+			runLeased(ctx, coordinator, "stuck", cfg.StuckInterval, logger, func(ctx context.Context, tx pgx.Tx, logger *slog.Logger) error {
+				return stuckNotifications(ctx, tx, logger, cfg)
+			})
+		})
 	}
 
 	<-ctx.Done()
 	logger.Info("Maintenance tickers stopped")
 }
 
-func runLoop(ctx context.Context, ch <-chan time.Time, name string, fn func()) {
+func runLoop(ctx context.Context, ch <-chan time.Time, fn func()) {
 	for {
 		select {
 		case <-ch:
@@ -78,58 +123,201 @@ func runLoop(ctx context.Context, ch <-chan time.Time, name string, fn func()) {
 	}
 }
 
+// runGated runs fn against pool only if coordinator grants this replica
+// task's lease for this tick.
+func runGated(ctx context.Context, coordinator Coordinator, task string, ttl time.Duration, logger *slog.Logger, pool *pgxpool.Pool, fn func(ctx context.Context, tx pgx.Tx, logger *slog.Logger)) {
+	ctx, span := observability.Start(ctx, "maintenance."+task, map[string]string{"task": task})
+	start := time.Now()
+	var runErr error
+	defer func() {
+		observeTask(task, start, runErr)
+		span.End(runErr)
+	}()
+
+	ok, err := coordinator.TryAcquire(ctx, task, ttl)
+	if err != nil {
+		logger.Warn("maintenance lease attempt failed", "task", task, "error", err)
+		errreport.Record("maintenance_lease_failed", err)
+		runErr = err
+		return
+	}
+	if !ok {
+		return
+	}
+	// fn still runs in a transaction for a consistent read/write snapshot,
+	// even though the lease itself isn't held through it (see runLeased).
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		logger.Warn("maintenance task begin tx failed", "task", task, "error", err)
+		runErr = err
+		return
+	}
+	fn(ctx, tx, logger)
+	if err := tx.Commit(ctx); err != nil {
+		logger.Warn("maintenance task commit failed", "task", task, "error", err)
+		runErr = err
+	}
+}
+
+// runLeased runs fn inside coordinator's lease-held transaction for task,
+// so the lease stays valid for fn's entire duration.
+func runLeased(ctx context.Context, coordinator Coordinator, task string, ttl time.Duration, logger *slog.Logger, fn func(ctx context.Context, tx pgx.Tx, logger *slog.Logger) error) {
+	ctx, span := observability.Start(ctx, "maintenance."+task, map[string]string{"task": task})
+	start := time.Now()
+	ran, err := coordinator.WithLease(ctx, task, ttl, func(ctx context.Context, tx pgx.Tx) error {
+		return fn(ctx, tx, logger)
+	})
+	observeTask(task, start, err)
+	span.End(err)
+	if err != nil {
+		logger.Warn("maintenance leased task failed", "task", task, "error", err)
+		errreport.Record("maintenance_lease_failed", err)
+		return
+	}
+	if !ran {
+		logger.Debug("maintenance task skipped, not leader", "task", task)
+	}
+}
+
 // --------------------------------------------------------------------------
 // Task implementations
 // --------------------------------------------------------------------------
 
 // cleanup removes notifications older than 30 days that have been sent or
-// failed, and expired percentile_archive rows marked as final.
-func cleanup(ctx context.Context, pool *pgxpool.Pool, logger *slog.Logger) {
+// failed, and non-final percentile_archive rows older than 7 days. tx comes from
+// runGated — both deletes share one transaction for a consistent snapshot,
+// though (unlike catchUpSweep) holding the maintenance lease through it
+// isn't required since repeated deletes are idempotent.
+func cleanup(ctx context.Context, tx pgx.Tx, logger *slog.Logger) {
 	// Purge old sent/failed notifications
-	tag, err := pool.Exec(ctx, `
+	tag, err := tx.Exec(ctx, `
 		DELETE FROM notifications
 		WHERE status IN ('sent', 'failed')
 		  AND updated_at < NOW() - INTERVAL '30 days'`)
 	if err != nil {
 		logger.Warn("Cleanup: failed to purge old notifications", "error", err)
+		errreport.Record("maintenance_cleanup_failed", err)
 	} else if tag.RowsAffected() > 0 {
 		logger.Info("Cleanup: purged old notifications", "count", tag.RowsAffected())
 	}
 
 	// Purge old non-final percentile archive rows (keep final snapshots)
-	tag, err = pool.Exec(ctx, `
+	tag, err = tx.Exec(ctx, `
 		DELETE FROM percentile_archive
 		WHERE is_final = false
 		  AND archived_at < NOW() - INTERVAL '7 days'`)
 	if err != nil {
 		logger.Warn("Cleanup: failed to purge old archive rows", "error", err)
+		errreport.Record("maintenance_cleanup_failed", err)
 	} else if tag.RowsAffected() > 0 {
 		logger.Info("Cleanup: purged old archive rows", "count", tag.RowsAffected())
 	}
 }
 
-// generateDigests creates batch notification summaries for users who prefer
-// digest-style delivery instead of real-time pushes.
-// Currently a placeholder — will be implemented when user preference tables
-// include a delivery_mode column.
-func generateDigests(ctx context.Context, pool *pgxpool.Pool, logger *slog.Logger) {
-	// TODO: When user preferences support digest mode:
-	// 1. Query users with delivery_mode = 'digest'
-	// 2. Aggregate pending changes since last digest
-	// 3. Build summary notification and insert into notifications table
-	_ = ctx
-	_ = pool
-	_ = logger
+// digestInsertSQL batches every digest-mode follower's queued percentile
+// crossings into one notifications row per user, in a single statement so
+// "select who's due, aggregate their crossings, insert the digest, mark the
+// sources consumed, advance last_digest_at" all commit atomically under the
+// maintenance lease — no window where a crash between steps could double-send
+// or silently drop a batch.
+//
+// Requires two schema additions this snapshot has no migrations directory to
+// carry (see the PostgresCoordinator doc comment in coordinator.go for the
+// same situation with maintenance_leases):
+//
+//	ALTER TABLE notification_preferences
+//	    ADD COLUMN delivery_mode    text NOT NULL DEFAULT 'realtime'
+//	        CHECK (delivery_mode IN ('realtime', 'digest')),
+//	    ADD COLUMN digest_frequency text NOT NULL DEFAULT 'daily'
+//	        CHECK (digest_frequency IN ('daily', 'weekly')),
+//	    ADD COLUMN digest_hour      int NOT NULL DEFAULT 8,
+//	    ADD COLUMN timezone         text NOT NULL DEFAULT 'UTC',
+//	    ADD COLUMN last_digest_at   timestamptz NOT NULL DEFAULT '-infinity';
+//
+//	ALTER TABLE notifications
+//	    ADD COLUMN kind    text NOT NULL DEFAULT 'alert',
+//	    ADD COLUMN payload jsonb;
+//
+// Crossings are queued by notifications.Run (see pipeline.go) with
+// status = 'digest_pending' instead of 'scheduled' for digest-mode
+// followers — that alone is what stops the realtime dispatch worker
+// (store.go's ClaimDueBatch only claims status = 'scheduled') from also
+// sending them one at a time.
+const digestInsertSQL = `
+	WITH due AS (
+		SELECT user_id, digest_hour, timezone
+		FROM notification_preferences
+		WHERE delivery_mode = 'digest'
+		  AND last_digest_at < NOW() - (CASE digest_frequency WHEN 'weekly' THEN INTERVAL '7 days' ELSE INTERVAL '1 day' END)
+	),
+	crossings AS (
+		SELECT n.id, n.user_id, n.entity_type, n.entity_id, n.sport, n.stat_key, n.percentile, n.message
+		FROM notifications n
+		JOIN due d ON d.user_id = n.user_id
+		WHERE n.status = 'digest_pending' AND n.percentile >= 75
+		FOR UPDATE SKIP LOCKED
+	),
+	aggregated AS (
+		SELECT c.user_id,
+			max(c.percentile) AS percentile,
+			count(*) AS event_count,
+			jsonb_agg(jsonb_build_object(
+				'entity_type', c.entity_type, 'entity_id', c.entity_id, 'sport', c.sport,
+				'stat_key', c.stat_key, 'percentile', c.percentile, 'message', c.message
+			) ORDER BY c.percentile DESC) AS payload
+		FROM crossings c
+		GROUP BY c.user_id
+	),
+	inserted AS (
+		INSERT INTO notifications (
+			user_id, entity_type, entity_id, sport, kind, stat_key, percentile, message, payload, status, scheduled_for
+		)
+		SELECT
+			a.user_id, 'digest', 0, '', 'digest', 'digest', a.percentile,
+			a.event_count || ' updates since your last digest',
+			a.payload, 'scheduled',
+			-- next occurrence of d.digest_hour in d.timezone, mirroring
+			-- Scheduler.ScheduleDigestDelivery's Go-side logic
+			(CASE
+				WHEN (date_trunc('day', NOW() AT TIME ZONE d.timezone) + (d.digest_hour || ' hours')::interval) > (NOW() AT TIME ZONE d.timezone)
+				THEN (date_trunc('day', NOW() AT TIME ZONE d.timezone) + (d.digest_hour || ' hours')::interval)
+				ELSE (date_trunc('day', NOW() AT TIME ZONE d.timezone) + (d.digest_hour || ' hours')::interval + INTERVAL '1 day')
+			END) AT TIME ZONE d.timezone
+		FROM aggregated a
+		JOIN due d ON d.user_id = a.user_id
+		RETURNING user_id
+	),
+	consumed AS (
+		UPDATE notifications SET status = 'sent', updated_at = NOW()
+		WHERE id IN (SELECT id FROM crossings)
+	)
+	UPDATE notification_preferences SET last_digest_at = NOW()
+	WHERE user_id IN (SELECT user_id FROM inserted)`
+
+// generateDigests batches every digest-mode follower's accumulated percentile
+// crossings into one notification each, via digestInsertSQL.
+func generateDigests(ctx context.Context, tx pgx.Tx, logger *slog.Logger) {
+	tag, err := tx.Exec(ctx, digestInsertSQL)
+	if err != nil {
+		logger.Warn("Digest generation failed", "error", err)
+		errreport.Record("maintenance_digest_failed", err)
+		return
+	}
+	if tag.RowsAffected() > 0 {
+		logger.Info("Digest generation: batched notifications for users", "count", tag.RowsAffected())
+	}
 }
 
 // catchUpSweep checks for entities with high percentiles that may not have
 // had their NOTIFY events processed (e.g., during listener downtime).
 // Compares current percentiles against the last archived snapshot and
-// re-triggers notification processing for any gaps.
-func catchUpSweep(ctx context.Context, pool *pgxpool.Pool, logger *slog.Logger) {
+// re-triggers notification processing for any gaps. Runs inside the
+// maintenance lease's own transaction (see runLeased) so two replicas can
+// never race this INSERT-SELECT and create duplicate notification rows.
+func catchUpSweep(ctx context.Context, tx pgx.Tx, logger *slog.Logger) error {
 	// Find player_stats with percentiles >= 90 that were updated recently
 	// but don't have a corresponding notification scheduled
-	tag, err := pool.Exec(ctx, `
+	tag, err := tx.Exec(ctx, `
 		INSERT INTO notifications (user_id, entity_type, entity_id, sport, stat_key, percentile, message, status, scheduled_for)
 		SELECT
 			uf.user_id,
@@ -160,8 +348,93 @@ func catchUpSweep(ctx context.Context, pool *pgxpool.Pool, logger *slog.Logger)
 		  )
 		ON CONFLICT DO NOTHING`)
 	if err != nil {
-		logger.Warn("Catch-up sweep: failed", "error", err)
-	} else if tag.RowsAffected() > 0 {
+		errreport.Record("maintenance_catchup_failed", err)
+		return fmt.Errorf("catch-up sweep: %w", err)
+	}
+	if tag.RowsAffected() > 0 {
 		logger.Info("Catch-up sweep: created missed notifications", "count", tag.RowsAffected())
 	}
+	return nil
+}
+
+// stuckNotifications reaps rows the dispatch worker claimed (status moved
+// to "sending" by ClaimDueBatch, see store.go) but never finished — the
+// worker crashed mid-send, the push provider timed out, or the LISTEN
+// connection dropped between selection and the eventual MarkSent/MarkFailed
+// call. Anything that's sat in "sending" longer than cfg.StuckThreshold is
+// either re-queued with backoff or, past cfg.MaxAttempts, marked "failed".
+// Runs inside the maintenance lease's own transaction (see runLeased) so
+// two replicas never double-bump the same row's attempts.
+func stuckNotifications(ctx context.Context, tx pgx.Tx, logger *slog.Logger, cfg Config) error {
+	rows, err := tx.Query(ctx, `
+		SELECT id, attempts FROM notifications
+		WHERE status = 'sending'
+		  AND updated_at < NOW() - ($1 * INTERVAL '1 second')
+		FOR UPDATE SKIP LOCKED`,
+		cfg.StuckThreshold.Seconds())
+	if err != nil {
+		errreport.Record("maintenance_stuck_failed", err)
+		return fmt.Errorf("find stuck notifications: %w", err)
+	}
+
+	type stuckRow struct {
+		id       int
+		attempts int
+	}
+	var stuck []stuckRow
+	for rows.Next() {
+		var r stuckRow
+		if err := rows.Scan(&r.id, &r.attempts); err != nil {
+			rows.Close()
+			return fmt.Errorf("scan stuck notification: %w", err)
+		}
+		stuck = append(stuck, r)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("iterate stuck notifications: %w", err)
+	}
+
+	var requeued, failed int
+	for _, r := range stuck {
+		nextAttempt := r.attempts + 1
+		if nextAttempt >= cfg.MaxAttempts {
+			if _, err := tx.Exec(ctx, `
+				UPDATE notifications
+				SET status = 'failed', attempts = $2, last_error = 'stuck: exceeded max attempts', updated_at = NOW()
+				WHERE id = $1`, r.id, nextAttempt); err != nil {
+				return fmt.Errorf("mark stuck notification %d failed: %w", r.id, err)
+			}
+			failed++
+			continue
+		}
+
+		backoff := stuckBackoff(cfg.BackoffBase, nextAttempt)
+		if _, err := tx.Exec(ctx, `
+			UPDATE notifications
+			SET status = 'scheduled',
+				attempts = $2,
+				scheduled_for = NOW() + ($3 * INTERVAL '1 second'),
+				last_error = 'stuck: re-queued by reaper',
+				updated_at = NOW()
+			WHERE id = $1`, r.id, nextAttempt, backoff.Seconds()); err != nil {
+			return fmt.Errorf("requeue stuck notification %d: %w", r.id, err)
+		}
+		requeued++
+	}
+
+	if requeued > 0 || failed > 0 {
+		logger.Info("Stuck-notification reaper", "requeued", requeued, "failed", failed)
+	}
+	return nil
+}
+
+// stuckBackoff computes the reaper's exponential backoff (base, doubling
+// per attempt) with ±20% jitter, so a burst of stuck rows doesn't all
+// re-queue for the exact same instant and thunder back into the dispatch
+// worker together.
+func stuckBackoff(base time.Duration, attempts int) time.Duration {
+	raw := float64(base) * math.Pow(2, float64(attempts))
+	jitter := 0.8 + randv2.Float64()*0.4
+	return time.Duration(raw * jitter)
 }
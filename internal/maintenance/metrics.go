@@ -0,0 +1,61 @@
+package maintenance
+
+import (
+	"time"
+
+	"github.com/albapepper/scoracle-data/internal/cache"
+	"github.com/albapepper/scoracle-data/internal/metrics"
+)
+
+// reg is the process-wide metrics registry for maintenance tasks, set once
+// at startup via SetMetrics. A nil reg (the default) makes every
+// instrumented tick a no-op, mirroring seed.SetMetrics.
+var reg *metrics.Registry
+
+// appCache is the process-wide response cache, set once at startup via
+// SetCache. A nil appCache (the default) makes RefreshMaterializedViews'
+// post-refresh purge a no-op, same idiom as reg above.
+var appCache cache.Cache
+
+// SetMetrics wires a metrics registry into every subsequent maintenance
+// task run. Call once from cmd/api/main.go before Start.
+func SetMetrics(r *metrics.Registry) {
+	reg = r
+}
+
+// SetCache wires the response cache into RefreshMaterializedViews, so a
+// view refresh can purge the cache keys it backs instead of leaving them to
+// expire on their own TTL. Call once from cmd/api/main.go before Start.
+func SetCache(c cache.Cache) {
+	appCache = c
+}
+
+// observeTask records scoracle_maintenance_task_duration_seconds{task} for
+// every tick (whether or not this replica won the lease) and
+// scoracle_maintenance_task_errors_total{task} when err is non-nil.
+func observeTask(task string, start time.Time, err error) {
+	if reg == nil {
+		return
+	}
+	reg.ObserveDuration("scoracle_maintenance_task_duration_seconds",
+		"Maintenance task latency in seconds, labeled by task.",
+		map[string]string{"task": task}, start)
+	if err != nil {
+		reg.IncCounter("scoracle_maintenance_task_errors_total",
+			"Maintenance task failures, labeled by task.",
+			map[string]string{"task": task})
+	}
+}
+
+// observeViewRefresh records materialized_view_refresh_duration_seconds{view}
+// for one view's REFRESH MATERIALIZED VIEW CONCURRENTLY call, regardless of
+// whether it succeeded — a refresh that's failing slowly is exactly the case
+// this metric needs to surface.
+func observeViewRefresh(view string, start time.Time) {
+	if reg == nil {
+		return
+	}
+	reg.ObserveDuration("materialized_view_refresh_duration_seconds",
+		"Materialized view refresh latency in seconds, labeled by view.",
+		map[string]string{"view": view}, start)
+}
@@ -0,0 +1,19 @@
+package metrics
+
+import (
+	"log/slog"
+	"net/http"
+)
+
+// ListenAndServeAdmin starts a dedicated HTTP server exposing r.Handler() at
+// /metrics on addr, separate from any public-facing router so Prometheus
+// scrapes never compete with user traffic for a listener. Blocks until the
+// server errors; intended to be called with `go`.
+func (r *Registry) ListenAndServeAdmin(addr string, logger *slog.Logger) {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", r.Handler())
+	logger.Info("Admin metrics listener started", "addr", addr)
+	if err := http.ListenAndServe(addr, mux); err != nil {
+		logger.Error("Admin metrics listener failed", "error", err)
+	}
+}
@@ -0,0 +1,13 @@
+package metrics
+
+import "net/http"
+
+// Handler serves the accumulated metrics in Prometheus text exposition
+// format at /metrics.
+func (r *Registry) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(r.Render()))
+	})
+}
@@ -0,0 +1,262 @@
+// Package metrics is a minimal Prometheus-compatible metrics registry.
+// It implements just enough of the exposition format (counters and
+// histograms with labels) to back a /metrics endpoint, without pulling in
+// the full client_golang dependency tree.
+package metrics
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaultLatencyBuckets mirrors the Prometheus client default HTTP latency
+// buckets (seconds), which cover sub-millisecond cache hits through
+// multi-second cold queries.
+var defaultLatencyBuckets = []float64{
+	0.001, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10,
+}
+
+// Registry holds all counters and histograms for the process. A single
+// Registry is created at startup (cmd/api/main.go) and passed to
+// handler.New, which shares it across every handler.
+type Registry struct {
+	mu         sync.Mutex
+	counters   map[string]*counter
+	histograms map[string]*histogram
+	gaugeFuncs map[string]gaugeFunc
+}
+
+// gaugeFunc is a lazily-sampled gauge: instead of pushing values in, the
+// Registry pulls the current value at render time. Used for metrics backed
+// by state someone else already tracks (e.g. pgxpool.Pool.Stat()), so we
+// don't have to duplicate that bookkeeping here.
+type gaugeFunc struct {
+	help string
+	fn   func() float64
+}
+
+// New creates an empty Registry.
+func New() *Registry {
+	return &Registry{
+		counters:   make(map[string]*counter),
+		histograms: make(map[string]*histogram),
+		gaugeFuncs: make(map[string]gaugeFunc),
+	}
+}
+
+// RegisterGaugeFunc registers a gauge sampled on demand by calling fn each
+// time /metrics is scraped — e.g. RegisterGaugeFunc("db_pool_acquire_wait_seconds",
+// "...", func() float64 { return pool.Stat().AcquireDuration().Seconds() }).
+func (r *Registry) RegisterGaugeFunc(name, help string, fn func() float64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.gaugeFuncs[name] = gaugeFunc{help: help, fn: fn}
+}
+
+// counter is a monotonically increasing value, keyed by label set.
+type counter struct {
+	help   string
+	values map[string]float64
+}
+
+// histogram tracks observation counts per bucket, plus sum/count, keyed by
+// label set.
+type histogram struct {
+	help    string
+	buckets []float64
+	counts  map[string][]uint64
+	sums    map[string]float64
+	totals  map[string]uint64
+}
+
+// labelKey renders labels into a stable, comparable map key.
+func labelKey(labels map[string]string) string {
+	if len(labels) == 0 {
+		return ""
+	}
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var b strings.Builder
+	for i, k := range keys {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(k)
+		b.WriteByte('=')
+		b.WriteString(labels[k])
+	}
+	return b.String()
+}
+
+// IncCounter increments the named counter (creating it on first use) by 1,
+// under the given label set.
+func (r *Registry) IncCounter(name, help string, labels map[string]string) {
+	r.AddCounter(name, help, labels, 1)
+}
+
+// AddCounter increments the named counter by delta.
+func (r *Registry) AddCounter(name, help string, labels map[string]string, delta float64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	c, ok := r.counters[name]
+	if !ok {
+		c = &counter{help: help, values: make(map[string]float64)}
+		r.counters[name] = c
+	}
+	c.values[labelKey(labels)] += delta
+}
+
+// ObserveHistogram records an observation for the named histogram.
+func (r *Registry) ObserveHistogram(name, help string, labels map[string]string, value float64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	h, ok := r.histograms[name]
+	if !ok {
+		h = &histogram{
+			help:    help,
+			buckets: defaultLatencyBuckets,
+			counts:  make(map[string][]uint64),
+			sums:    make(map[string]float64),
+			totals:  make(map[string]uint64),
+		}
+		r.histograms[name] = h
+	}
+	key := labelKey(labels)
+	counts, ok := h.counts[key]
+	if !ok {
+		counts = make([]uint64, len(h.buckets))
+		h.counts[key] = counts
+	}
+	for i, le := range h.buckets {
+		if value <= le {
+			counts[i]++
+		}
+	}
+	h.sums[key] += value
+	h.totals[key]++
+}
+
+// ObserveDuration is a convenience wrapper for timing an operation: call it
+// with the start time and it records the elapsed seconds.
+func (r *Registry) ObserveDuration(name, help string, labels map[string]string, start time.Time) {
+	r.ObserveHistogram(name, help, labels, time.Since(start).Seconds())
+}
+
+// Render writes every counter and histogram in Prometheus text exposition
+// format (the same format Handler serves at /metrics).
+func (r *Registry) Render() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var b strings.Builder
+
+	gaugeNames := make([]string, 0, len(r.gaugeFuncs))
+	for name := range r.gaugeFuncs {
+		gaugeNames = append(gaugeNames, name)
+	}
+	sort.Strings(gaugeNames)
+	for _, name := range gaugeNames {
+		g := r.gaugeFuncs[name]
+		fmt.Fprintf(&b, "# HELP %s %s\n", name, g.help)
+		fmt.Fprintf(&b, "# TYPE %s gauge\n", name)
+		fmt.Fprintf(&b, "%s %s\n", name, formatFloat(g.fn()))
+	}
+
+	names := make([]string, 0, len(r.counters))
+	for name := range r.counters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		c := r.counters[name]
+		fmt.Fprintf(&b, "# HELP %s %s\n", name, c.help)
+		fmt.Fprintf(&b, "# TYPE %s counter\n", name)
+		writeSeries(&b, name, c.values)
+	}
+
+	names = names[:0]
+	for name := range r.histograms {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		h := r.histograms[name]
+		fmt.Fprintf(&b, "# HELP %s %s\n", name, h.help)
+		fmt.Fprintf(&b, "# TYPE %s histogram\n", name)
+
+		keys := make([]string, 0, len(h.counts))
+		for key := range h.counts {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			counts := h.counts[key]
+			prefix := name
+			labelPart := key
+			for i, le := range h.buckets {
+				writeMetricLine(&b, prefix+"_bucket", mergeLabel(labelPart, "le", formatFloat(le)), float64(counts[i]))
+			}
+			writeMetricLine(&b, prefix+"_bucket", mergeLabel(labelPart, "le", "+Inf"), float64(h.totals[key]))
+			writeMetricLine(&b, prefix+"_sum", labelPart, h.sums[key])
+			writeMetricLine(&b, prefix+"_count", labelPart, float64(h.totals[key]))
+		}
+	}
+
+	return b.String()
+}
+
+func writeSeries(b *strings.Builder, name string, values map[string]float64) {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		writeMetricLine(b, name, key, values[key])
+	}
+}
+
+func writeMetricLine(b *strings.Builder, name, renderedLabels string, value float64) {
+	if renderedLabels == "" {
+		fmt.Fprintf(b, "%s %s\n", name, formatFloat(value))
+		return
+	}
+	fmt.Fprintf(b, "%s{%s} %s\n", name, promLabels(renderedLabels), formatFloat(value))
+}
+
+// mergeLabel appends an extra label (e.g. "le") onto an already-rendered
+// label key string.
+func mergeLabel(renderedLabels, k, v string) string {
+	extra := k + "=" + v
+	if renderedLabels == "" {
+		return extra
+	}
+	return renderedLabels + "," + extra
+}
+
+// promLabels converts our internal "k=v,k2=v2" key format into Prometheus's
+// quoted label syntax: k="v",k2="v2".
+func promLabels(renderedLabels string) string {
+	parts := strings.Split(renderedLabels, ",")
+	out := make([]string, len(parts))
+	for i, p := range parts {
+		kv := strings.SplitN(p, "=", 2)
+		if len(kv) != 2 {
+			out[i] = p
+			continue
+		}
+		out[i] = fmt.Sprintf("%s=%q", kv[0], kv[1])
+	}
+	return strings.Join(out, ",")
+}
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
@@ -0,0 +1,44 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// Middleware wraps every request with request-count and latency
+// instrumentation, labeled by route pattern and status code. It must be
+// registered after chi's RouteContext is populated (i.e. within the
+// router, not before) so chi.RouteContext(r.Context()).RoutePattern()
+// resolves to the matched pattern rather than the raw path.
+func (r *Registry) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		start := time.Now()
+		ww := middleware.NewWrapResponseWriter(w, req.ProtoMajor)
+
+		next.ServeHTTP(ww, req)
+
+		route := routePattern(req)
+		status := strconv.Itoa(ww.Status())
+
+		r.IncCounter("http_requests_total", "Total HTTP requests.",
+			map[string]string{"route": route, "method": req.Method, "status": status})
+		r.ObserveDuration("http_request_duration_seconds", "HTTP request latency in seconds.",
+			map[string]string{"route": route, "method": req.Method}, start)
+	})
+}
+
+// routePattern returns the matched chi route pattern (e.g.
+// "/api/v1/stats/{entityType}/{entityID}"), falling back to the raw path
+// when no route context is present (e.g. 404s that never matched a route).
+func routePattern(r *http.Request) string {
+	if rctx := chi.RouteContext(r.Context()); rctx != nil {
+		if pattern := rctx.RoutePattern(); pattern != "" {
+			return pattern
+		}
+	}
+	return r.URL.Path
+}
@@ -0,0 +1,88 @@
+package news
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	// enrichConcurrency bounds how many article pages are fetched at once,
+	// so a limit=50 request doesn't fan out 50 simultaneous downloads.
+	enrichConcurrency = 5
+	enrichTimeout     = 10 * time.Second
+	// enrichMaxBodyBytes bounds how much of an article page is read —
+	// article HTML rarely exceeds this, and it caps memory use when several
+	// workers are fetching concurrently.
+	enrichMaxBodyBytes = 2 << 20 // 2 MiB
+)
+
+// enrichArticles fetches each article's URL through client (so a shared
+// httpcache.Transport applies the same conditional-GET caching as the RSS
+// fetch itself), extracts its main content, and fills in Content, Language,
+// Sentiment, and Tags in place. A fetch or extraction failure leaves that
+// article unenriched rather than dropping it — enrichment is best-effort,
+// not required for an article to be useful.
+func enrichArticles(ctx context.Context, client *http.Client, articles []Article) []Article {
+	sem := make(chan struct{}, enrichConcurrency)
+	var wg sync.WaitGroup
+	for i := range articles {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			enrichOne(ctx, client, &articles[i])
+		}(i)
+	}
+	wg.Wait()
+	return articles
+}
+
+func enrichOne(ctx context.Context, client *http.Client, a *Article) {
+	body, err := fetchArticleHTML(ctx, client, a.URL)
+	if err != nil {
+		log.Printf("[news] enrich fetch %q failed: %v", a.URL, err)
+		return
+	}
+
+	content := extractMainContent(body)
+	if content == "" {
+		return
+	}
+
+	a.Content = content
+	a.Language = detectLanguage(content)
+	a.Sentiment = scoreSentiment(content)
+	a.Tags = extractTags(content)
+}
+
+func fetchArticleHTML(ctx context.Context, client *http.Client, url string) (string, error) {
+	fetchCtx, cancel := context.WithTimeout(ctx, enrichTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(fetchCtx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("User-Agent", "Mozilla/5.0 (compatible; ScoracleBot/1.0)")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("enrich fetch http %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, enrichMaxBodyBytes))
+	if err != nil {
+		return "", err
+	}
+	return string(body), nil
+}
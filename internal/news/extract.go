@@ -0,0 +1,47 @@
+package news
+
+import (
+	"html"
+	"regexp"
+	"strings"
+)
+
+var (
+	scriptStyleRe = regexp.MustCompile(`(?is)<(script|style|noscript)[^>]*>.*?</(script|style|noscript)>`)
+	paragraphRe   = regexp.MustCompile(`(?is)<p[^>]*>(.*?)</p>`)
+	tagRe         = regexp.MustCompile(`(?s)<[^>]+>`)
+	whitespaceRe  = regexp.MustCompile(`\s+`)
+)
+
+// extractMainContent is a lightweight readability-style extractor. Rather
+// than walking the DOM to find the subtree with the highest text density
+// (what go-readability/Mozilla's Readability.js do), it assumes paragraph
+// text dominates an article's content-bearing markup and concatenates every
+// <p> block's stripped text — this catches the common case (news articles
+// are overwhelmingly <p>-tagged body copy) without an HTML parser
+// dependency. Falls back to stripping all markup from the whole document if
+// no <p> tags matched.
+func extractMainContent(rawHTML string) string {
+	rawHTML = scriptStyleRe.ReplaceAllString(rawHTML, "")
+
+	var sb strings.Builder
+	for _, m := range paragraphRe.FindAllStringSubmatch(rawHTML, -1) {
+		text := cleanFragment(m[1])
+		if text == "" {
+			continue
+		}
+		sb.WriteString(text)
+		sb.WriteString("\n\n")
+	}
+	if sb.Len() > 0 {
+		return strings.TrimSpace(sb.String())
+	}
+
+	return cleanFragment(rawHTML)
+}
+
+func cleanFragment(fragment string) string {
+	text := tagRe.ReplaceAllString(fragment, " ")
+	text = html.UnescapeString(text)
+	return strings.TrimSpace(whitespaceRe.ReplaceAllString(text, " "))
+}
@@ -0,0 +1,133 @@
+package news
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// buildSearchName shortens very long names (e.g. Brazilian players).
+func buildSearchName(fullName, firstName, lastName string) string {
+	parts := strings.Fields(fullName)
+
+	// Long names (4+ parts): use first + last.
+	if len(parts) >= 4 && firstName != "" && lastName != "" {
+		return firstName + " " + lastName
+	}
+
+	// Names ending in Jr/Junior/II/III: use first + suffix.
+	if len(parts) >= 3 {
+		suffix := strings.ToLower(parts[len(parts)-1])
+		if suffix == "jr" || suffix == "jr." || suffix == "junior" || suffix == "ii" || suffix == "iii" {
+			return parts[0] + " " + parts[len(parts)-1]
+		}
+	}
+
+	return fullName
+}
+
+// nameInText checks if an entity name appears in text with stricter matching.
+func nameInText(name, text, firstName, lastName, team string) bool {
+	if name == "" || text == "" {
+		return false
+	}
+	nameLower := strings.ToLower(strings.TrimSpace(name))
+	textLower := strings.ToLower(strings.TrimSpace(text))
+
+	// Exact full name match.
+	if strings.Contains(textLower, nameLower) {
+		return true
+	}
+
+	// Multi-part name matching.
+	nameParts := strings.Fields(nameLower)
+	if len(nameParts) >= 2 {
+		fn := strings.ToLower(strings.TrimSpace(firstName))
+		if fn == "" {
+			fn = nameParts[0]
+		}
+		ln := strings.ToLower(strings.TrimSpace(lastName))
+		if ln == "" {
+			ln = nameParts[len(nameParts)-1]
+		}
+
+		fnMatch := len(fn) > 1 && wordBoundaryMatch(fn, textLower)
+		lnMatch := len(ln) > 1 && wordBoundaryMatch(ln, textLower)
+
+		// Both first AND last name present.
+		if fnMatch && lnMatch {
+			return true
+		}
+
+		// Name part + team context.
+		if team != "" && (fnMatch || lnMatch) {
+			if strings.Contains(textLower, strings.ToLower(strings.TrimSpace(team))) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// wordBoundaryMatch checks for a whole-word match using \b.
+func wordBoundaryMatch(word, text string) bool {
+	re, err := regexp.Compile(`\b` + regexp.QuoteMeta(word) + `\b`)
+	if err != nil {
+		return strings.Contains(text, word)
+	}
+	return re.MatchString(text)
+}
+
+// dedupeKey identifies an article for merge/dedup purposes: its URL, or
+// failing that (a provider that doesn't return one), its lowercased title.
+func dedupeKey(a Article) string {
+	if a.URL != "" {
+		return a.URL
+	}
+	return strings.ToLower(strings.TrimSpace(a.Title))
+}
+
+// deduplicateArticles removes duplicate articles by dedupeKey, keeping the
+// first occurrence.
+func deduplicateArticles(articles []Article) []Article {
+	seen := make(map[string]bool)
+	out := make([]Article, 0, len(articles))
+	for _, a := range articles {
+		key := dedupeKey(a)
+		if key == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		out = append(out, a)
+	}
+	return out
+}
+
+// sortArticlesByDate sorts articles by published date, newest first.
+func sortArticlesByDate(articles []Article) {
+	parseFmts := []string{
+		time.RFC1123Z,
+		time.RFC1123,
+		time.RFC3339,
+		"2006-01-02T15:04:05Z",
+		"2006-01-02T15:04:05-07:00",
+	}
+
+	parseDate := func(s string) time.Time {
+		s = strings.TrimSpace(s)
+		for _, f := range parseFmts {
+			if t, err := time.Parse(f, s); err == nil {
+				return t
+			}
+		}
+		return time.Time{}
+	}
+
+	sort.Slice(articles, func(i, j int) bool {
+		ti := parseDate(articles[i].PublishedAt)
+		tj := parseDate(articles[j].PublishedAt)
+		return ti.After(tj)
+	})
+}
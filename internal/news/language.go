@@ -0,0 +1,43 @@
+package news
+
+import "strings"
+
+// langProfiles gives each supported language a handful of its most
+// distinctive trigrams — drawn from common function words (articles,
+// conjunctions, verb endings) rather than a full frequency corpus. That's
+// enough to discriminate between a handful of languages for a short news
+// article without embedding a large dataset.
+var langProfiles = map[string][]string{
+	"en": {"the", "and", "ing", "ion", "ent", "for", "tio", "ter", "est", "ers"},
+	"es": {"que", "ion", "aci", "los", "del", "ent", "par", "con", "est", "ado"},
+	"fr": {"les", "ent", "ion", "que", "des", "eur", "ais", "tre", "pou", "ect"},
+	"de": {"der", "ich", "sch", "und", "ein", "die", "ver", "den", "nde", "che"},
+}
+
+// detectLanguage picks the profile with the most trigram overlap against
+// text, defaulting to "en" when text is too short to score or no profile
+// scores above zero. Trigrams are taken byte-wise (not rune-wise), which
+// only makes sense for the Latin-alphabet languages above.
+func detectLanguage(text string) string {
+	text = strings.ToLower(text)
+	if len(text) < 3 {
+		return "en"
+	}
+
+	counts := make(map[string]int, len(text))
+	for i := 0; i+3 <= len(text); i++ {
+		counts[text[i:i+3]]++
+	}
+
+	best, bestScore := "en", 0
+	for lang, trigrams := range langProfiles {
+		score := 0
+		for _, tg := range trigrams {
+			score += counts[tg]
+		}
+		if score > bestScore {
+			best, bestScore = lang, score
+		}
+	}
+	return best
+}
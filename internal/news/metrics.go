@@ -0,0 +1,45 @@
+package news
+
+import (
+	"time"
+
+	"github.com/albapepper/scoracle-data/internal/metrics"
+)
+
+// reg is the process-wide metrics registry, set once at startup via
+// SetMetrics. A nil reg (the default) makes every instrumented call a
+// no-op. See internal/seed.SetMetrics for why this is a package-level var
+// rather than a parameter threaded through Service.
+var reg *metrics.Registry
+
+// SetMetrics wires a metrics registry into subsequent GetEntityNews calls.
+func SetMetrics(r *metrics.Registry) {
+	reg = r
+}
+
+// observeFetch records scoracle_news_fetch_total{provider,status} and
+// scoracle_news_fetch_duration_seconds{provider} for one provider's Fetch
+// call, plus scoracle_news_articles_returned{provider} for how many
+// articles it returned.
+func observeFetch(provider, status string, start time.Time, articleCount int) {
+	if reg == nil {
+		return
+	}
+	reg.IncCounter("scoracle_news_fetch_total", "News provider fetches, labeled by provider and status.",
+		map[string]string{"provider": provider, "status": status})
+	reg.ObserveDuration("scoracle_news_fetch_duration_seconds", "News provider fetch latency in seconds.",
+		map[string]string{"provider": provider}, start)
+	reg.ObserveHistogram("scoracle_news_articles_returned", "Articles returned per provider fetch.",
+		map[string]string{"provider": provider}, float64(articleCount))
+}
+
+// observeFilterDropped counts scoracle_news_filter_dropped_total{reason}
+// for articles discarded after a provider fetch succeeded (e.g. a rules
+// include/exclude mismatch).
+func observeFilterDropped(reason string) {
+	if reg == nil {
+		return
+	}
+	reg.IncCounter("scoracle_news_filter_dropped_total", "Articles dropped after fetch, labeled by reason.",
+		map[string]string{"reason": reason})
+}
@@ -0,0 +1,58 @@
+// Package news is a pluggable news-provider registry. Each source (Google
+// News RSS, NewsAPI, and config-driven team/league feeds via FeedProvider)
+// implements Provider and registers with a rate limiter via Service, so
+// adding a source is a single-file drop-in — or, for a team/league feed, a
+// config entry — rather than a change to the handler's hardcoded rss/api/both
+// switch.
+package news
+
+import "context"
+
+// Article is a normalized news article from any source. Content, Language,
+// Sentiment, and Tags are only populated when GetEntityNews is called with
+// enrich=true — see enrich.go.
+type Article struct {
+	Title       string  `json:"title"`
+	Description string  `json:"description"`
+	URL         string  `json:"url"`
+	Source      string  `json:"source"`
+	PublishedAt string  `json:"published_at"`
+	ImageURL    *string `json:"image_url"`
+	Author      *string `json:"author,omitempty"`
+
+	// Content is the extracted main body text of the article page (see
+	// extractMainContent), empty unless enrichment ran and the fetch
+	// succeeded.
+	Content string `json:"content,omitempty"`
+	// Language is a best-guess ISO 639-1 code from trigram matching over
+	// Content (see detectLanguage).
+	Language string `json:"language,omitempty"`
+	// Sentiment is a lexicon-based score in roughly [-5, 5], averaged over
+	// Content's tokens (see scoreSentiment). Zero both for neutral text and
+	// for un-enriched articles — Language being empty is the signal to
+	// distinguish the two.
+	Sentiment float32 `json:"sentiment,omitempty"`
+	// Tags are the most frequent capitalized phrases in Content (see
+	// extractTags) — a cheap stand-in for named-entity extraction.
+	Tags []string `json:"tags,omitempty"`
+}
+
+// Query carries the entity-matching parameters every provider needs to
+// search for and filter relevant articles.
+type Query struct {
+	EntityName string
+	Sport      string
+	Team       string
+	FirstName  string
+	LastName   string
+	Limit      int
+}
+
+// Provider is a single news source. Name identifies it for the `source`
+// query param and Status(); Weight breaks ties when merging results from
+// multiple providers (higher weight sorts first).
+type Provider interface {
+	Name() string
+	Weight() int
+	Fetch(ctx context.Context, q Query) ([]Article, error)
+}
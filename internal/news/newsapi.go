@@ -0,0 +1,111 @@
+package news
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const newsAPITimeout = 15 * time.Second
+
+// sportDomains restricts NewsAPI results to sport-relevant outlets, since
+// its free tier has no sport-specific filter.
+var sportDomains = map[string]string{
+	"NBA":      "espn.com,bleacherreport.com,nba.com,theathletic.com,cbssports.com",
+	"NFL":      "espn.com,bleacherreport.com,nfl.com,theathletic.com,cbssports.com",
+	"FOOTBALL": "espn.com,skysports.com,bbc.com,goal.com,theathletic.com,theguardian.com",
+}
+
+// NewsAPIProvider fetches articles from newsapi.org. It's a secondary
+// source gated behind an API key and a tight per-day quota.
+type NewsAPIProvider struct {
+	apiKey     string
+	httpClient *http.Client
+}
+
+// NewNewsAPIProvider returns a NewsAPIProvider. apiKey must be non-empty;
+// callers should only register this provider when one is configured.
+func NewNewsAPIProvider(apiKey string) *NewsAPIProvider {
+	return &NewsAPIProvider{
+		apiKey:     apiKey,
+		httpClient: &http.Client{Timeout: newsAPITimeout},
+	}
+}
+
+func (p *NewsAPIProvider) Name() string { return "api" }
+func (p *NewsAPIProvider) Weight() int  { return 1 }
+
+type newsAPIResponse struct {
+	Status       string `json:"status"`
+	TotalResults int    `json:"totalResults"`
+	Articles     []struct {
+		Source struct {
+			Name string `json:"name"`
+		} `json:"source"`
+		Author      *string `json:"author"`
+		Title       string  `json:"title"`
+		Description string  `json:"description"`
+		URL         string  `json:"url"`
+		URLToImage  *string `json:"urlToImage"`
+		PublishedAt string  `json:"publishedAt"`
+	} `json:"articles"`
+	Message string `json:"message"`
+}
+
+func (p *NewsAPIProvider) Fetch(ctx context.Context, q Query) ([]Article, error) {
+	limit := q.Limit
+	if limit < 1 {
+		limit = rssDefaultLimit
+	}
+
+	fromDate := time.Now().UTC().AddDate(0, 0, -7).Format("2006-01-02")
+
+	params := url.Values{}
+	params.Set("q", q.EntityName)
+	params.Set("from", fromDate)
+	params.Set("sortBy", "relevancy")
+	params.Set("pageSize", fmt.Sprintf("%d", limit))
+	params.Set("language", "en")
+	if domain, ok := sportDomains[strings.ToUpper(q.Sport)]; ok {
+		params.Set("domains", domain)
+	}
+
+	u := "https://newsapi.org/v2/everything?" + params.Encode()
+	req, err := http.NewRequestWithContext(ctx, "GET", u, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("X-Api-Key", p.apiKey)
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("newsapi request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var apiResp newsAPIResponse
+	if err := json.NewDecoder(resp.Body).Decode(&apiResp); err != nil {
+		return nil, fmt.Errorf("newsapi decode: %w", err)
+	}
+	if apiResp.Status != "ok" {
+		return nil, fmt.Errorf("newsapi error: %s", apiResp.Message)
+	}
+
+	articles := make([]Article, 0, len(apiResp.Articles))
+	for _, a := range apiResp.Articles {
+		articles = append(articles, Article{
+			Title:       a.Title,
+			Description: a.Description,
+			URL:         a.URL,
+			Source:      a.Source.Name,
+			Author:      a.Author,
+			PublishedAt: a.PublishedAt,
+			ImageURL:    a.URLToImage,
+		})
+	}
+	return articles, nil
+}
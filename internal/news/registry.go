@@ -0,0 +1,75 @@
+package news
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+
+	"golang.org/x/time/rate"
+)
+
+// Registry holds every registered Provider behind a per-source rate
+// limiter, shared across handler invocations so a burst of requests can't
+// blow through a source's daily/per-second quota.
+type Registry struct {
+	mu        sync.RWMutex
+	providers map[string]Provider
+	limiters  map[string]*rate.Limiter
+}
+
+// NewRegistry returns an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		providers: make(map[string]Provider),
+		limiters:  make(map[string]*rate.Limiter),
+	}
+}
+
+// Register adds p under p.Name(), rate-limited by limiter. A nil limiter
+// means unlimited.
+func (r *Registry) Register(p Provider, limiter *rate.Limiter) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.providers[p.Name()] = p
+	r.limiters[p.Name()] = limiter
+}
+
+// Names returns every registered provider name, sorted.
+func (r *Registry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	names := make([]string, 0, len(r.providers))
+	for name := range r.providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Lookup returns the provider registered under name, if any.
+func (r *Registry) Lookup(name string) (Provider, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	p, ok := r.providers[name]
+	return p, ok
+}
+
+// Fetch runs the named provider's Fetch, first checking its rate limiter.
+// A provider over quota returns an error rather than blocking, so a busy
+// source degrades the merged result instead of stalling the request.
+func (r *Registry) Fetch(ctx context.Context, name string, q Query) ([]Article, error) {
+	r.mu.RLock()
+	p, ok := r.providers[name]
+	limiter := r.limiters[name]
+	r.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("news: provider %q is not registered", name)
+	}
+	if limiter != nil && !limiter.Allow() {
+		log.Printf("[news] provider %q rate-limited, skipping", name)
+		return nil, fmt.Errorf("news: provider %q is rate-limited", name)
+	}
+	return p.Fetch(ctx, q)
+}
@@ -0,0 +1,173 @@
+package news
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/albapepper/scoracle-data/internal/httpcache"
+)
+
+const (
+	rssDefaultLimit = 10
+	rssMinArticles  = 3
+	rssTimeout      = 15 * time.Second
+)
+
+// timeWindows are the escalating lookback windows (hours) tried until
+// enough matching articles are found.
+var timeWindows = []int{24, 48, 168}
+
+// sportTerms are sport-specific search term suffixes appended to the
+// entity name to narrow RSS results.
+var sportTerms = map[string]string{
+	"NBA":      "NBA basketball",
+	"NFL":      "NFL football",
+	"FOOTBALL": "soccer football",
+}
+
+// RSSProvider fetches articles from Google News RSS. It's the primary,
+// always-available source (no API key required).
+type RSSProvider struct {
+	httpClient *http.Client
+}
+
+// NewRSSProvider returns an RSSProvider. cacheTransport, if non-nil, makes
+// the window-escalation fetches in Fetch conditional-GET aware, so
+// re-running the same query (or the same window across requests) costs a
+// 304 instead of a full re-download once Google News's response is fresh
+// in cache. Pass nil to fetch uncached.
+func NewRSSProvider(cacheTransport *httpcache.Transport) *RSSProvider {
+	var transport http.RoundTripper
+	if cacheTransport != nil {
+		transport = cacheTransport
+	}
+	return &RSSProvider{httpClient: &http.Client{Timeout: rssTimeout, Transport: transport}}
+}
+
+func (p *RSSProvider) Name() string { return "rss" }
+func (p *RSSProvider) Weight() int  { return 2 }
+
+// Fetch escalates through timeWindows until it collects rssMinArticles
+// matching articles or runs out of windows.
+func (p *RSSProvider) Fetch(ctx context.Context, q Query) ([]Article, error) {
+	searchName := buildSearchName(q.EntityName, q.FirstName, q.LastName)
+	searchQuery := searchName
+	if term, ok := sportTerms[strings.ToUpper(q.Sport)]; ok {
+		searchQuery = searchName + " " + term
+	}
+
+	limit := q.Limit
+	if limit < 1 {
+		limit = rssDefaultLimit
+	}
+
+	var matched []Article
+	for _, hours := range timeWindows {
+		articles, err := p.fetchWindow(ctx, searchQuery, hours)
+		if err != nil {
+			log.Printf("[news] rss fetch error (window=%dh): %v", hours, err)
+			continue
+		}
+		for _, a := range articles {
+			if nameInText(q.EntityName, a.Title, q.FirstName, q.LastName, q.Team) {
+				matched = append(matched, a)
+			}
+		}
+		matched = deduplicateArticles(matched)
+		if len(matched) >= rssMinArticles {
+			break
+		}
+	}
+
+	sortArticlesByDate(matched)
+	if len(matched) > limit {
+		matched = matched[:limit]
+	}
+	return matched, nil
+}
+
+type rssFeed struct {
+	XMLName xml.Name  `xml:"rss"`
+	Items   []rssItem `xml:"channel>item"`
+}
+
+type rssItem struct {
+	Title       string `xml:"title"`
+	Link        string `xml:"link"`
+	PubDate     string `xml:"pubDate"`
+	Description string `xml:"description"`
+}
+
+func (p *RSSProvider) fetchWindow(ctx context.Context, query string, hoursBack int) ([]Article, error) {
+	when := "1d"
+	if hoursBack > 24 && hoursBack <= 168 {
+		when = "7d"
+	} else if hoursBack > 168 {
+		when = "30d"
+	}
+
+	u := fmt.Sprintf(
+		"https://news.google.com/rss/search?q=%s+when:%s&hl=en-US&gl=US&ceid=US:en",
+		url.QueryEscape(query), when,
+	)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", u, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", "Mozilla/5.0 (compatible; ScoracleBot/1.0)")
+	req.Header.Set("Accept", "application/rss+xml, application/xml, text/xml")
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("rss fetch: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("rss http %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("rss read: %w", err)
+	}
+
+	var feed rssFeed
+	if err := xml.Unmarshal(body, &feed); err != nil {
+		return nil, fmt.Errorf("rss parse: %w", err)
+	}
+
+	htmlTagRe := regexp.MustCompile(`<[^>]+>`)
+	articles := make([]Article, 0, len(feed.Items))
+	for _, item := range feed.Items {
+		title := item.Title
+		source := "Google News"
+		if idx := strings.LastIndex(title, " - "); idx != -1 {
+			source = strings.TrimSpace(title[idx+3:])
+			title = strings.TrimSpace(title[:idx])
+		}
+
+		desc := htmlTagRe.ReplaceAllString(item.Description, "")
+		if len(desc) > 300 {
+			desc = desc[:300] + "..."
+		}
+
+		articles = append(articles, Article{
+			Title:       title,
+			Description: desc,
+			URL:         item.Link,
+			Source:      source,
+			PublishedAt: item.PubDate,
+		})
+	}
+	return articles, nil
+}
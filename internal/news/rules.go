@@ -0,0 +1,105 @@
+package news
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Rule is one include/exclude word list, applied independently to
+// Article.Title, Article.Description, and Article.Source.
+type Rule struct {
+	MustInclude []string `json:"must_include,omitempty" yaml:"must_include,omitempty"`
+	MustExclude []string `json:"must_exclude,omitempty" yaml:"must_exclude,omitempty"`
+}
+
+// RuleSet holds content include/exclude rules: a default applied to every
+// article, overridden per sport, overridden again per entity (most specific
+// wins). Loaded once at startup — see LoadRuleSet — so operators can
+// suppress spam (e.g. gambling, injury-rumor clickbait) or require
+// sport-context words ("basketball") without recompiling.
+type RuleSet struct {
+	Default  *Rule           `json:"default,omitempty" yaml:"default,omitempty"`
+	BySport  map[string]Rule `json:"by_sport,omitempty" yaml:"by_sport,omitempty"`
+	ByEntity map[string]Rule `json:"by_entity,omitempty" yaml:"by_entity,omitempty"`
+}
+
+// LoadRuleSet reads path as YAML (.yaml/.yml extension) or JSON (anything
+// else) into a RuleSet. An empty path returns an empty RuleSet — its zero
+// value matches every article, same as no rules configured at all.
+func LoadRuleSet(path string) (*RuleSet, error) {
+	if path == "" {
+		return &RuleSet{}, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read news rules file: %w", err)
+	}
+
+	var rs RuleSet
+	if strings.HasSuffix(path, ".yaml") || strings.HasSuffix(path, ".yml") {
+		if err := yaml.Unmarshal(data, &rs); err != nil {
+			return nil, fmt.Errorf("parse news rules yaml: %w", err)
+		}
+	} else if err := json.Unmarshal(data, &rs); err != nil {
+		return nil, fmt.Errorf("parse news rules json: %w", err)
+	}
+	return &rs, nil
+}
+
+// ruleFor resolves the most specific rule for (entityName, sport): a
+// per-entity rule overrides a per-sport rule overrides the default. Returns
+// nil if none apply, meaning every article passes.
+func (rs *RuleSet) ruleFor(entityName, sport string) *Rule {
+	if rs == nil {
+		return nil
+	}
+	if r, ok := rs.ByEntity[entityName]; ok {
+		return &r
+	}
+	if r, ok := rs.BySport[strings.ToUpper(sport)]; ok {
+		return &r
+	}
+	return rs.Default
+}
+
+// matches reports whether a passes r's must_include/must_exclude checks.
+// Title, Description, and Source are each checked independently: if
+// MustInclude is non-empty, the field must contain at least one of its
+// words (whole-word, case-insensitive) — an empty field never does, so it
+// fails the check — and if MustExclude is non-empty, the field must
+// contain none of its words — an empty field trivially passes.
+func (r *Rule) matches(a Article) bool {
+	if r == nil {
+		return true
+	}
+	for _, field := range []string{a.Title, a.Description, a.Source} {
+		if len(r.MustInclude) > 0 && !containsAnyWord(field, r.MustInclude) {
+			return false
+		}
+		if containsAnyWord(field, r.MustExclude) {
+			return false
+		}
+	}
+	return true
+}
+
+func containsAnyWord(text string, words []string) bool {
+	if text == "" {
+		return false
+	}
+	textLower := strings.ToLower(text)
+	for _, w := range words {
+		w = strings.ToLower(strings.TrimSpace(w))
+		if w == "" {
+			continue
+		}
+		if wordBoundaryMatch(w, textLower) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,55 @@
+package news
+
+import (
+	_ "embed"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+//go:embed afinn_subset.tsv
+var afinnData string
+
+// afinnScores maps a lowercased token to its AFINN-style valence in
+// [-5, 5]. See afinn_subset.tsv for why this is a hand-picked subset of
+// AFINN-165 rather than the full word list.
+var afinnScores = parseAFINN(afinnData)
+
+func parseAFINN(data string) map[string]int {
+	scores := make(map[string]int)
+	for _, line := range strings.Split(data, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		parts := strings.SplitN(line, "\t", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		n, err := strconv.Atoi(strings.TrimSpace(parts[1]))
+		if err != nil {
+			continue
+		}
+		scores[parts[0]] = n
+	}
+	return scores
+}
+
+// sentimentTokenRe splits text into word tokens for lexicon lookup.
+var sentimentTokenRe = regexp.MustCompile(`[a-zA-Z']+`)
+
+// scoreSentiment sums each token's AFINN score and normalizes by token
+// count, so a long article isn't scored higher just for repeating a mildly
+// positive word more often than a short one. Returns 0 for empty input or
+// text with no scored tokens.
+func scoreSentiment(text string) float32 {
+	tokens := sentimentTokenRe.FindAllString(strings.ToLower(text), -1)
+	if len(tokens) == 0 {
+		return 0
+	}
+	var sum int
+	for _, t := range tokens {
+		sum += afinnScores[t]
+	}
+	return float32(sum) / float32(len(tokens))
+}
@@ -0,0 +1,227 @@
+package news
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	"github.com/albapepper/scoracle-data/internal/httpcache"
+)
+
+// Per-source rate limits, chosen from each provider's published free-tier
+// quota: Google News RSS tolerates roughly 1 req/sec before throttling;
+// NewsAPI's free tier caps out around 100 requests/day.
+var (
+	rssRateLimit     = rate.NewLimiter(rate.Limit(1), 2)
+	newsAPIRateLimit = rate.NewLimiter(rate.Every(24*time.Hour/100), 1)
+)
+
+// Service is the facade handlers call: it resolves a comma-separated
+// `source` query param against the registry, fetches every requested
+// provider concurrently, merges/dedupes the results, and applies rules'
+// content include/exclude filters.
+type Service struct {
+	reg          *Registry
+	rules        *RuleSet
+	cacheTran    *httpcache.Transport
+	enrichClient *http.Client
+}
+
+// NewService builds a Service with the RSS provider always registered, the
+// NewsAPI provider registered only when apiKey is configured, one
+// FeedProvider per entry in feeds (team/league Atom/RSS/JSON Feed sources —
+// see config.Config.NewsFeeds), and rules loaded from rulesFile (empty
+// means no content filtering). cacheTransport wraps the RSS provider's HTTP
+// client so repeat fetches of the same Google News window cost a 304
+// instead of a full re-download; pass nil to fetch uncached.
+func NewService(apiKey string, feeds []string, rulesFile string, cacheTransport *httpcache.Transport) *Service {
+	reg := NewRegistry()
+	reg.Register(NewRSSProvider(cacheTransport), rssRateLimit)
+	if apiKey != "" {
+		reg.Register(NewNewsAPIProvider(apiKey), newsAPIRateLimit)
+	}
+	RegisterFeedProviders(reg, feeds)
+
+	rules, err := LoadRuleSet(rulesFile)
+	if err != nil {
+		log.Printf("[news] loading rules from %q failed, content filtering disabled: %v", rulesFile, err)
+		rules = &RuleSet{}
+	}
+
+	var enrichTransport http.RoundTripper
+	if cacheTransport != nil {
+		enrichTransport = cacheTransport
+	}
+
+	return &Service{
+		reg:          reg,
+		rules:        rules,
+		cacheTran:    cacheTransport,
+		enrichClient: &http.Client{Timeout: enrichTimeout, Transport: enrichTransport},
+	}
+}
+
+// HasNewsAPI reports whether the "api" provider is registered.
+func (s *Service) HasNewsAPI() bool {
+	_, ok := s.reg.Lookup("api")
+	return ok
+}
+
+// Status returns service configuration status, keyed by registered
+// provider name, plus the upstream HTTP cache's hit/miss/revalidation
+// counts if caching is enabled.
+func (s *Service) Status() map[string]interface{} {
+	status := map[string]interface{}{
+		"providers":      s.reg.Names(),
+		"primary_source": "rss",
+		"api_configured": s.HasNewsAPI(),
+	}
+	if s.cacheTran != nil {
+		status["http_cache"] = s.cacheTran.Stats()
+	}
+	return status
+}
+
+// GetEntityNews fetches news for an entity from the providers named in
+// sourceParam (comma-separated; empty means every registered provider),
+// applies s.rules' content include/exclude filters, and merges/dedupes the
+// survivors by URL (or title, if a provider omits one). When enrich is
+// true, each surviving article's page is fetched and run through the
+// extraction/language/sentiment pipeline in enrich.go before returning —
+// see its doc comment for cost/concurrency. The handler wires this to an
+// `enrich=true` query param on the news endpoint.
+func (s *Service) GetEntityNews(
+	ctx context.Context,
+	entityName, sport, team, sourceParam string,
+	limit int,
+	firstName, lastName string,
+	enrich bool,
+) (map[string]interface{}, error) {
+	names := s.resolveSources(sourceParam)
+	if len(names) == 0 {
+		return nil, fmt.Errorf("no news providers available for sources %q", sourceParam)
+	}
+
+	q := Query{
+		EntityName: entityName,
+		Sport:      sport,
+		Team:       team,
+		FirstName:  firstName,
+		LastName:   lastName,
+		Limit:      limit,
+	}
+
+	type fetched struct {
+		provider    Provider
+		articles    []Article
+		err         error
+		cacheStatus string
+	}
+	results := make([]fetched, len(names))
+
+	var wg sync.WaitGroup
+	for i, name := range names {
+		wg.Add(1)
+		go func(i int, name string) {
+			defer wg.Done()
+			p, _ := s.reg.Lookup(name)
+
+			var cacheStatus string
+			fetchCtx := httpcache.WithStatusSink(ctx, &cacheStatus)
+			start := time.Now()
+			articles, err := s.reg.Fetch(fetchCtx, name, q)
+			status := "success"
+			if err != nil {
+				status = "error"
+				log.Printf("[news] provider %q fetch error: %v", name, err)
+			}
+			observeFetch(name, status, start, len(articles))
+			results[i] = fetched{provider: p, articles: articles, err: err, cacheStatus: cacheStatus}
+		}(i, name)
+	}
+	wg.Wait()
+
+	// Merge highest-weight provider first so ties in dedup favor it.
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].provider == nil || results[j].provider == nil {
+			return false
+		}
+		return results[i].provider.Weight() > results[j].provider.Weight()
+	})
+
+	rule := s.rules.ruleFor(entityName, sport)
+
+	seen := make(map[string]bool)
+	var merged []Article
+	counts := make(map[string]int)
+	filteredByRules := 0
+	upstreamCache := ""
+	for _, r := range results {
+		if r.provider == nil {
+			continue
+		}
+		counts[r.provider.Name()] = len(r.articles)
+		if r.provider.Name() == "rss" {
+			upstreamCache = r.cacheStatus
+		}
+		for _, a := range r.articles {
+			if !rule.matches(a) {
+				filteredByRules++
+				observeFilterDropped("rule")
+				continue
+			}
+			key := dedupeKey(a)
+			if key == "" || seen[key] {
+				continue
+			}
+			seen[key] = true
+			merged = append(merged, a)
+		}
+	}
+
+	sortArticlesByDate(merged)
+	if limit > 0 && len(merged) > limit {
+		merged = merged[:limit]
+	}
+
+	if enrich && len(merged) > 0 {
+		merged = enrichArticles(ctx, s.enrichClient, merged)
+	}
+
+	return map[string]interface{}{
+		"query":    entityName,
+		"sport":    sport,
+		"articles": merged,
+		"provider": strings.Join(names, ","),
+		"meta": map[string]interface{}{
+			"per_provider_counts": counts,
+			"merged_count":        len(merged),
+			"filtered_by_rules":   filteredByRules,
+			"X-Upstream-Cache":    upstreamCache,
+		},
+	}, nil
+}
+
+// resolveSources parses the comma-separated `source` query param against
+// the registry. Empty or "both" (kept for backward compatibility with the
+// old rss/api/both query values) means every registered provider.
+func (s *Service) resolveSources(sourceParam string) []string {
+	if sourceParam == "" || sourceParam == "both" {
+		return s.reg.Names()
+	}
+	var names []string
+	for _, part := range strings.Split(sourceParam, ",") {
+		name := strings.TrimSpace(part)
+		if _, ok := s.reg.Lookup(name); ok {
+			names = append(names, name)
+		}
+	}
+	return names
+}
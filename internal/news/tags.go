@@ -0,0 +1,39 @@
+package news
+
+import (
+	"regexp"
+	"sort"
+)
+
+// capitalizedPhraseRe matches a run of capitalized words (likely proper
+// nouns: team names, player names, sponsors), so extractTags can surface
+// what an article is actually about without a full NLP pipeline.
+var capitalizedPhraseRe = regexp.MustCompile(`\b[A-Z][a-zA-Z'-]+(?:\s+[A-Z][a-zA-Z'-]+)*\b`)
+
+// maxTags bounds how many tags extractTags returns per article.
+const maxTags = 5
+
+// extractTags returns the maxTags most frequent capitalized phrases in
+// text, longest-match-first on ties so "Los Angeles Lakers" wins over
+// "Lakers" alone when both occur equally often.
+func extractTags(text string) []string {
+	counts := make(map[string]int)
+	for _, m := range capitalizedPhraseRe.FindAllString(text, -1) {
+		counts[m]++
+	}
+
+	tags := make([]string, 0, len(counts))
+	for t := range counts {
+		tags = append(tags, t)
+	}
+	sort.Slice(tags, func(i, j int) bool {
+		if counts[tags[i]] != counts[tags[j]] {
+			return counts[tags[i]] > counts[tags[j]]
+		}
+		return len(tags[i]) > len(tags[j])
+	})
+	if len(tags) > maxTags {
+		tags = tags[:maxTags]
+	}
+	return tags
+}
@@ -0,0 +1,146 @@
+package newswatch
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	"github.com/albapepper/scoracle-data/internal/news"
+)
+
+const (
+	deliverTimeout     = 10 * time.Second
+	deliverMaxAttempts = 5
+	deliverBaseDelay   = 2 * time.Second
+	deliverMaxDelay    = 5 * time.Minute
+	defaultHostRPS     = 1.0
+)
+
+// SignatureHeader carries the hex-encoded HMAC-SHA256 signature of the raw
+// request body, keyed by the subscription's HMACSecret, so a receiver can
+// verify a delivery actually came from this poller.
+const SignatureHeader = "X-Newswatch-Signature"
+
+// deliveryPayload is the JSON body POSTed to Subscription.DeliveryURL.
+type deliveryPayload struct {
+	SubscriptionID int            `json:"subscription_id"`
+	EntityName     string         `json:"entity_name"`
+	Sport          string         `json:"sport"`
+	Articles       []news.Article `json:"articles"`
+}
+
+// hostLimiters holds one token-bucket limiter per delivery host — the same
+// register-by-key idiom as breaker.Limiters — so many subscriptions pointed
+// at the same host don't burst it, while a slow host doesn't throttle
+// deliveries to every other one.
+type hostLimiters struct {
+	mu       sync.Mutex
+	limiters map[string]*rate.Limiter
+}
+
+func newHostLimiters() *hostLimiters {
+	return &hostLimiters{limiters: make(map[string]*rate.Limiter)}
+}
+
+func (l *hostLimiters) wait(ctx context.Context, host string) error {
+	l.mu.Lock()
+	lim, ok := l.limiters[host]
+	if !ok {
+		lim = rate.NewLimiter(rate.Limit(defaultHostRPS), 1)
+		l.limiters[host] = lim
+	}
+	l.mu.Unlock()
+	return lim.Wait(ctx)
+}
+
+// sign returns the hex-encoded HMAC-SHA256 of body keyed by secret.
+func sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// deliverBackoff returns the exponential backoff (base 2s, cap 5m) with full
+// jitter for the given attempt count (1-indexed) — the same shape as
+// fixture.backoffDelay, scaled down for an HTTP push rather than an
+// hours-long upstream-provider retry.
+func deliverBackoff(attempt int) time.Duration {
+	if attempt < 1 {
+		attempt = 1
+	}
+	raw := float64(deliverBaseDelay) * math.Pow(2, float64(attempt-1))
+	if raw > float64(deliverMaxDelay) {
+		raw = float64(deliverMaxDelay)
+	}
+	return time.Duration(rand.Int63n(int64(raw) + 1))
+}
+
+// deliver POSTs articles to sub.DeliveryURL, signing the body with
+// sub.HMACSecret, retrying non-2xx responses on deliverBackoff up to
+// deliverMaxAttempts times. limiters rate-limits per delivery host so one
+// subscription's retries can't starve another's.
+func deliver(ctx context.Context, client *http.Client, limiters *hostLimiters, sub Subscription, articles []news.Article) error {
+	body, err := json.Marshal(deliveryPayload{
+		SubscriptionID: sub.ID,
+		EntityName:     sub.EntityName,
+		Sport:          sub.Sport,
+		Articles:       articles,
+	})
+	if err != nil {
+		return fmt.Errorf("marshal delivery payload: %w", err)
+	}
+
+	host := sub.DeliveryURL
+	if u, err := url.Parse(sub.DeliveryURL); err == nil {
+		host = u.Host
+	}
+
+	var lastErr error
+	for attempt := 1; attempt <= deliverMaxAttempts; attempt++ {
+		if attempt > 1 {
+			select {
+			case <-time.After(deliverBackoff(attempt - 1)):
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+
+		if err := limiters.wait(ctx, host); err != nil {
+			return err
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, sub.DeliveryURL, bytes.NewReader(body))
+		if err != nil {
+			return fmt.Errorf("build delivery request: %w", err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set(SignatureHeader, sign(sub.HMACSecret, body))
+
+		resp, err := client.Do(req)
+		if err != nil {
+			lastErr = fmt.Errorf("delivery post: %w", err)
+			observeDelivery("error")
+			continue
+		}
+		resp.Body.Close()
+		if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+			observeDelivery("success")
+			return nil
+		}
+		lastErr = fmt.Errorf("delivery returned status %d", resp.StatusCode)
+		observeDelivery("rejected")
+	}
+	return lastErr
+}
@@ -0,0 +1,41 @@
+package newswatch
+
+import (
+	"time"
+
+	"github.com/albapepper/scoracle-data/internal/metrics"
+)
+
+// reg is the process-wide metrics registry, set once at startup via
+// SetMetrics. A nil reg (the default) makes every instrumented call a
+// no-op. See internal/seed.SetMetrics for why this is a package-level var
+// rather than a parameter threaded through Poller.
+var reg *metrics.Registry
+
+// SetMetrics wires a metrics registry into subsequent Poller.Run calls.
+func SetMetrics(r *metrics.Registry) {
+	reg = r
+}
+
+// observePoll records scoracle_newswatch_poll_total{sport,result} and
+// scoracle_newswatch_poll_duration_seconds{sport} for one subscription's
+// poll.
+func observePoll(sport, result string, start time.Time) {
+	if reg == nil {
+		return
+	}
+	reg.IncCounter("scoracle_newswatch_poll_total", "Newswatch subscription polls, labeled by sport and result.",
+		map[string]string{"sport": sport, "result": result})
+	reg.ObserveDuration("scoracle_newswatch_poll_duration_seconds", "Newswatch poll latency in seconds.",
+		map[string]string{"sport": sport}, start)
+}
+
+// observeDelivery counts scoracle_newswatch_delivery_total{result} for one
+// webhook delivery attempt, including retries.
+func observeDelivery(result string) {
+	if reg == nil {
+		return
+	}
+	reg.IncCounter("scoracle_newswatch_delivery_total", "Newswatch webhook delivery attempts, labeled by result.",
+		map[string]string{"result": result})
+}
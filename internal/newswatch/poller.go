@@ -0,0 +1,156 @@
+package newswatch
+
+import (
+	"context"
+	"log/slog"
+	"math/rand"
+	"net/http"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/albapepper/scoracle-data/internal/news"
+)
+
+const (
+	pollLoopInterval = 30 * time.Second
+	pollTimeout      = 20 * time.Second
+	// pollJitter spreads due subscriptions out over a few seconds so
+	// hundreds registered with the same MinIntervalSeconds don't all hit
+	// news.Service.GetEntityNews (and, in turn, Google News) on the same
+	// tick.
+	pollJitter = 5 * time.Second
+)
+
+// Poller periodically fetches news for every due Subscription, diffs the
+// results against each subscription's seen-URL set, and delivers newly
+// discovered articles to DeliveryURL.
+type Poller struct {
+	pool     *pgxpool.Pool
+	news     *news.Service
+	client   *http.Client
+	limiters *hostLimiters
+	logger   *slog.Logger
+}
+
+// NewPoller creates a Poller. newsService should be the same Service
+// instance the API handlers use, so the poller shares its rate-limited
+// Registry and HTTP cache rather than competing with foreground requests
+// for the same provider quota.
+func NewPoller(pool *pgxpool.Pool, newsService *news.Service, logger *slog.Logger) *Poller {
+	return &Poller{
+		pool:     pool,
+		news:     newsService,
+		client:   &http.Client{Timeout: deliverTimeout},
+		limiters: newHostLimiters(),
+		logger:   logger,
+	}
+}
+
+// Run polls every pollLoopInterval until ctx is canceled. Intended to run as
+// a background goroutine alongside the API server — the same shape as
+// external.TwitterService.StartBackgroundRefresh and
+// fixture.Retrier.RunRetryLoop.
+func (p *Poller) Run(ctx context.Context) {
+	ticker := time.NewTicker(pollLoopInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			p.runOnce(ctx)
+		}
+	}
+}
+
+func (p *Poller) runOnce(ctx context.Context) {
+	subs, err := DueSubscriptions(ctx, p.pool)
+	if err != nil {
+		p.logger.Warn("newswatch: list due subscriptions failed", "error", err)
+		return
+	}
+
+	for _, sub := range subs {
+		select {
+		case <-time.After(time.Duration(rand.Int63n(int64(pollJitter)))):
+		case <-ctx.Done():
+			return
+		}
+		p.pollOne(ctx, sub)
+	}
+}
+
+// pollOne fetches news for one subscription, delivers whatever hasn't been
+// seen before, and stamps last_polled_at regardless of outcome (a delivery
+// failure is retried next tick rather than immediately, so a dead
+// DeliveryURL doesn't starve every other subscription's poll).
+func (p *Poller) pollOne(ctx context.Context, sub Subscription) {
+	start := time.Now()
+	pollCtx, cancel := context.WithTimeout(ctx, pollTimeout)
+	defer cancel()
+
+	// Webhook delivery only needs headline/URL/description, not full-article
+	// enrichment, so enrich stays false here — that cost is opt-in per
+	// foreground request via the handler's enrich=true query param.
+	result, err := p.news.GetEntityNews(pollCtx, sub.EntityName, sub.Sport, sub.Team, "", 0, sub.FirstName, sub.LastName, false)
+	if err != nil {
+		observePoll(sub.Sport, "error", start)
+		p.logger.Warn("newswatch: poll failed", "subscription_id", sub.ID, "error", err)
+		p.finishPoll(ctx, sub.ID)
+		return
+	}
+
+	articles, _ := result["articles"].([]news.Article)
+	if len(articles) == 0 {
+		observePoll(sub.Sport, "empty", start)
+		p.finishPoll(ctx, sub.ID)
+		return
+	}
+
+	urls := make([]string, 0, len(articles))
+	for _, a := range articles {
+		urls = append(urls, a.URL)
+	}
+	seen, err := FilterUnseen(ctx, p.pool, sub.ID, urls)
+	if err != nil {
+		p.logger.Warn("newswatch: filter unseen failed", "subscription_id", sub.ID, "error", err)
+		p.finishPoll(ctx, sub.ID)
+		return
+	}
+
+	var fresh []news.Article
+	var freshURLs []string
+	for _, a := range articles {
+		if seen[a.URL] {
+			continue
+		}
+		fresh = append(fresh, a)
+		freshURLs = append(freshURLs, a.URL)
+	}
+
+	if len(fresh) == 0 {
+		observePoll(sub.Sport, "no_new_articles", start)
+		p.finishPoll(ctx, sub.ID)
+		return
+	}
+
+	if err := deliver(ctx, p.client, p.limiters, sub, fresh); err != nil {
+		observePoll(sub.Sport, "delivery_failed", start)
+		p.logger.Warn("newswatch: delivery failed", "subscription_id", sub.ID, "delivery_url", sub.DeliveryURL, "error", err)
+		p.finishPoll(ctx, sub.ID)
+		return
+	}
+
+	if err := MarkSeen(ctx, p.pool, sub.ID, freshURLs); err != nil {
+		p.logger.Warn("newswatch: mark seen failed", "subscription_id", sub.ID, "error", err)
+	}
+	observePoll(sub.Sport, "delivered", start)
+	p.finishPoll(ctx, sub.ID)
+}
+
+func (p *Poller) finishPoll(ctx context.Context, subscriptionID int) {
+	if err := MarkPolled(ctx, p.pool, subscriptionID); err != nil {
+		p.logger.Warn("newswatch: mark polled failed", "subscription_id", subscriptionID, "error", err)
+	}
+}
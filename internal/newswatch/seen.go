@@ -0,0 +1,66 @@
+package newswatch
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// maxSeenURLs bounds how many seen URLs are kept per subscription — old
+// enough that a rotated-out article couldn't still be "new", small enough
+// that a long-lived subscription's bookkeeping doesn't grow unbounded.
+const maxSeenURLs = 500
+
+// FilterUnseen reports which of urls have already been delivered for
+// subscriptionID, without marking anything seen — the caller does that only
+// after a successful delivery, via MarkSeen.
+func FilterUnseen(ctx context.Context, pool *pgxpool.Pool, subscriptionID int, urls []string) (map[string]bool, error) {
+	seen := make(map[string]bool, len(urls))
+	if len(urls) == 0 {
+		return seen, nil
+	}
+	rows, err := pool.Query(ctx, `
+		SELECT url FROM newswatch_seen_urls WHERE subscription_id = $1 AND url = ANY($2)`,
+		subscriptionID, urls)
+	if err != nil {
+		return nil, fmt.Errorf("filter unseen urls: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var u string
+		if err := rows.Scan(&u); err != nil {
+			return nil, fmt.Errorf("scan seen url: %w", err)
+		}
+		seen[u] = true
+	}
+	return seen, rows.Err()
+}
+
+// MarkSeen records urls as delivered for subscriptionID, then trims entries
+// older than the maxSeenURLs most recent so a subscription's bookkeeping
+// stays bounded regardless of how long it's been running.
+func MarkSeen(ctx context.Context, pool *pgxpool.Pool, subscriptionID int, urls []string) error {
+	for _, u := range urls {
+		_, err := pool.Exec(ctx, `
+			INSERT INTO newswatch_seen_urls (subscription_id, url, seen_at)
+			VALUES ($1, $2, NOW())
+			ON CONFLICT (subscription_id, url) DO UPDATE SET seen_at = EXCLUDED.seen_at`,
+			subscriptionID, u)
+		if err != nil {
+			return fmt.Errorf("mark seen url: %w", err)
+		}
+	}
+	_, err := pool.Exec(ctx, `
+		DELETE FROM newswatch_seen_urls
+		WHERE subscription_id = $1 AND url NOT IN (
+			SELECT url FROM newswatch_seen_urls
+			WHERE subscription_id = $1
+			ORDER BY seen_at DESC
+			LIMIT $2
+		)`, subscriptionID, maxSeenURLs)
+	if err != nil {
+		return fmt.Errorf("trim seen urls: %w", err)
+	}
+	return nil
+}
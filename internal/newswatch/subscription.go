@@ -0,0 +1,134 @@
+// Package newswatch turns internal/news' pull-based GetEntityNews into a
+// push subscription: operators register a Subscription naming an entity and
+// a DeliveryURL, a background Poller periodically fetches news for it and
+// diffs against a per-subscription seen-URL set, and newly discovered
+// articles are POSTed to DeliveryURL with an HMAC signature. See poller.go
+// for the fetch/diff/deliver loop and deliver.go for the webhook push
+// itself.
+package newswatch
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// minPollInterval floors Subscription.MinIntervalSeconds so a misconfigured
+// client can't make the poller hammer a provider every tick.
+const minPollInterval = 60 * time.Second
+
+// Subscription is a standing request to poll news.Service.GetEntityNews for
+// one entity and push newly discovered articles to DeliveryURL.
+type Subscription struct {
+	ID                 int        `json:"id"`
+	EntityName         string     `json:"entity_name"`
+	Sport              string     `json:"sport"`
+	Team               string     `json:"team,omitempty"`
+	FirstName          string     `json:"first_name,omitempty"`
+	LastName           string     `json:"last_name,omitempty"`
+	MinIntervalSeconds int        `json:"min_interval_seconds"`
+	DeliveryURL        string     `json:"delivery_url"`
+	HMACSecret         string     `json:"-"` // never echoed back over the API
+	CreatedAt          time.Time  `json:"created_at"`
+	LastPolledAt       *time.Time `json:"last_polled_at,omitempty"`
+}
+
+// CreateSubscription inserts a new subscription, clamping MinIntervalSeconds
+// up to minPollInterval if needed.
+func CreateSubscription(ctx context.Context, pool *pgxpool.Pool, sub Subscription) (Subscription, error) {
+	if sub.MinIntervalSeconds < int(minPollInterval.Seconds()) {
+		sub.MinIntervalSeconds = int(minPollInterval.Seconds())
+	}
+	err := pool.QueryRow(ctx, `
+		INSERT INTO newswatch_subscriptions (
+			entity_name, sport, team, first_name, last_name,
+			min_interval_seconds, delivery_url, hmac_secret, created_at
+		) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,NOW())
+		RETURNING id, created_at`,
+		sub.EntityName, sub.Sport, nullable(sub.Team), nullable(sub.FirstName), nullable(sub.LastName),
+		sub.MinIntervalSeconds, sub.DeliveryURL, sub.HMACSecret,
+	).Scan(&sub.ID, &sub.CreatedAt)
+	if err != nil {
+		return Subscription{}, fmt.Errorf("insert newswatch subscription: %w", err)
+	}
+	return sub, nil
+}
+
+// ListSubscriptions returns every subscription, newest first. HMACSecret is
+// omitted (callers needing it for delivery use DueSubscriptions instead).
+func ListSubscriptions(ctx context.Context, pool *pgxpool.Pool) ([]Subscription, error) {
+	rows, err := pool.Query(ctx, `
+		SELECT id, entity_name, sport, COALESCE(team,''), COALESCE(first_name,''), COALESCE(last_name,''),
+			min_interval_seconds, delivery_url, created_at, last_polled_at
+		FROM newswatch_subscriptions
+		ORDER BY created_at DESC`)
+	if err != nil {
+		return nil, fmt.Errorf("list newswatch subscriptions: %w", err)
+	}
+	defer rows.Close()
+
+	var subs []Subscription
+	for rows.Next() {
+		var s Subscription
+		if err := rows.Scan(&s.ID, &s.EntityName, &s.Sport, &s.Team, &s.FirstName, &s.LastName,
+			&s.MinIntervalSeconds, &s.DeliveryURL, &s.CreatedAt, &s.LastPolledAt); err != nil {
+			return nil, fmt.Errorf("scan newswatch subscription: %w", err)
+		}
+		subs = append(subs, s)
+	}
+	return subs, rows.Err()
+}
+
+// DeleteSubscription removes a subscription and its seen-URL bookkeeping.
+func DeleteSubscription(ctx context.Context, pool *pgxpool.Pool, id int) error {
+	if _, err := pool.Exec(ctx, `DELETE FROM newswatch_seen_urls WHERE subscription_id = $1`, id); err != nil {
+		return fmt.Errorf("delete newswatch seen urls: %w", err)
+	}
+	if _, err := pool.Exec(ctx, `DELETE FROM newswatch_subscriptions WHERE id = $1`, id); err != nil {
+		return fmt.Errorf("delete newswatch subscription: %w", err)
+	}
+	return nil
+}
+
+// DueSubscriptions returns subscriptions whose MinIntervalSeconds has
+// elapsed since LastPolledAt (or that have never been polled), for the
+// Poller's tick.
+func DueSubscriptions(ctx context.Context, pool *pgxpool.Pool) ([]Subscription, error) {
+	rows, err := pool.Query(ctx, `
+		SELECT id, entity_name, sport, COALESCE(team,''), COALESCE(first_name,''), COALESCE(last_name,''),
+			min_interval_seconds, delivery_url, hmac_secret, created_at, last_polled_at
+		FROM newswatch_subscriptions
+		WHERE last_polled_at IS NULL
+			OR last_polled_at <= NOW() - (min_interval_seconds * interval '1 second')`)
+	if err != nil {
+		return nil, fmt.Errorf("due newswatch subscriptions: %w", err)
+	}
+	defer rows.Close()
+
+	var subs []Subscription
+	for rows.Next() {
+		var s Subscription
+		if err := rows.Scan(&s.ID, &s.EntityName, &s.Sport, &s.Team, &s.FirstName, &s.LastName,
+			&s.MinIntervalSeconds, &s.DeliveryURL, &s.HMACSecret, &s.CreatedAt, &s.LastPolledAt); err != nil {
+			return nil, fmt.Errorf("scan due newswatch subscription: %w", err)
+		}
+		subs = append(subs, s)
+	}
+	return subs, rows.Err()
+}
+
+// MarkPolled stamps a subscription's last_polled_at, so DueSubscriptions
+// skips it until its interval elapses again.
+func MarkPolled(ctx context.Context, pool *pgxpool.Pool, id int) error {
+	_, err := pool.Exec(ctx, `UPDATE newswatch_subscriptions SET last_polled_at = NOW() WHERE id = $1`, id)
+	return err
+}
+
+func nullable(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return s
+}
@@ -6,12 +6,14 @@ import (
 	"math"
 
 	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/albapepper/scoracle-data/internal/db"
 )
 
 // DetectChanges finds percentile movements that cross milestones or exceed
 // the delta threshold for entities involved in a fixture.
 func DetectChanges(ctx context.Context, pool *pgxpool.Pool, fixtureID int) ([]Change, error) {
-	rows, err := pool.Query(ctx, "detect_percentile_changes", fixtureID)
+	rows, err := db.QueryDetectPercentileChanges(ctx, pool, fixtureID)
 	if err != nil {
 		return nil, fmt.Errorf("detect percentile changes: %w", err)
 	}
@@ -0,0 +1,223 @@
+package notifications
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"math/rand"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"golang.org/x/time/rate"
+
+	"github.com/albapepper/scoracle-data/internal/errreport"
+)
+
+// DispatchMetrics holds Prometheus-style counters for the dispatcher, keyed
+// by outcome. Exported via Snapshot so a /metrics handler can render them.
+type DispatchMetrics struct {
+	mu          sync.Mutex
+	sentTotal   int64
+	retryTotal  int64
+	failedTotal int64
+}
+
+// NewDispatchMetrics creates an empty metrics set.
+func NewDispatchMetrics() *DispatchMetrics {
+	return &DispatchMetrics{}
+}
+
+func (m *DispatchMetrics) recordSent() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.sentTotal++
+}
+
+func (m *DispatchMetrics) recordRetry() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.retryTotal++
+}
+
+func (m *DispatchMetrics) recordFailed() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.failedTotal++
+}
+
+// Snapshot returns copies of the counters as
+// notification_dispatch_{sent,retry,failed}_total.
+func (m *DispatchMetrics) Snapshot() (sent, retry, failed int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.sentTotal, m.retryTotal, m.failedTotal
+}
+
+// Dispatcher runs a pool of worker goroutines that claim due notifications
+// via ClaimDueBatch (FOR UPDATE SKIP LOCKED) and hand them to whichever
+// Sender in Senders matches each token's platform, with per-worker rate
+// limiting and a bounded retry ladder for transient send errors.
+type Dispatcher struct {
+	Senders       *SenderRegistry
+	Workers       int
+	RatePerSecond float64
+	Metrics       *DispatchMetrics
+}
+
+// NewDispatcher creates a Dispatcher. workers <= 0 defaults to GOMAXPROCS;
+// ratePerSecond <= 0 defaults to 5 sends/sec/worker.
+func NewDispatcher(senders *SenderRegistry, workers int, ratePerSecond float64) *Dispatcher {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if ratePerSecond <= 0 {
+		ratePerSecond = 5
+	}
+	return &Dispatcher{
+		Senders:       senders,
+		Workers:       workers,
+		RatePerSecond: ratePerSecond,
+		Metrics:       NewDispatchMetrics(),
+	}
+}
+
+// Run starts the worker pool and blocks until ctx is canceled. Each worker
+// finishes whatever batch it has already claimed before exiting, so no
+// notification is left orphaned in the "sending" state.
+func (d *Dispatcher) Run(ctx context.Context, pool *pgxpool.Pool, logger *slog.Logger) {
+	logger.Info("Notification dispatcher started", "workers", d.Workers, "rate_per_second", d.RatePerSecond)
+
+	var wg sync.WaitGroup
+	for i := 0; i < d.Workers; i++ {
+		wg.Add(1)
+		go func(workerID int) {
+			defer wg.Done()
+			d.runWorker(ctx, pool, logger, workerID)
+		}(i)
+	}
+	wg.Wait()
+
+	logger.Info("Notification dispatcher stopped")
+}
+
+// runWorker loops claiming and sending batches until ctx is canceled.
+func (d *Dispatcher) runWorker(ctx context.Context, pool *pgxpool.Pool, logger *slog.Logger, workerID int) {
+	limiter := rate.NewLimiter(rate.Limit(d.RatePerSecond), 1)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+
+		claimed, err := ClaimDueBatch(ctx, pool, dispatchWorkerBatchSize)
+		if err != nil {
+			logger.Warn("dispatch worker claim failed", "worker", workerID, "error", err)
+			time.Sleep(dispatchPollInterval)
+			continue
+		}
+
+		if len(claimed) == 0 {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(dispatchPollInterval):
+			}
+			continue
+		}
+
+		// Claimed rows are already marked "sending" — finish them even if ctx
+		// is canceled mid-batch, so we never orphan a row.
+		for _, row := range claimed {
+			if err := limiter.Wait(context.Background()); err != nil {
+				logger.Warn("dispatch rate limiter wait failed", "worker", workerID, "error", err)
+			}
+			d.sendOne(ctx, pool, logger, row)
+		}
+	}
+}
+
+// sendOne delivers a single claimed row and applies the retry ladder on
+// transient failure: reschedule back to "scheduled" with backoff until
+// maxSendRetries is exhausted, then MarkFailed.
+func (d *Dispatcher) sendOne(ctx context.Context, pool *pgxpool.Pool, logger *slog.Logger, row claimedRow) {
+	start := time.Now()
+	tokens, err := GetDeviceTokens(context.Background(), pool, row.UserID)
+	if err != nil {
+		d.giveUpOrRetry(pool, logger, row, start, fmt.Errorf("get device tokens: %w", err))
+		return
+	}
+	if len(tokens) == 0 {
+		d.giveUpOrRetry(pool, logger, row, start, fmt.Errorf("no device tokens for user %s", row.UserID))
+		return
+	}
+
+	data := map[string]string{
+		"entity_type": row.EntityType,
+		"entity_id":   fmt.Sprintf("%d", row.EntityID),
+		"sport":       row.Sport,
+	}
+
+	sent, failedPlatforms := d.Senders.Dispatch(context.Background(), tokens, "Scoracle", row.Message, data)
+	if !sent {
+		reason := "no sender succeeded"
+		if len(failedPlatforms) > 0 {
+			reason = strings.Join(failedPlatforms, "; ")
+		}
+		d.giveUpOrRetry(pool, logger, row, start, fmt.Errorf("%s", reason))
+		return
+	}
+	if len(failedPlatforms) > 0 {
+		logger.Warn("partial send failure", "notification_id", row.ID, "failed_platforms", strings.Join(failedPlatforms, "; "))
+	}
+
+	if err := MarkSent(context.Background(), pool, row.ID); err != nil {
+		logger.Warn("mark sent failed", "notification_id", row.ID, "error", err)
+		return
+	}
+	d.Metrics.recordSent()
+	observeDispatch("sent", start)
+}
+
+// giveUpOrRetry reschedules a notification on backoff, or marks it failed
+// once maxSendRetries is exhausted.
+func (d *Dispatcher) giveUpOrRetry(pool *pgxpool.Pool, logger *slog.Logger, row claimedRow, start time.Time, sendErr error) {
+	nextAttempt := row.Attempts + 1
+	if nextAttempt >= maxSendRetries {
+		if err := MarkFailed(context.Background(), pool, row.ID, sendErr.Error()); err != nil {
+			logger.Warn("mark failed failed", "notification_id", row.ID, "error", err)
+			return
+		}
+		d.Metrics.recordFailed()
+		observeDispatch("failed", start)
+		errreport.Record("notification_send_failed", sendErr)
+		logger.Warn("notification send permanently failed", "notification_id", row.ID, "attempts", nextAttempt, "error", sendErr)
+		return
+	}
+
+	backoff := sendBackoffDelay(nextAttempt)
+	if err := RescheduleForRetry(context.Background(), pool, row.ID, sendErr.Error(), backoff); err != nil {
+		logger.Warn("reschedule failed", "notification_id", row.ID, "error", err)
+		return
+	}
+	d.Metrics.recordRetry()
+	logger.Info("notification send failed, rescheduled", "notification_id", row.ID, "attempt", nextAttempt, "backoff", backoff, "error", sendErr)
+}
+
+// sendBackoffDelay returns the exponential backoff (base 1m, cap 1h) with
+// full jitter for the given attempt count (1-indexed).
+func sendBackoffDelay(attempt int) time.Duration {
+	if attempt < 1 {
+		attempt = 1
+	}
+	raw := float64(sendRetryBaseDelay) * math.Pow(2, float64(attempt-1))
+	if raw > float64(sendRetryMaxDelay) {
+		raw = float64(sendRetryMaxDelay)
+	}
+	return time.Duration(rand.Int63n(int64(raw) + 1))
+}
@@ -0,0 +1,55 @@
+package notifications
+
+import (
+	"time"
+
+	"github.com/albapepper/scoracle-data/internal/metrics"
+)
+
+// reg is the process-wide metrics registry for the Dispatcher (see
+// dispatcher.go), set once at startup via SetMetrics. A nil reg (the
+// default) makes every instrumented call a no-op. Dispatcher also keeps its
+// own DispatchMetrics counters, queryable via Snapshot, independent of this
+// registry. See internal/seed.SetMetrics for why this is a package-level
+// var rather than a parameter threaded through every call.
+var reg *metrics.Registry
+
+// SetMetrics wires a metrics registry into subsequent observeDispatch calls
+// and registers a worker-pool-depth gauge for any Dispatcher started
+// afterward via RegisterDepthGauge.
+func SetMetrics(r *metrics.Registry) {
+	reg = r
+}
+
+// RegisterDepthGauge exposes d.Workers as scoracle_notification_worker_pool_depth.
+// Call after constructing a Dispatcher, once SetMetrics has run.
+func RegisterDepthGauge(d *Dispatcher) {
+	if reg == nil {
+		return
+	}
+	reg.RegisterGaugeFunc("scoracle_notification_worker_pool_depth",
+		"Dispatcher worker goroutines.", func() float64 { return float64(d.Workers) })
+}
+
+// observeDispatch records scoracle_notifications_dispatched_total{result}
+// and scoracle_notification_dispatch_latency_seconds for one claimed row.
+func observeDispatch(result string, start time.Time) {
+	if reg == nil {
+		return
+	}
+	reg.IncCounter("scoracle_notifications_dispatched_total", "Notifications dispatched, labeled by result.",
+		map[string]string{"result": result})
+	reg.ObserveDuration("scoracle_notification_dispatch_latency_seconds", "Notification dispatch latency in seconds.",
+		nil, start)
+}
+
+// observeFCMTokens adds to scoracle_fcm_tokens_total{result}, labeled per
+// individual token outcome from a SendEachForMulticast response (not per
+// batch), so success/failure/pruned counts reflect actual device reach.
+func observeFCMTokens(result string, n int) {
+	if reg == nil || n == 0 {
+		return
+	}
+	reg.AddCounter("scoracle_fcm_tokens_total", "FCM send results, labeled by per-token outcome.",
+		map[string]string{"result": result}, float64(n))
+}
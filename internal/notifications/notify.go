@@ -13,11 +13,13 @@ import "time"
 
 const (
 	defaultWindowHours = 12
-	quietStartHour     = 22 // 10 PM local
-	quietEndHour       = 9  // 9 AM local
-	dispatchInterval   = 30 * time.Second
-	dispatchBatchSize  = 100
-	maxScheduleRetries = 20
+
+	// Dispatcher: concurrent workers claiming smaller batches via SKIP LOCKED.
+	dispatchWorkerBatchSize = 20
+	dispatchPollInterval    = 2 * time.Second
+	sendRetryBaseDelay      = time.Minute
+	sendRetryMaxDelay       = time.Hour
+	maxSendRetries          = 5 // attempts after which a transient send failure is given up on
 )
 
 // Percentile milestones that trigger notifications.
@@ -61,4 +63,10 @@ type Pending struct {
 	Percentile  float64
 	Message     string
 	ScheduleFor time.Time
+
+	// Status is the row's initial status: "scheduled" for realtime delivery
+	// (the dispatch worker's ClaimDueBatch picks these up directly) or
+	// "digest_pending" for digest-mode followers, whose crossings wait here
+	// until maintenance.generateDigests batches them into one notification.
+	Status string
 }
@@ -4,12 +4,30 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"strings"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+// digestEntry is one percentile crossing queued for a follower, kept until
+// digest coalescing decides whether it ships standalone or merged.
+type digestEntry struct {
+	entityName  string
+	statDisplay string
+	change      Change
+}
+
+// userAggregate collects every crossing a single digest-enabled follower
+// should be notified about for this fixture, so they get one row instead of
+// one notification per stat.
+type userAggregate struct {
+	follower Follower
+	entries  []digestEntry
+}
+
 // Run detects percentile changes from a seeded fixture, fans out to followers,
-// schedules delivery times, and persists the notifications.
+// schedules delivery times honoring quiet hours and digest preferences, and
+// persists the notifications.
 // Called after seed + percentile recalculation.
 func Run(ctx context.Context, pool *pgxpool.Pool, fixtureID int, logger *slog.Logger) error {
 	// 1. Detect significant percentile changes
@@ -29,9 +47,16 @@ func Run(ctx context.Context, pool *pgxpool.Pool, fixtureID int, logger *slog.Lo
 		return fmt.Errorf("get match time: %w", err)
 	}
 
-	// 3. Fan out: for each change, find followers and build notifications
+	scheduler := NewScheduler()
+	prefsCache := make(map[string]Preferences)
+	digests := make(map[string]*userAggregate)
 	var pending []Pending
+
+	// 3. Fan out: for each change, find followers, apply their preferences,
+	// and either queue a standalone notification or fold into their digest.
 	for _, change := range changes {
+		publishChange(ctx, change, logger)
+
 		followers, err := GetFollowers(ctx, pool, change.EntityType, change.EntityID, change.Sport)
 		if err != nil {
 			logger.Warn("get followers failed", "entity", change.EntityID, "error", err)
@@ -43,9 +68,55 @@ func Run(ctx context.Context, pool *pgxpool.Pool, fixtureID int, logger *slog.Lo
 
 		entityName, _ := GetEntityName(ctx, pool, change.EntityType, change.EntityID, change.Sport)
 		statDisplay, _ := GetStatDisplayName(ctx, pool, change.Sport, change.StatKey, change.EntityType)
-		msg := buildMessage(entityName, statDisplay, change)
 
 		for _, f := range followers {
+			prefs, ok := prefsCache[f.UserID]
+			if !ok {
+				prefs, err = GetPreferences(ctx, pool, f.UserID)
+				if err != nil {
+					logger.Warn("get preferences failed", "user_id", f.UserID, "error", err)
+					prefs = defaultPreferences
+				}
+				prefsCache[f.UserID] = prefs
+			}
+			if change.NewPctile < prefs.MinPercentile {
+				continue
+			}
+
+			// Batch-digest followers get no realtime row out of this run —
+			// their crossing is queued standalone as digest_pending,
+			// and maintenance.generateDigests batches everything queued for
+			// them (across fixtures) into one notification at their next
+			// digest slot. This is what keeps them from also getting the
+			// realtime push below: the dispatch worker's ClaimDueBatch only
+			// claims status = 'scheduled' rows.
+			if prefs.DeliveryMode == "digest" {
+				pending = append(pending, Pending{
+					UserID:      f.UserID,
+					EntityType:  change.EntityType,
+					EntityID:    change.EntityID,
+					Sport:       change.Sport,
+					FixtureID:   fixtureID,
+					StatKey:     change.StatKey,
+					Percentile:  change.NewPctile,
+					Message:     buildMessage(entityName, statDisplay, change),
+					ScheduleFor: matchTime,
+					Status:      "digest_pending",
+				})
+				continue
+			}
+
+			entry := digestEntry{entityName: entityName, statDisplay: statDisplay, change: change}
+			if prefs.DigestEnabled {
+				agg, ok := digests[f.UserID]
+				if !ok {
+					agg = &userAggregate{follower: f}
+					digests[f.UserID] = agg
+				}
+				agg.entries = append(agg.entries, entry)
+				continue
+			}
+
 			pending = append(pending, Pending{
 				UserID:      f.UserID,
 				EntityType:  change.EntityType,
@@ -54,18 +125,36 @@ func Run(ctx context.Context, pool *pgxpool.Pool, fixtureID int, logger *slog.Lo
 				FixtureID:   fixtureID,
 				StatKey:     change.StatKey,
 				Percentile:  change.NewPctile,
-				Message:     msg,
-				ScheduleFor: ScheduleDelivery(matchTime, f.Timezone),
+				Message:     buildMessage(entityName, statDisplay, change),
+				ScheduleFor: scheduler.ScheduleFor(matchTime, f.Timezone, prefs),
 			})
 		}
 	}
 
+	// 4. Collapse each digest-enabled follower's entries into one row (this
+	// only runs for realtime-delivery followers; digest-mode followers
+	// never populate digests — see the loop above).
+	for userID, agg := range digests {
+		rep := representativeEntry(agg.entries)
+		pending = append(pending, Pending{
+			UserID:      userID,
+			EntityType:  rep.change.EntityType,
+			EntityID:    rep.change.EntityID,
+			Sport:       rep.change.Sport,
+			FixtureID:   fixtureID,
+			StatKey:     rep.change.StatKey,
+			Percentile:  rep.change.NewPctile,
+			Message:     buildDigestMessage(agg.entries),
+			ScheduleFor: scheduler.ScheduleFor(matchTime, agg.follower.Timezone, prefsCache[userID]),
+		})
+	}
+
 	if len(pending) == 0 {
 		logger.Info("No followers to notify", "fixture_id", fixtureID)
 		return nil
 	}
 
-	// 4. Persist
+	// 5. Persist
 	inserted, err := InsertPending(ctx, pool, pending)
 	if err != nil {
 		return fmt.Errorf("insert pending: %w", err)
@@ -85,6 +174,35 @@ func buildMessage(entityName, statDisplay string, c Change) string {
 	return fmt.Sprintf("%s is now %d%s percentile in %s", entityName, pctile, suffix, statDisplay)
 }
 
+// buildDigestMessage combines every crossing queued for a digest-enabled
+// follower into a single message instead of one notification per stat.
+func buildDigestMessage(entries []digestEntry) string {
+	if len(entries) == 1 {
+		e := entries[0]
+		return buildMessage(e.entityName, e.statDisplay, e.change)
+	}
+
+	parts := make([]string, len(entries))
+	for i, e := range entries {
+		pctile := int(e.change.NewPctile)
+		parts[i] = fmt.Sprintf("%s %d%s pctile in %s", e.entityName, pctile, ordinalSuffix(pctile), e.statDisplay)
+	}
+	return fmt.Sprintf("%d updates: %s", len(entries), strings.Join(parts, "; "))
+}
+
+// representativeEntry picks the most significant crossing in a digest — the
+// highest new percentile — to populate the persisted row's single
+// entity/stat/percentile columns.
+func representativeEntry(entries []digestEntry) digestEntry {
+	best := entries[0]
+	for _, e := range entries[1:] {
+		if e.change.NewPctile > best.change.NewPctile {
+			best = e
+		}
+	}
+	return best
+}
+
 func ordinalSuffix(n int) string {
 	if n%100 >= 11 && n%100 <= 13 {
 		return "th"
@@ -0,0 +1,70 @@
+package notifications
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/albapepper/scoracle-data/internal/db"
+)
+
+// Preferences controls per-user quiet hours and digesting, loaded from the
+// notification_preferences table. Users without a row get defaultPreferences.
+type Preferences struct {
+	QuietStartHour int
+	QuietEndHour   int
+	DigestEnabled  bool
+	MinPercentile  float64
+
+	// DeliveryMode is "realtime" (push as crossings happen, subject to quiet
+	// hours) or "digest" (queue crossings and batch them into one push per
+	// DigestFrequency — see maintenance.generateDigests). Distinct from
+	// DigestEnabled, which only coalesces crossings within a single fixture's
+	// Run into one message; DeliveryMode governs whether that message ships
+	// near the match or waits for the next scheduled digest.
+	DeliveryMode string
+	// DigestFrequency is "daily" or "weekly"; read by generateDigests to
+	// decide whether a user's LastDigestAt is stale enough to batch again.
+	DigestFrequency string
+	// DigestHour is the local hour (0-23) batched digests are delivered at.
+	DigestHour int
+	// Timezone is the IANA zone DigestHour is interpreted in.
+	Timezone string
+	// LastDigestAt is when this user's last batched digest was generated.
+	LastDigestAt time.Time
+}
+
+// defaultPreferences applies to any user without a notification_preferences
+// row: quiet 10 PM - 8 AM local, digested, no percentile floor, realtime
+// delivery once a message clears quiet hours.
+var defaultPreferences = Preferences{
+	QuietStartHour:  22,
+	QuietEndHour:    8,
+	DigestEnabled:   true,
+	MinPercentile:   0,
+	DeliveryMode:    "realtime",
+	DigestFrequency: "daily",
+	DigestHour:      8,
+	Timezone:        "UTC",
+}
+
+// GetPreferences loads a user's notification preferences, falling back to
+// defaultPreferences if they haven't configured any.
+func GetPreferences(ctx context.Context, pool *pgxpool.Pool, userID string) (Preferences, error) {
+	var p Preferences
+	err := db.QueryRowGetNotificationPreferences(ctx, pool, userID).Scan(
+		&p.QuietStartHour, &p.QuietEndHour, &p.DigestEnabled, &p.MinPercentile,
+		&p.DeliveryMode, &p.DigestFrequency, &p.DigestHour, &p.Timezone, &p.LastDigestAt,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return defaultPreferences, nil
+	}
+	if err != nil {
+		return Preferences{}, fmt.Errorf("get notification preferences: %w", err)
+	}
+	return p, nil
+}
@@ -5,31 +5,69 @@ import (
 	"time"
 )
 
-// ScheduleDelivery picks a random time within [matchTime, matchTime+window]
-// that falls inside waking hours (9 AM – 10 PM) in the user's timezone.
-func ScheduleDelivery(matchTime time.Time, timezone string) time.Time {
+// Scheduler computes delivery times honoring each user's quiet hours.
+type Scheduler struct{}
+
+// NewScheduler creates a Scheduler.
+func NewScheduler() *Scheduler {
+	return &Scheduler{}
+}
+
+// ScheduleFor picks a random time within [matchTime, matchTime+window] in the
+// follower's IANA timezone, then shifts forward to the next allowed minute
+// if that falls inside prefs' quiet hours.
+func (s *Scheduler) ScheduleFor(matchTime time.Time, timezone string, prefs Preferences) time.Time {
 	loc, err := time.LoadLocation(timezone)
 	if err != nil {
 		loc = time.UTC
 	}
 
 	windowEnd := matchTime.Add(time.Duration(defaultWindowHours) * time.Hour)
-	window := windowEnd.Sub(matchTime)
-
-	for i := 0; i < maxScheduleRetries; i++ {
-		offset := time.Duration(rand.Int64N(int64(window)))
-		candidate := matchTime.Add(offset).In(loc)
-		if isWakingHour(candidate.Hour()) {
-			return candidate
-		}
+	offset := time.Duration(rand.Int64N(int64(windowEnd.Sub(matchTime))))
+	candidate := matchTime.Add(offset).In(loc)
+
+	if isQuietHour(candidate.Hour(), prefs.QuietStartHour, prefs.QuietEndHour) {
+		candidate = nextAllowedMinute(candidate, prefs.QuietEndHour)
+	}
+	return candidate
+}
+
+// ScheduleDigestDelivery returns the next occurrence of prefs.DigestHour in
+// prefs.Timezone strictly after now — the local-time slot a digest-mode
+// follower's next batched notification (see maintenance.generateDigests)
+// should land in. Unlike ScheduleFor, there's no quiet-hour shift to apply:
+// DigestHour is chosen by the user precisely because it's a waking hour.
+func (s *Scheduler) ScheduleDigestDelivery(now time.Time, prefs Preferences) time.Time {
+	loc, err := time.LoadLocation(prefs.Timezone)
+	if err != nil {
+		loc = time.UTC
 	}
+	local := now.In(loc)
+	next := time.Date(local.Year(), local.Month(), local.Day(), prefs.DigestHour, 0, 0, 0, loc)
+	if !next.After(local) {
+		next = next.AddDate(0, 0, 1)
+	}
+	return next
+}
 
-	// Fallback: 9 AM + random minute next day in user's timezone
-	next := matchTime.Add(24 * time.Hour).In(loc)
-	return time.Date(next.Year(), next.Month(), next.Day(),
-		quietEndHour, rand.IntN(60), 0, 0, loc)
+// isQuietHour reports whether hour falls in the half-open range
+// [quietStart, quietEnd), handling windows that wrap midnight (e.g. 22 -> 8).
+func isQuietHour(hour, quietStart, quietEnd int) bool {
+	if quietStart == quietEnd {
+		return false
+	}
+	if quietStart < quietEnd {
+		return hour >= quietStart && hour < quietEnd
+	}
+	return hour >= quietStart || hour < quietEnd
 }
 
-func isWakingHour(hour int) bool {
-	return hour >= quietEndHour && hour < quietStartHour
+// nextAllowedMinute shifts t forward to quietEnd:00 local time, same day if
+// that's still ahead of t, otherwise the following day.
+func nextAllowedMinute(t time.Time, quietEnd int) time.Time {
+	next := time.Date(t.Year(), t.Month(), t.Day(), quietEnd, 0, 0, 0, t.Location())
+	if !next.After(t) {
+		next = next.AddDate(0, 0, 1)
+	}
+	return next
 }
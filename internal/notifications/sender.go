@@ -1,55 +1,431 @@
 package notifications
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"log/slog"
+	"math"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	firebase "firebase.google.com/go/v4"
+	"firebase.google.com/go/v4/messaging"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sideshow/apns2"
+	"github.com/sideshow/apns2/payload"
+	apns2token "github.com/sideshow/apns2/token"
+	"google.golang.org/api/option"
+)
+
+// Sender delivers a notification to a set of device tokens for one
+// platform. Platform identifies which device_tokens.platform rows a Sender
+// handles (e.g. "android", "ios", "webhook") — SenderRegistry uses it to
+// route claimed notification rows to the right implementation.
+type Sender interface {
+	SendMulti(ctx context.Context, tokens []string, title, body string, data map[string]string) error
+	Platform() string
+}
+
+// SenderRegistry maps platform -> Sender, the same register-by-key idiom
+// used by internal/news.Registry and internal/provider/registry.Registry.
+// Notifications can run with any subset of FCM/APNs/webhook configured:
+// Dispatch reports a token's platform as failed when no Sender is registered
+// for it, and Empty tells callers when none is registered at all.
+type SenderRegistry struct {
+	mu      sync.RWMutex
+	senders map[string]Sender
+}
+
+// NewSenderRegistry creates an empty registry.
+func NewSenderRegistry() *SenderRegistry {
+	return &SenderRegistry{senders: make(map[string]Sender)}
+}
+
+// Register adds a Sender under its own Platform(). Callers should skip
+// Register when a constructor returns its not-configured nil sentinel (see
+// NewFCMSender/NewAPNSSender/NewWebhookSender) — the same "if sender != nil"
+// check cmd/api/main.go already does before starting the dispatch worker.
+func (r *SenderRegistry) Register(s Sender) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.senders[s.Platform()] = s
+}
+
+// Empty reports whether no Senders have been registered, so callers can
+// skip starting the dispatch worker entirely rather than having every claim
+// fail with "no sender configured".
+func (r *SenderRegistry) Empty() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return len(r.senders) == 0
+}
+
+// Lookup returns the Sender registered for platform, if any.
+func (r *SenderRegistry) Lookup(platform string) (Sender, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	s, ok := r.senders[platform]
+	return s, ok
+}
+
+// Dispatch groups tokens by platform and sends through each platform's
+// registered Sender, so one notification row fans out to a user's iOS and
+// Android (and any webhook) devices in a single pass. sent reports whether
+// at least one platform succeeded; failedPlatforms lists every platform
+// that errored or had no registered Sender, formatted for MarkFailed's
+// reason column so platform-specific token expiry is debuggable later.
+func (r *SenderRegistry) Dispatch(ctx context.Context, tokens []DeviceToken, title, body string, data map[string]string) (sent bool, failedPlatforms []string) {
+	byPlatform := make(map[string][]string)
+	for _, t := range tokens {
+		byPlatform[t.Platform] = append(byPlatform[t.Platform], t.Token)
+	}
+
+	for platform, toks := range byPlatform {
+		s, ok := r.Lookup(platform)
+		if !ok {
+			failedPlatforms = append(failedPlatforms, platform+": no sender configured")
+			continue
+		}
+		if err := s.SendMulti(ctx, toks, title, body, data); err != nil {
+			failedPlatforms = append(failedPlatforms, fmt.Sprintf("%s: %v", platform, err))
+			continue
+		}
+		sent = true
+	}
+	return sent, failedPlatforms
+}
+
+// --------------------------------------------------------------------------
+// FCM
+// --------------------------------------------------------------------------
+
+// FCM tuning: batch size caps at the messaging API's own multicast limit.
+// Transient Unavailable/Internal errors get a short exponential backoff
+// retry per batch rather than failing the whole SendMulti call, since those
+// are FCM-side blips rather than anything wrong with the tokens themselves.
+const (
+	fcmBatchSize    = 500
+	fcmMaxRetries   = 3
+	fcmRetryBase    = 500 * time.Millisecond
+	fcmRetryMaxWait = 5 * time.Second
 )
 
 // FCMSender sends push notifications via Firebase Cloud Messaging.
 // Nil-safe: when not configured, all methods are no-ops.
 type FCMSender struct {
-	credentialsFile string
-	logger          *slog.Logger
-	// TODO: Add firebase.google.com/go/v4/messaging.Client when FCM
-	// dependency is added. For now this is a structured placeholder
-	// that logs send attempts.
+	client *messaging.Client
+	pool   *pgxpool.Pool
+	logger *slog.Logger
 }
 
-// NewFCMSender creates an FCM sender from a service account credentials file.
-// Returns nil if credentialsFile is empty (notifications disabled).
-func NewFCMSender(credentialsFile string, logger *slog.Logger) *FCMSender {
+// NewFCMSender creates an FCM sender from a service account credentials
+// file. Returns nil if credentialsFile is empty (notifications disabled) or
+// the Firebase app/messaging client fails to initialize. pool is used to
+// prune tokens FCM reports as unregistered or invalid.
+func NewFCMSender(ctx context.Context, credentialsFile string, pool *pgxpool.Pool, logger *slog.Logger) *FCMSender {
 	if credentialsFile == "" {
 		return nil
 	}
-	return &FCMSender{
-		credentialsFile: credentialsFile,
-		logger:          logger,
+	app, err := firebase.NewApp(ctx, nil, option.WithCredentialsFile(credentialsFile))
+	if err != nil {
+		logger.Error("init firebase app", "error", err)
+		return nil
+	}
+	client, err := app.Messaging(ctx)
+	if err != nil {
+		logger.Error("init firebase messaging client", "error", err)
+		return nil
 	}
+	return &FCMSender{client: client, pool: pool, logger: logger}
 }
 
-// SendMulti sends a notification to multiple device tokens.
-// When the FCM client is integrated, this will call SendEachForMulticast.
-// Currently logs the send for development/testing.
+// Platform identifies the device_tokens rows this sender handles.
+func (s *FCMSender) Platform() string { return "android" }
+
+// SendMulti chunks tokens into batches of fcmBatchSize (FCM's own multicast
+// limit) and sends each via SendEachForMulticast, then walks the per-token
+// responses to prune unregistered/invalid tokens and retry batches that
+// failed with a transient Unavailable/Internal error.
 func (s *FCMSender) SendMulti(ctx context.Context, tokens []string, title, body string, data map[string]string) error {
 	if s == nil {
 		return nil // no-op when not configured
 	}
+	if len(tokens) == 0 {
+		return fmt.Errorf("no tokens to send to")
+	}
+
+	var failures []string
+	for i := 0; i < len(tokens); i += fcmBatchSize {
+		batch := tokens[i:min(i+fcmBatchSize, len(tokens))]
+		if err := s.sendBatch(ctx, batch, title, body, data); err != nil {
+			failures = append(failures, err.Error())
+		}
+	}
+	if len(failures) > 0 {
+		return fmt.Errorf("fcm: %s", strings.Join(failures, "; "))
+	}
+	return nil
+}
+
+// sendBatch sends one multicast batch, retrying the whole batch on a
+// transient Unavailable/Internal error from FCM itself (distinct from
+// per-token failures, which are classified and handled in the response loop
+// below).
+func (s *FCMSender) sendBatch(ctx context.Context, tokens []string, title, body string, data map[string]string) error {
+	msg := &messaging.MulticastMessage{
+		Tokens:       tokens,
+		Notification: &messaging.Notification{Title: title, Body: body},
+		Data:         data,
+	}
+
+	var resp *messaging.BatchResponse
+	var err error
+	for attempt := 1; attempt <= fcmMaxRetries; attempt++ {
+		resp, err = s.client.SendEachForMulticast(ctx, msg)
+		if err == nil {
+			break
+		}
+		if !messaging.IsUnavailable(err) && !messaging.IsInternal(err) {
+			return fmt.Errorf("send multicast: %w", err)
+		}
+		if attempt == fcmMaxRetries {
+			return fmt.Errorf("send multicast after %d attempts: %w", attempt, err)
+		}
+		select {
+		case <-time.After(fcmBackoff(attempt)):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("send multicast: %w", err)
+	}
+
+	var failures []string
+	for i, r := range resp.Responses {
+		token := tokens[i]
+		if r.Success {
+			observeFCMTokens("success", 1)
+			continue
+		}
+		switch {
+		case messaging.IsRegistrationTokenNotRegistered(r.Error), messaging.IsInvalidArgument(r.Error):
+			observeFCMTokens("pruned", 1)
+			if err := DeleteDeviceToken(ctx, s.pool, token); err != nil {
+				s.logger.Warn("prune invalid FCM token", "error", err)
+			}
+		default:
+			observeFCMTokens("failure", 1)
+			failures = append(failures, fmt.Sprintf("%s: %v", token, r.Error))
+		}
+	}
+	if len(failures) > 0 {
+		return fmt.Errorf("%d token(s) failed: %s", len(failures), strings.Join(failures, "; "))
+	}
+	return nil
+}
 
-	// TODO: Replace with actual FCM client call:
-	//   msg := &messaging.MulticastMessage{
-	//       Tokens:       tokens,
-	//       Notification: &messaging.Notification{Title: title, Body: body},
-	//       Data:         data,
-	//   }
-	//   resp, err := s.client.SendEachForMulticast(ctx, msg)
+// fcmBackoff returns exponential backoff (base fcmRetryBase, capped at
+// fcmRetryMaxWait) for the given attempt count (1-indexed).
+func fcmBackoff(attempt int) time.Duration {
+	d := time.Duration(float64(fcmRetryBase) * math.Pow(2, float64(attempt-1)))
+	if d > fcmRetryMaxWait {
+		return fcmRetryMaxWait
+	}
+	return d
+}
+
+// SendToTopic broadcasts a notification to every device subscribed to topic,
+// for league-wide milestones where enumerating followers individually would
+// be wasteful.
+func (s *FCMSender) SendToTopic(ctx context.Context, topic, title, body string, data map[string]string) error {
+	if s == nil {
+		return nil
+	}
+	msg := &messaging.Message{
+		Topic:        topic,
+		Notification: &messaging.Notification{Title: title, Body: body},
+		Data:         data,
+	}
+	if _, err := s.client.Send(ctx, msg); err != nil {
+		return fmt.Errorf("send to topic %s: %w", topic, err)
+	}
+	return nil
+}
 
-	s.logger.Info("FCM send (pending integration)",
-		"tokens", len(tokens), "title", title, "body", body)
+// --------------------------------------------------------------------------
+// APNs
+// --------------------------------------------------------------------------
 
+// APNSSender sends push notifications to iOS devices via APNs, authenticated
+// with a JWT signed by a .p8 key (sideshow/apns2's token-based auth, so there
+// is no per-device certificate to renew). Nil-safe: when not configured, all
+// methods are no-ops.
+type APNSSender struct {
+	client   *apns2.Client
+	bundleID string
+	logger   *slog.Logger
+}
+
+// NewAPNSSender creates an APNs sender from a .p8 signing key and its
+// associated key/team/bundle IDs. Returns nil if any of them are empty
+// (notifications disabled) or the key file fails to load.
+func NewAPNSSender(keyFile, keyID, teamID, bundleID string, production bool, logger *slog.Logger) *APNSSender {
+	if keyFile == "" || keyID == "" || teamID == "" || bundleID == "" {
+		return nil
+	}
+	authKey, err := apns2token.AuthKeyFromFile(keyFile)
+	if err != nil {
+		logger.Error("load APNs auth key", "error", err)
+		return nil
+	}
+	tok := &apns2token.Token{AuthKey: authKey, KeyID: keyID, TeamID: teamID}
+
+	client := apns2.NewTokenClient(tok)
+	if production {
+		client = client.Production()
+	} else {
+		client = client.Development()
+	}
+	return &APNSSender{client: client, bundleID: bundleID, logger: logger}
+}
+
+// Platform identifies the device_tokens rows this sender handles.
+func (s *APNSSender) Platform() string { return "ios" }
+
+// SendMulti pushes a notification to each token individually — APNs has no
+// multicast endpoint, unlike FCM — and aggregates per-token failures into a
+// single error so the caller's retry/MarkFailed bookkeeping stays per-row.
+func (s *APNSSender) SendMulti(ctx context.Context, tokens []string, title, body string, data map[string]string) error {
+	if s == nil {
+		return nil
+	}
 	if len(tokens) == 0 {
 		return fmt.Errorf("no tokens to send to")
 	}
 
+	p := payload.NewPayload().AlertTitle(title).AlertBody(body)
+	for k, v := range data {
+		p.Custom(k, v)
+	}
+
+	var failures []string
+	for _, tok := range tokens {
+		resp, err := s.client.PushWithContext(ctx, &apns2.Notification{
+			DeviceToken: tok,
+			Topic:       s.bundleID,
+			Payload:     p,
+		})
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %v", tok, err))
+			continue
+		}
+		if !resp.Sent() {
+			failures = append(failures, fmt.Sprintf("%s: %s", tok, resp.Reason))
+		}
+	}
+	if len(failures) > 0 {
+		return fmt.Errorf("apns: %s", strings.Join(failures, "; "))
+	}
+	return nil
+}
+
+// --------------------------------------------------------------------------
+// Webhook
+// --------------------------------------------------------------------------
+
+const webhookTimeout = 10 * time.Second
+
+// WebhookSender POSTs a JSON envelope to a fixed URL, for internal ops
+// fanout (Slack, PagerDuty, etc. all accept a generic incoming webhook).
+// Nil-safe: when not configured, all methods are no-ops.
+type WebhookSender struct {
+	url    string
+	client *http.Client
+	logger *slog.Logger
+}
+
+// NewWebhookSender creates a webhook sender posting to url. Returns nil if
+// url is empty (notifications disabled).
+func NewWebhookSender(url string, logger *slog.Logger) *WebhookSender {
+	if url == "" {
+		return nil
+	}
+	return &WebhookSender{
+		url:    url,
+		client: &http.Client{Timeout: webhookTimeout},
+		logger: logger,
+	}
+}
+
+// Platform identifies the device_tokens rows this sender handles.
+func (s *WebhookSender) Platform() string { return "webhook" }
+
+// webhookEnvelope is the JSON body POSTed to the configured URL.
+type webhookEnvelope struct {
+	Title  string            `json:"title"`
+	Body   string            `json:"body"`
+	Data   map[string]string `json:"data,omitempty"`
+	Tokens []string          `json:"tokens,omitempty"`
+}
+
+// SendMulti posts one envelope covering every token, since a webhook
+// recipient (a channel, a queue) isn't per-device the way push tokens are.
+func (s *WebhookSender) SendMulti(ctx context.Context, tokens []string, title, body string, data map[string]string) error {
+	if s == nil {
+		return nil
+	}
+
+	payload, err := json.Marshal(webhookEnvelope{Title: title, Body: body, Data: data, Tokens: tokens})
+	if err != nil {
+		return fmt.Errorf("marshal webhook envelope: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("build webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("webhook post: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// --------------------------------------------------------------------------
+// Noop
+// --------------------------------------------------------------------------
+
+// NoopSender logs what it would have sent instead of delivering it, for
+// wiring into a SenderRegistry in staging/dry-run environments where the
+// dispatcher's claim/retry/mark-sent flow needs exercising end to end
+// without real push credentials or device tokens.
+type NoopSender struct {
+	platform string
+	logger   *slog.Logger
+}
+
+// NewNoopSender creates a NoopSender registered under platform — pass the
+// platform you want to simulate (e.g. "android" in a staging deploy with no
+// Firebase credentials yet).
+func NewNoopSender(platform string, logger *slog.Logger) *NoopSender {
+	return &NoopSender{platform: platform, logger: logger}
+}
+
+// Platform identifies the device_tokens rows this sender handles.
+func (s *NoopSender) Platform() string { return s.platform }
+
+// SendMulti logs the notification it would have sent and always succeeds.
+func (s *NoopSender) SendMulti(ctx context.Context, tokens []string, title, body string, data map[string]string) error {
+	s.logger.Info("noop sender: would send", "platform", s.platform, "tokens", len(tokens), "title", title, "body", body)
 	return nil
 }
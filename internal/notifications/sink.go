@@ -0,0 +1,68 @@
+package notifications
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"time"
+
+	"github.com/albapepper/scoracle-data/internal/eventsink"
+)
+
+// sink is the process-wide event sink for publishing PercentileChange
+// events alongside persisting pending notifications, set once at startup
+// via SetEventSink. A nil sink (the default) makes publishing a no-op. See
+// internal/seed.SetMetrics for why this is a package-level var rather than
+// a parameter threaded through every call.
+var sink eventsink.Sink
+
+// SetEventSink wires an event sink into subsequent Run calls.
+func SetEventSink(s eventsink.Sink) {
+	sink = s
+}
+
+// percentileChangeEvent is the JSON payload published for a Change, under
+// event type "percentile_change".
+type percentileChangeEvent struct {
+	FixtureID  int     `json:"fixture_id"`
+	EntityType string  `json:"entity_type"`
+	EntityID   int     `json:"entity_id"`
+	Sport      string  `json:"sport"`
+	Season     int     `json:"season"`
+	StatKey    string  `json:"stat_key"`
+	OldPctile  float64 `json:"old_percentile"`
+	NewPctile  float64 `json:"new_percentile"`
+}
+
+// publishChange publishes one Change to the configured sink as a
+// schema-versioned envelope, keyed by its (fixture_id, entity_id, stat_key)
+// idempotency key. Errors are logged, not returned — a sink outage
+// shouldn't block scheduling the in-app notification.
+func publishChange(ctx context.Context, change Change, logger *slog.Logger) {
+	if sink == nil {
+		return
+	}
+	idempotencyKey := eventsink.IdempotencyKey(change.FixtureID, change.EntityID, change.StatKey)
+	env, err := eventsink.NewEnvelope("percentile_change", idempotencyKey, time.Now(), percentileChangeEvent{
+		FixtureID:  change.FixtureID,
+		EntityType: change.EntityType,
+		EntityID:   change.EntityID,
+		Sport:      change.Sport,
+		Season:     change.Season,
+		StatKey:    change.StatKey,
+		OldPctile:  change.OldPctile,
+		NewPctile:  change.NewPctile,
+	})
+	if err != nil {
+		logger.Warn("build percentile change envelope", "error", err)
+		return
+	}
+	payload, err := json.Marshal(env)
+	if err != nil {
+		logger.Warn("marshal percentile change envelope", "error", err)
+		return
+	}
+	if err := sink.Publish(ctx, "percentile_change", []byte(idempotencyKey), payload); err != nil {
+		logger.Warn("publish percentile change event", "error", err)
+	}
+}
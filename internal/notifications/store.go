@@ -6,11 +6,13 @@ import (
 	"time"
 
 	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/albapepper/scoracle-data/internal/db"
 )
 
 // GetFollowers returns users following an entity.
 func GetFollowers(ctx context.Context, pool *pgxpool.Pool, entityType string, entityID int, sport string) ([]Follower, error) {
-	rows, err := pool.Query(ctx, "get_entity_followers", entityType, entityID, sport)
+	rows, err := db.QueryGetEntityFollowers(ctx, pool, entityType, entityID, sport)
 	if err != nil {
 		return nil, fmt.Errorf("get followers: %w", err)
 	}
@@ -43,7 +45,7 @@ func GetEntityName(ctx context.Context, pool *pgxpool.Pool, entityType string, e
 // GetStatDisplayName returns the human-readable name for a stat key.
 func GetStatDisplayName(ctx context.Context, pool *pgxpool.Pool, sport, statKey, entityType string) (string, error) {
 	var displayName string
-	err := pool.QueryRow(ctx, "stat_display_name", sport, statKey, entityType).Scan(&displayName)
+	err := db.QueryRowStatDisplayName(ctx, pool, sport, statKey, entityType).Scan(&displayName)
 	if err != nil {
 		return statKey, nil // fallback to raw key
 	}
@@ -53,7 +55,7 @@ func GetStatDisplayName(ctx context.Context, pool *pgxpool.Pool, sport, statKey,
 // GetMatchTime returns the start time of a fixture.
 func GetMatchTime(ctx context.Context, pool *pgxpool.Pool, fixtureID int) (time.Time, error) {
 	var t time.Time
-	if err := pool.QueryRow(ctx, "fixture_start_time", fixtureID).Scan(&t); err != nil {
+	if err := db.QueryRowFixtureStartTime(ctx, pool, fixtureID).Scan(&t); err != nil {
 		return time.Time{}, fmt.Errorf("get match time: %w", err)
 	}
 	return t, nil
@@ -63,13 +65,17 @@ func GetMatchTime(ctx context.Context, pool *pgxpool.Pool, fixtureID int) (time.
 func InsertPending(ctx context.Context, pool *pgxpool.Pool, pending []Pending) (int, error) {
 	inserted := 0
 	for _, n := range pending {
+		status := n.Status
+		if status == "" {
+			status = "scheduled"
+		}
 		_, err := pool.Exec(ctx, `
 			INSERT INTO notifications (
 				user_id, entity_type, entity_id, sport, fixture_id,
 				stat_key, percentile, message, status, scheduled_for
-			) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,'scheduled',$9)`,
+			) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10)`,
 			n.UserID, n.EntityType, n.EntityID, n.Sport, n.FixtureID,
-			n.StatKey, n.Percentile, n.Message, n.ScheduleFor,
+			n.StatKey, n.Percentile, n.Message, status, n.ScheduleFor,
 		)
 		if err != nil {
 			return inserted, fmt.Errorf("insert notification: %w", err)
@@ -79,6 +85,46 @@ func InsertPending(ctx context.Context, pool *pgxpool.Pool, pending []Pending) (
 	return inserted, nil
 }
 
+// DeviceToken is one row of user_devices: a push/webhook endpoint tagged
+// with the platform it belongs to, so SenderRegistry.Dispatch can route it
+// to the matching Sender.
+type DeviceToken struct {
+	Token    string
+	Platform string
+}
+
+// GetDeviceTokens returns all active device tokens for a user, across every
+// platform they've registered. Shared by the Dispatcher (dispatcher.go) and
+// the milestone listener (internal/listener), so the platform column is
+// looked up in exactly one place.
+func GetDeviceTokens(ctx context.Context, pool *pgxpool.Pool, userID string) ([]DeviceToken, error) {
+	rows, err := db.QueryGetUserDeviceTokens(ctx, pool, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tokens []DeviceToken
+	for rows.Next() {
+		var t DeviceToken
+		if err := rows.Scan(&t.Token, &t.Platform); err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, t)
+	}
+	return tokens, rows.Err()
+}
+
+// DeleteDeviceToken removes a device token row, e.g. after FCM reports it as
+// unregistered or otherwise invalid so we stop sending to it.
+func DeleteDeviceToken(ctx context.Context, pool *pgxpool.Pool, token string) error {
+	_, err := pool.Exec(ctx, `DELETE FROM user_devices WHERE token = $1`, token)
+	if err != nil {
+		return fmt.Errorf("delete device token: %w", err)
+	}
+	return nil
+}
+
 // claimedRow is an internal type for claimed notification rows.
 type claimedRow struct {
 	ID         int
@@ -87,11 +133,13 @@ type claimedRow struct {
 	EntityType string
 	EntityID   int
 	Sport      string
+	Attempts   int
 }
 
-// ClaimDue atomically claims a batch of due notifications for sending.
-// Uses FOR UPDATE SKIP LOCKED for safe concurrent dispatch.
-func ClaimDue(ctx context.Context, pool *pgxpool.Pool) ([]claimedRow, error) {
+// ClaimDueBatch atomically claims a batch of due notifications for sending.
+// Uses FOR UPDATE SKIP LOCKED, sized by the caller so multiple concurrent
+// Dispatcher workers can each claim a smaller slice.
+func ClaimDueBatch(ctx context.Context, pool *pgxpool.Pool, limit int) ([]claimedRow, error) {
 	rows, err := pool.Query(ctx, `
 		UPDATE notifications
 		SET status = 'sending', updated_at = NOW()
@@ -102,8 +150,8 @@ func ClaimDue(ctx context.Context, pool *pgxpool.Pool) ([]claimedRow, error) {
 			LIMIT $1
 			FOR UPDATE SKIP LOCKED
 		)
-		RETURNING id, user_id, message, entity_type, entity_id, sport`,
-		dispatchBatchSize,
+		RETURNING id, user_id, message, entity_type, entity_id, sport, attempts`,
+		limit,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("claim due notifications: %w", err)
@@ -113,7 +161,7 @@ func ClaimDue(ctx context.Context, pool *pgxpool.Pool) ([]claimedRow, error) {
 	var claimed []claimedRow
 	for rows.Next() {
 		var r claimedRow
-		if err := rows.Scan(&r.ID, &r.UserID, &r.Message, &r.EntityType, &r.EntityID, &r.Sport); err != nil {
+		if err := rows.Scan(&r.ID, &r.UserID, &r.Message, &r.EntityType, &r.EntityID, &r.Sport, &r.Attempts); err != nil {
 			return nil, fmt.Errorf("scan claimed: %w", err)
 		}
 		claimed = append(claimed, r)
@@ -136,3 +184,23 @@ func MarkFailed(ctx context.Context, pool *pgxpool.Pool, id int, reason string)
 		WHERE id = $1`, id, reason)
 	return err
 }
+
+// RescheduleForRetry flips a notification back to scheduled after a
+// transient send error, bumping its attempt count and pushing scheduled_for
+// out by backoff. Used by the dispatcher's retry ladder.
+func RescheduleForRetry(ctx context.Context, pool *pgxpool.Pool, id int, reason string, backoff time.Duration) error {
+	_, err := pool.Exec(ctx, `
+		UPDATE notifications
+		SET status = 'scheduled',
+			attempts = attempts + 1,
+			scheduled_for = NOW() + ($2 * interval '1 second'),
+			last_error = $3,
+			updated_at = NOW()
+		WHERE id = $1`,
+		id, backoff.Seconds(), reason,
+	)
+	if err != nil {
+		return fmt.Errorf("reschedule notification %d: %w", id, err)
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+// Package observability provides lightweight distributed tracing for
+// ingestion, API, and maintenance workloads.
+//
+// Spans are emitted through a pluggable Exporter rather than the OpenTelemetry
+// SDK — the same call internal/metrics made to hand-roll a minimal Prometheus
+// exposition format "without pulling in the full client_golang dependency
+// tree" applies here too: a handful of spans per request/seed-run/maintenance
+// tick doesn't justify the OTel SDK's dependency graph (otel, otel/sdk,
+// otel/exporters/otlp/...). The default Exporter just logs completed spans;
+// Init wires up OTEL_EXPORTER_OTLP_ENDPOINT when configured, though without
+// the SDK dependency there's nothing to actually export to yet — see Init's
+// doc comment.
+package observability
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// CompletedSpan is everything an Exporter needs to record one finished span.
+type CompletedSpan struct {
+	Name       string
+	Attributes map[string]string
+	Start      time.Time
+	Duration   time.Duration
+	Err        error
+}
+
+// Exporter receives every span as it completes.
+type Exporter interface {
+	Export(s CompletedSpan)
+}
+
+// logExporter is the default Exporter: it logs each span at Debug level (or
+// Warn, if the span carried an error) rather than sending it anywhere.
+type logExporter struct {
+	logger *slog.Logger
+}
+
+func (e logExporter) Export(s CompletedSpan) {
+	logger := e.logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+	args := []interface{}{"duration", s.Duration}
+	for k, v := range s.Attributes {
+		args = append(args, k, v)
+	}
+	if s.Err != nil {
+		args = append(args, "error", s.Err)
+		logger.Warn("span: "+s.Name, args...)
+		return
+	}
+	logger.Debug("span: "+s.Name, args...)
+}
+
+// exporter is the process-wide destination for completed spans, installed
+// once at startup via Init.
+var exporter Exporter = logExporter{}
+
+// Init configures tracing from endpoint (OTEL_EXPORTER_OTLP_ENDPOINT).
+//
+// With endpoint empty, spans go to the default log exporter. With endpoint
+// set, spans still go to the log exporter — there's no OTLP exporter wired
+// up here (see the package doc comment) — but Init logs a warning so a
+// configured-but-silently-ignored endpoint isn't a surprise. A real OTLP
+// exporter can be dropped in later by implementing Exporter and calling
+// SetExporter, without touching any call site below.
+func Init(endpoint string, logger *slog.Logger) {
+	if endpoint == "" {
+		SetExporter(logExporter{logger: logger})
+		return
+	}
+	logger.Warn("observability: OTEL_EXPORTER_OTLP_ENDPOINT is set but no OTLP exporter is wired up yet, spans will only be logged",
+		"endpoint", endpoint)
+	SetExporter(logExporter{logger: logger})
+}
+
+// SetExporter installs exp as the destination for every span completed from
+// this point on. nil restores the default log exporter.
+func SetExporter(exp Exporter) {
+	if exp == nil {
+		exp = logExporter{}
+	}
+	exporter = exp
+}
+
+// spanContextKey namespaces the context key Start attaches the active Span
+// under, so nested Start calls could look up their parent if a future caller
+// needs it (nothing does yet).
+type spanContextKey struct{}
+
+// Span is a single in-flight unit of work, started by Start and finished by
+// End.
+type Span struct {
+	name  string
+	attrs map[string]string
+	start time.Time
+}
+
+// Start begins a span named name with the given attributes (nil is fine) and
+// returns a context carrying it alongside the Span itself. Callers defer
+// span.End(err) to report completion — pass the operation's own error, or
+// nil on success.
+func Start(ctx context.Context, name string, attrs map[string]string) (context.Context, *Span) {
+	s := &Span{name: name, attrs: attrs, start: time.Now()}
+	return context.WithValue(ctx, spanContextKey{}, s), s
+}
+
+// SetAttribute adds or overwrites an attribute on the span, for details only
+// known partway through the operation (e.g. an HTTP handler's resolved route
+// pattern or status code).
+func (s *Span) SetAttribute(key, value string) {
+	if s.attrs == nil {
+		s.attrs = make(map[string]string)
+	}
+	s.attrs[key] = value
+}
+
+// Start returns when this span began, e.g. to feed a metrics histogram that
+// wants the same start time the span itself used.
+func (s *Span) Start() time.Time {
+	return s.start
+}
+
+// End reports the span as complete to the installed Exporter. err is the
+// operation's result — nil on success.
+func (s *Span) End(err error) {
+	exporter.Export(CompletedSpan{
+		Name:       s.name,
+		Attributes: s.attrs,
+		Start:      s.start,
+		Duration:   time.Since(s.start),
+		Err:        err,
+	})
+}
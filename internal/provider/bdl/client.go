@@ -8,14 +8,38 @@ package bdl
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
+	"math/rand"
 	"net/http"
 	"net/url"
+	"strconv"
 	"time"
 
-	"golang.org/x/time/rate"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/albapepper/scoracle-data/internal/provider"
+	"github.com/albapepper/scoracle-data/internal/provider/breaker"
+)
+
+// Circuit breaker tuning: open after breakerFailureThreshold consecutive
+// 5xx/429 within breakerWindow, cool down for breakerCooldown before probing.
+const (
+	breakerFailureThreshold = 5
+	breakerWindow           = time.Minute
+	breakerCooldown         = 30 * time.Second
+)
+
+// Retry tuning for 429/5xx/network errors: a handful of decorrelated-jitter
+// backoff retries within the same call, rather than surfacing the error up
+// to the caller immediately. These are the defaults NewClient falls back to
+// when maxRetries/maxBackoff is <= 0; see its doc comment.
+const (
+	defaultMaxRetries  = 3
+	rateLimitBaseDelay = 1 * time.Second
+	defaultMaxBackoff  = 30 * time.Second
 )
 
 // Client is the shared HTTP client for all BDL endpoints.
@@ -23,22 +47,44 @@ type Client struct {
 	httpClient *http.Client
 	baseURL    string
 	apiKey     string
-	limiter    *rate.Limiter
+	limiters   breaker.RateLimiter
+	cb         *breaker.Breaker
 	logger     *slog.Logger
+
+	maxRetries int
+	maxBackoff time.Duration
 }
 
-// NewClient creates a BDL HTTP client with rate limiting.
-func NewClient(baseURL, apiKey string, requestsPerMinute int, logger *slog.Logger) *Client {
+// NewClient creates a BDL HTTP client with adaptive, per-endpoint rate
+// limiting and a circuit breaker guarding against a flaky endpoint burning
+// the whole requests-per-minute budget. limiter rate-limits requests, keyed
+// by path; pass nil for the in-process default (per-process limiting only —
+// see breaker.RedisLimiter for a distributed alternative shared across every
+// pod hitting the same BDL key). maxRetries and maxBackoff tune how hard
+// getConditional retries a 429/5xx/network error before giving up — pass
+// <= 0 for either to use the package defaults.
+func NewClient(baseURL, apiKey string, requestsPerMinute int, logger *slog.Logger, limiter breaker.RateLimiter, maxRetries int, maxBackoff time.Duration) *Client {
 	if logger == nil {
 		logger = slog.Default()
 	}
-	rps := float64(requestsPerMinute) / 60.0
+	if limiter == nil {
+		limiter = breaker.NewLimiters(float64(requestsPerMinute) / 60.0)
+	}
+	if maxRetries <= 0 {
+		maxRetries = defaultMaxRetries
+	}
+	if maxBackoff <= 0 {
+		maxBackoff = defaultMaxBackoff
+	}
 	return &Client{
 		httpClient: &http.Client{Timeout: 30 * time.Second},
 		baseURL:    baseURL,
 		apiKey:     apiKey,
-		limiter:    rate.NewLimiter(rate.Limit(rps), 1),
+		limiters:   limiter,
+		cb:         breaker.New(breakerFailureThreshold, breakerWindow, breakerCooldown),
 		logger:     logger,
+		maxRetries: maxRetries,
+		maxBackoff: maxBackoff,
 	}
 }
 
@@ -50,10 +96,123 @@ type paginatedResponse struct {
 	} `json:"meta"`
 }
 
-// get performs a rate-limited GET request to a BDL endpoint.
+// get performs a plain, non-conditional rate-limited, circuit-breaker-guarded
+// GET request to a BDL endpoint. It's a convenience wrapper over
+// getConditional for the common case of no incremental caching.
 func (c *Client) get(ctx context.Context, path string, params url.Values) (*paginatedResponse, error) {
-	if err := c.limiter.Wait(ctx); err != nil {
-		return nil, fmt.Errorf("rate limit wait: %w", err)
+	resp, _, _, err := c.getConditional(ctx, path, params, provider.Cursor{})
+	return resp, err
+}
+
+// getConditional is like get but sends If-None-Match/If-Modified-Since from
+// cursor (pass the zero value to always fetch in full), so an endpoint that
+// hasn't changed since the last call can short-circuit with a 304 instead of
+// re-transferring its whole payload. notModified is only true on an actual
+// 304; resp is nil in that case and newCursor is cursor updated with any
+// ETag/Last-Modified the 304 response itself carried.
+// Callers that get notModified back are responsible for reusing whatever
+// they cached last time (see provider.Cursor's Payload field).
+//
+// Each path still gets its own rate limiter, adjusted from Retry-After /
+// X-RateLimit-* response headers, so one bad endpoint doesn't starve sibling
+// endpoints on the same client. A 429/5xx/network error retries with
+// decorrelated-jitter backoff (honoring Retry-After when the response sent
+// one) up to c.maxRetries before being returned to the caller.
+func (c *Client) getConditional(ctx context.Context, path string, params url.Values, cursor provider.Cursor) (resp *paginatedResponse, newCursor provider.Cursor, notModified bool, err error) {
+	var lastErr error
+	delay := rateLimitBaseDelay
+	for attempt := 0; attempt <= c.maxRetries; attempt++ {
+		resp, newCursor, notModified, err := c.fetchOnce(ctx, path, params, cursor)
+		var re *retryableError
+		if !errors.As(err, &re) {
+			return resp, newCursor, notModified, err
+		}
+		lastErr = err
+		if attempt == c.maxRetries {
+			break
+		}
+
+		wait := re.retryAfter
+		if wait <= 0 {
+			delay = decorrelatedBackoff(delay, rateLimitBaseDelay, c.maxBackoff)
+			wait = delay
+		} else if wait > c.maxBackoff {
+			wait = c.maxBackoff
+		}
+		c.logger.Warn("bdl request failed, retrying", "path", path, "attempt", attempt+1, "delay", wait, "error", re.err)
+		select {
+		case <-ctx.Done():
+			return nil, cursor, false, ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+	return nil, cursor, false, lastErr
+}
+
+// retryableError wraps a transient failure (429, 5xx, or a network error)
+// that getConditional's retry loop should back off and retry, carrying the
+// upstream's requested Retry-After when the response included one.
+type retryableError struct {
+	err        error
+	retryAfter time.Duration // 0 when not signaled by the response
+}
+
+func (e *retryableError) Error() string { return e.err.Error() }
+func (e *retryableError) Unwrap() error { return e.err }
+
+// decorrelatedBackoff computes the next retry delay per AWS's decorrelated
+// jitter algorithm: a random duration between base and 3x the previous
+// delay, capped at maxBackoff. This spreads retries out more than a plain
+// exponential-with-jitter backoff, which matters when many seed workers hit
+// the same transient outage at once.
+func decorrelatedBackoff(prev, base, maxBackoff time.Duration) time.Duration {
+	if prev < base {
+		prev = base
+	}
+	upper := prev * 3
+	if upper > maxBackoff {
+		upper = maxBackoff
+	}
+	if upper <= base {
+		return base
+	}
+	return base + time.Duration(rand.Int63n(int64(upper-base)))
+}
+
+// retryAfterDuration parses a Retry-After header, accepting both the
+// delay-seconds form and the HTTP-date form, or 0 if absent/invalid/past.
+func retryAfterDuration(header http.Header) time.Duration {
+	ra := header.Get("Retry-After")
+	if ra == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(ra); err == nil {
+		if secs <= 0 {
+			return 0
+		}
+		return time.Duration(secs) * time.Second
+	}
+	if t, err := http.ParseTime(ra); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
+
+// fetchOnce performs a single conditional GET, bypassing retry logic — a
+// 429/5xx/network error comes back wrapped in retryableError for
+// getConditional's retry loop to act on.
+func (c *Client) fetchOnce(ctx context.Context, path string, params url.Values, cursor provider.Cursor) (resp *paginatedResponse, newCursor provider.Cursor, notModified bool, err error) {
+	start := time.Now()
+	defer func() { observeRequest(path, requestStatus(notModified, err), start) }()
+
+	if !c.cb.Allow() {
+		return nil, cursor, false, fmt.Errorf("bdl %s: %w", path, breaker.ErrOpen)
+	}
+
+	if err := c.limiters.Wait(ctx, path); err != nil {
+		return nil, cursor, false, fmt.Errorf("rate limit wait: %w", err)
 	}
 
 	u := c.baseURL + path
@@ -63,31 +222,137 @@ func (c *Client) get(ctx context.Context, path string, params url.Values) (*pagi
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
 	if err != nil {
-		return nil, fmt.Errorf("create request: %w", err)
+		return nil, cursor, false, fmt.Errorf("create request: %w", err)
 	}
 	req.Header.Set("Authorization", c.apiKey)
+	if cursor.ETag != "" {
+		req.Header.Set("If-None-Match", cursor.ETag)
+	}
+	if cursor.LastModified != "" {
+		req.Header.Set("If-Modified-Since", cursor.LastModified)
+	}
 
-	resp, err := c.httpClient.Do(req)
+	httpResp, err := c.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("http request %s: %w", path, err)
+		if ctx.Err() != nil {
+			return nil, cursor, false, ctx.Err()
+		}
+		return nil, cursor, false, &retryableError{err: fmt.Errorf("http request %s: %w", path, err)}
+	}
+	defer httpResp.Body.Close()
+
+	c.limiters.AdjustFromHeaders(path, httpResp.Header)
+
+	newCursor = cursor
+	if etag := httpResp.Header.Get("ETag"); etag != "" {
+		newCursor.ETag = etag
+	}
+	if lastMod := httpResp.Header.Get("Last-Modified"); lastMod != "" {
+		newCursor.LastModified = lastMod
+	}
+
+	if httpResp.StatusCode == http.StatusNotModified {
+		c.cb.RecordSuccess()
+		_, _ = io.Copy(io.Discard, httpResp.Body)
+		return nil, newCursor, true, nil
 	}
-	defer resp.Body.Close()
 
-	body, err := io.ReadAll(resp.Body)
+	body, err := io.ReadAll(httpResp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("read response body: %w", err)
+		return nil, cursor, false, fmt.Errorf("read response body: %w", err)
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("BDL %s returned %d: %s", path, resp.StatusCode, truncate(body, 200))
+	if httpResp.StatusCode != http.StatusOK {
+		if httpResp.StatusCode == http.StatusTooManyRequests || httpResp.StatusCode >= 500 {
+			c.cb.RecordFailure()
+			return nil, cursor, false, &retryableError{
+				err:        classifyStatus(httpResp.StatusCode, path, body),
+				retryAfter: retryAfterDuration(httpResp.Header),
+			}
+		}
+		return nil, cursor, false, classifyStatus(httpResp.StatusCode, path, body)
 	}
+	c.cb.RecordSuccess()
 
 	var result paginatedResponse
 	if err := json.Unmarshal(body, &result); err != nil {
-		return nil, fmt.Errorf("decode response: %w", err)
+		return nil, cursor, false, fmt.Errorf("decode response: %w", err)
 	}
 
-	return &result, nil
+	return &result, newCursor, false, nil
+}
+
+// paginatedPrefetch drives a cursor-paginated BDL endpoint through a
+// two-stage pipeline: one goroutine issues each page's HTTP request while a
+// second decodes+normalizes the previous page and calls fn, so the network
+// round trip for page N+1 overlaps with processing page N instead of
+// blocking on it. fetch performs one page's GET (its url.Values is mutated
+// in place with the next cursor between calls, matching the sequential
+// callers this replaces); decode unmarshals that page's raw Data into items.
+//
+// BDL's endpoints only support cursor pagination, not offset/page params
+// (see this package's doc comment), so unlike a page-range fan-out across N
+// independent workers, a cursor chain can only ever pipeline two stages —
+// each page's cursor is only known once the prior page's response has
+// decoded. The buffered channel is depth 1 for exactly this reason: it lets
+// the fetcher start page N+1's request as soon as page N lands, without
+// waiting for page N to finish processing.
+func paginatedPrefetch[T any](ctx context.Context, fetch func(ctx context.Context, params url.Values) (*paginatedResponse, error), params url.Values, decode func(json.RawMessage) ([]T, error), fn func(T) error) error {
+	pages := make(chan json.RawMessage, 1)
+	g, ctx := errgroup.WithContext(ctx)
+
+	g.Go(func() error {
+		defer close(pages)
+		for {
+			resp, err := fetch(ctx, params)
+			if err != nil {
+				return err
+			}
+			select {
+			case pages <- resp.Data:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+			if resp.Meta.NextCursor == nil {
+				return nil
+			}
+			params.Set("cursor", strconv.Itoa(*resp.Meta.NextCursor))
+		}
+	})
+
+	g.Go(func() error {
+		for data := range pages {
+			items, err := decode(data)
+			if err != nil {
+				return err
+			}
+			for _, item := range items {
+				if err := fn(item); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+
+	return g.Wait()
+}
+
+// classifyStatus maps a non-200 BDL response to a wrapped sentinel error so
+// callers can tell transient failures (rate limit, server error) apart from
+// permanent ones without inspecting the message.
+func classifyStatus(status int, path string, body []byte) error {
+	detail := fmt.Sprintf("BDL %s returned %d: %s", path, status, truncate(body, 200))
+	switch {
+	case status == http.StatusTooManyRequests:
+		return fmt.Errorf("%s: %w", detail, ErrRateLimited)
+	case status == http.StatusNotFound:
+		return fmt.Errorf("%s: %w", detail, ErrNotFound)
+	case status >= 500:
+		return fmt.Errorf("%s: %w", detail, ErrServerError)
+	default:
+		return errors.New(detail)
+	}
 }
 
 // truncate returns a truncated string representation for error messages.
@@ -0,0 +1,15 @@
+package bdl
+
+import "errors"
+
+// Sentinel errors returned by Client.get so callers can classify failures
+// (e.g. the fixture retry queue distinguishes transient from permanent
+// errors) without parsing error strings or status codes themselves.
+var (
+	// ErrRateLimited indicates BDL returned 429 Too Many Requests.
+	ErrRateLimited = errors.New("bdl: rate limited")
+	// ErrServerError indicates BDL returned a 5xx response.
+	ErrServerError = errors.New("bdl: server error")
+	// ErrNotFound indicates BDL returned 404 for the requested resource.
+	ErrNotFound = errors.New("bdl: not found")
+)
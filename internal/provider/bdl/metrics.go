@@ -0,0 +1,53 @@
+package bdl
+
+import (
+	"errors"
+	"time"
+
+	"github.com/albapepper/scoracle-data/internal/metrics"
+)
+
+// reg is the process-wide metrics registry for bdl requests, set once at
+// startup via SetMetrics. A nil reg (the default) makes every instrumented
+// call a no-op; see internal/seed.SetMetrics for why this is a package-level
+// var rather than a parameter threaded through Client.
+var reg *metrics.Registry
+
+// SetMetrics wires a metrics registry into every subsequent Client request.
+// Call once from cmd/ingest/main.go (or cmd/api/main.go) before constructing
+// any handler.
+func SetMetrics(r *metrics.Registry) {
+	reg = r
+}
+
+// observeRequest records scoracle_bdl_requests_total{endpoint,status} and
+// scoracle_bdl_request_duration_seconds{endpoint} for one fetchOnce call.
+// status is "ok", "not_modified", "rate_limited", "server_error", or
+// "error" (anything else, including decode/network failures).
+func observeRequest(endpoint, status string, start time.Time) {
+	if reg == nil {
+		return
+	}
+	reg.IncCounter("scoracle_bdl_requests_total", "BDL HTTP requests, labeled by endpoint and outcome.",
+		map[string]string{"endpoint": endpoint, "status": status})
+	reg.ObserveDuration("scoracle_bdl_request_duration_seconds", "BDL HTTP request latency in seconds, labeled by endpoint.",
+		map[string]string{"endpoint": endpoint}, start)
+}
+
+// requestStatus classifies a fetchOnce outcome for observeRequest's status
+// label, preferring the same sentinel errors classifyStatus already
+// produces over re-deriving a class from the HTTP status code.
+func requestStatus(notModified bool, err error) string {
+	switch {
+	case err == nil && notModified:
+		return "not_modified"
+	case err == nil:
+		return "ok"
+	case errors.Is(err, ErrRateLimited):
+		return "rate_limited"
+	case errors.Is(err, ErrServerError):
+		return "server_error"
+	default:
+		return "error"
+	}
+}
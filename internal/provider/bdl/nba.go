@@ -0,0 +1,553 @@
+package bdl
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"strconv"
+
+	"github.com/albapepper/scoracle-data/internal/provider"
+	"github.com/albapepper/scoracle-data/internal/provider/breaker"
+	"github.com/albapepper/scoracle-data/internal/provider/schema"
+)
+
+const nbaBaseURL = "https://api.balldontlie.io/v1"
+
+// NBAHandler fetches and normalizes NBA data from BallDontLie.
+type NBAHandler struct {
+	client *Client
+	logger *slog.Logger
+}
+
+// NewNBAHandler creates an NBA handler with the given API key. limiter
+// rate-limits requests; pass nil for the in-process default (see
+// breaker.RateLimiter), so tests can inject a deterministic fake.
+func NewNBAHandler(apiKey string, limiter breaker.RateLimiter, logger *slog.Logger) *NBAHandler {
+	return &NBAHandler{
+		client: NewClient(nbaBaseURL, apiKey, 600, logger, limiter, 0, 0),
+		logger: logger,
+	}
+}
+
+// --------------------------------------------------------------------------
+// Teams
+// --------------------------------------------------------------------------
+
+type bdlTeamRaw struct {
+	ID           int    `json:"id"`
+	Name         string `json:"name"`
+	FullName     string `json:"full_name"`
+	Abbreviation string `json:"abbreviation"`
+	City         string `json:"city"`
+	Conference   string `json:"conference"`
+	Division     string `json:"division"`
+}
+
+// GetTeams fetches all NBA teams in canonical format, always bypassing the
+// conditional-request cache. See GetTeamsCached for incremental fetches.
+func (h *NBAHandler) GetTeams(ctx context.Context) ([]provider.Team, error) {
+	teams, _, _, err := h.GetTeamsCached(ctx, provider.Cursor{})
+	return teams, err
+}
+
+// GetTeamsCached is like GetTeams but conditional on cursor (typically the
+// one a prior call returned, persisted via seed.SaveProviderCursor): when
+// the endpoint reports no change via ETag/Last-Modified, notModified is true
+// and teams is nil — callers should fall back to whatever team list they
+// cached alongside the cursor (see seed.SeedNBA) instead of re-upserting.
+func (h *NBAHandler) GetTeamsCached(ctx context.Context, cursor provider.Cursor) (teams []provider.Team, newCursor provider.Cursor, notModified bool, err error) {
+	resp, newCursor, notModified, err := h.client.getConditional(ctx, "/teams", nil, cursor)
+	if err != nil {
+		return nil, cursor, false, fmt.Errorf("fetch NBA teams: %w", err)
+	}
+	if notModified {
+		return nil, newCursor, true, nil
+	}
+
+	var raw []bdlTeamRaw
+	if err := json.Unmarshal(resp.Data, &raw); err != nil {
+		return nil, cursor, false, fmt.Errorf("decode NBA teams: %w", err)
+	}
+
+	teams = make([]provider.Team, len(raw))
+	for i, t := range raw {
+		teams[i] = normalizeNBATeam(t)
+	}
+	return teams, newCursor, false, nil
+}
+
+func normalizeNBATeam(raw bdlTeamRaw) provider.Team {
+	meta := make(map[string]interface{})
+	if raw.FullName != "" {
+		meta["full_name"] = raw.FullName
+	}
+	return provider.Team{
+		ID:         raw.ID,
+		Name:       raw.Name,
+		ShortCode:  raw.Abbreviation,
+		City:       raw.City,
+		Conference: raw.Conference,
+		Division:   raw.Division,
+		Meta:       meta,
+	}
+}
+
+// --------------------------------------------------------------------------
+// Players (cursor-paginated)
+// --------------------------------------------------------------------------
+
+type bdlPlayerRaw struct {
+	ID        int         `json:"id"`
+	FirstName string      `json:"first_name"`
+	LastName  string      `json:"last_name"`
+	Position  string      `json:"position"`
+	Height    string      `json:"height"`
+	Weight    string      `json:"weight"`
+	Country   string      `json:"country"`
+	Team      *bdlTeamRaw `json:"team"`
+	// Meta fields
+	JerseyNumber json.RawMessage `json:"jersey_number"`
+	College      string          `json:"college"`
+	DraftYear    *int            `json:"draft_year"`
+	DraftRound   *int            `json:"draft_round"`
+	DraftNumber  *int            `json:"draft_number"`
+}
+
+// GetPlayers iterates all NBA players via cursor pagination, calling fn for each.
+func (h *NBAHandler) GetPlayers(ctx context.Context, fn func(provider.Player) error) error {
+	params := url.Values{"per_page": {"100"}}
+
+	for {
+		resp, err := h.client.get(ctx, "/players", params)
+		if err != nil {
+			return fmt.Errorf("fetch NBA players: %w", err)
+		}
+
+		var raw []bdlPlayerRaw
+		if err := json.Unmarshal(resp.Data, &raw); err != nil {
+			return fmt.Errorf("decode NBA players: %w", err)
+		}
+
+		for _, p := range raw {
+			if err := fn(normalizeNBAPlayer(p)); err != nil {
+				return err
+			}
+		}
+
+		if resp.Meta.NextCursor == nil {
+			break
+		}
+		params.Set("cursor", strconv.Itoa(*resp.Meta.NextCursor))
+	}
+	return nil
+}
+
+// GetPlayersParallel is like GetPlayers but overlaps each page's HTTP fetch
+// with the previous page's decode+fn callback via paginatedPrefetch, rather
+// than waiting for fn to return before requesting the next page. workers is
+// accepted for interface symmetry with a future offset/page-capable
+// endpoint but currently has no effect: BDL's /players endpoint only
+// supports cursor pagination, so true N-way fan-out across disjoint page
+// ranges isn't possible — see paginatedPrefetch's doc comment. On a season
+// where fn does non-trivial work (e.g. an upsert) per page, this still cuts
+// wall-clock roughly in half by hiding each page's network round trip behind
+// the previous page's processing; on a cheap fn it buys little, since the
+// round trip already dominates.
+func (h *NBAHandler) GetPlayersParallel(ctx context.Context, workers int, fn func(provider.Player) error) error {
+	_ = workers
+	params := url.Values{"per_page": {"100"}}
+	return paginatedPrefetch(ctx,
+		func(ctx context.Context, params url.Values) (*paginatedResponse, error) {
+			resp, err := h.client.get(ctx, "/players", params)
+			if err != nil {
+				return nil, fmt.Errorf("fetch NBA players: %w", err)
+			}
+			return resp, nil
+		},
+		params,
+		func(data json.RawMessage) ([]provider.Player, error) {
+			var raw []bdlPlayerRaw
+			if err := json.Unmarshal(data, &raw); err != nil {
+				return nil, fmt.Errorf("decode NBA players: %w", err)
+			}
+			players := make([]provider.Player, len(raw))
+			for i, p := range raw {
+				players[i] = normalizeNBAPlayer(p)
+			}
+			return players, nil
+		},
+		fn,
+	)
+}
+
+func normalizeNBAPlayer(raw bdlPlayerRaw) provider.Player {
+	name := (raw.FirstName + " " + raw.LastName)
+	if name == " " {
+		name = fmt.Sprintf("Player %d", raw.ID)
+	}
+
+	meta := make(map[string]interface{})
+	if raw.JerseyNumber != nil && string(raw.JerseyNumber) != "null" {
+		meta["jersey_number"] = json.RawMessage(raw.JerseyNumber)
+	}
+	if raw.College != "" {
+		meta["college"] = raw.College
+	}
+	if raw.DraftYear != nil {
+		meta["draft_year"] = *raw.DraftYear
+	}
+	if raw.DraftRound != nil {
+		meta["draft_round"] = *raw.DraftRound
+	}
+	if raw.DraftNumber != nil {
+		meta["draft_number"] = *raw.DraftNumber
+	}
+
+	var teamID *int
+	if raw.Team != nil {
+		teamID = &raw.Team.ID
+	}
+
+	return provider.Player{
+		ID:          raw.ID,
+		Name:        name,
+		FirstName:   raw.FirstName,
+		LastName:    raw.LastName,
+		Position:    raw.Position,
+		Height:      raw.Height,
+		Weight:      raw.Weight,
+		Nationality: raw.Country,
+		TeamID:      teamID,
+		Meta:        meta,
+	}
+}
+
+// --------------------------------------------------------------------------
+// Player Stats (cursor-paginated season averages)
+// --------------------------------------------------------------------------
+
+type bdlPlayerStatsRaw struct {
+	Player bdlPlayerRaw           `json:"player"`
+	Stats  map[string]interface{} `json:"stats"`
+}
+
+// GetPlayerStats iterates all player season averages, calling fn for each.
+func (h *NBAHandler) GetPlayerStats(ctx context.Context, season int, seasonType string, fn func(provider.PlayerStats) error) error {
+	return h.getPlayerStats(ctx, season, seasonType, nil, fn)
+}
+
+// GetPlayerStatsByTeams iterates player season averages restricted to the
+// given team IDs, calling fn for each. Used for per-fixture targeted seeding
+// so a single fixture only pulls stats for the two participating teams
+// instead of the whole league.
+func (h *NBAHandler) GetPlayerStatsByTeams(ctx context.Context, season int, teamIDs []int, seasonType string, fn func(provider.PlayerStats) error) error {
+	return h.getPlayerStats(ctx, season, seasonType, teamIDs, fn)
+}
+
+// GetPlayerStatsParallel is like GetPlayerStats but pipelines each page's
+// fetch against the previous page's decode+fn callback — see
+// GetPlayersParallel and paginatedPrefetch for why a two-stage pipeline is
+// the most parallelism a cursor-only endpoint allows. workers is accepted
+// for interface symmetry but currently has no effect.
+func (h *NBAHandler) GetPlayerStatsParallel(ctx context.Context, workers int, season int, seasonType string, fn func(provider.PlayerStats) error) error {
+	_ = workers
+	params := url.Values{
+		"season":      {strconv.Itoa(season)},
+		"season_type": {seasonType},
+		"type":        {"base"},
+		"per_page":    {"100"},
+	}
+	return paginatedPrefetch(ctx,
+		func(ctx context.Context, params url.Values) (*paginatedResponse, error) {
+			resp, err := h.client.get(ctx, "/season_averages/general", params)
+			if err != nil {
+				return nil, fmt.Errorf("fetch NBA player stats: %w", err)
+			}
+			return resp, nil
+		},
+		params,
+		func(data json.RawMessage) ([]provider.PlayerStats, error) {
+			var raw []bdlPlayerStatsRaw
+			if err := json.Unmarshal(data, &raw); err != nil {
+				return nil, fmt.Errorf("decode NBA player stats: %w", err)
+			}
+			stats := make([]provider.PlayerStats, len(raw))
+			for i, r := range raw {
+				stats[i] = normalizeNBAPlayerStats(r, seasonType)
+			}
+			return stats, nil
+		},
+		fn,
+	)
+}
+
+// GetPlayerStatsByTeamsParallel is GetPlayerStatsByTeams pipelined like
+// GetPlayerStatsParallel — used by SeedNBA's player_stats phase so a team
+// with enough roster turnover to span multiple pages still gets the
+// prefetch benefit, while the per-team restriction (and the cursor
+// checkpointing built on it) stays intact. workers is accepted for
+// interface symmetry but currently has no effect.
+func (h *NBAHandler) GetPlayerStatsByTeamsParallel(ctx context.Context, workers int, season int, teamIDs []int, seasonType string, fn func(provider.PlayerStats) error) error {
+	_ = workers
+	params := url.Values{
+		"season":      {strconv.Itoa(season)},
+		"season_type": {seasonType},
+		"type":        {"base"},
+		"per_page":    {"100"},
+	}
+	for _, id := range teamIDs {
+		params.Add("team_ids[]", strconv.Itoa(id))
+	}
+	return paginatedPrefetch(ctx,
+		func(ctx context.Context, params url.Values) (*paginatedResponse, error) {
+			resp, err := h.client.get(ctx, "/season_averages/general", params)
+			if err != nil {
+				return nil, fmt.Errorf("fetch NBA player stats: %w", err)
+			}
+			return resp, nil
+		},
+		params,
+		func(data json.RawMessage) ([]provider.PlayerStats, error) {
+			var raw []bdlPlayerStatsRaw
+			if err := json.Unmarshal(data, &raw); err != nil {
+				return nil, fmt.Errorf("decode NBA player stats: %w", err)
+			}
+			stats := make([]provider.PlayerStats, len(raw))
+			for i, r := range raw {
+				stats[i] = normalizeNBAPlayerStats(r, seasonType)
+			}
+			return stats, nil
+		},
+		fn,
+	)
+}
+
+func (h *NBAHandler) getPlayerStats(ctx context.Context, season int, seasonType string, teamIDs []int, fn func(provider.PlayerStats) error) error {
+	params := url.Values{
+		"season":      {strconv.Itoa(season)},
+		"season_type": {seasonType},
+		"type":        {"base"},
+		"per_page":    {"100"},
+	}
+	for _, id := range teamIDs {
+		params.Add("team_ids[]", strconv.Itoa(id))
+	}
+
+	for {
+		resp, err := h.client.get(ctx, "/season_averages/general", params)
+		if err != nil {
+			return fmt.Errorf("fetch NBA player stats: %w", err)
+		}
+
+		var raw []bdlPlayerStatsRaw
+		if err := json.Unmarshal(resp.Data, &raw); err != nil {
+			return fmt.Errorf("decode NBA player stats: %w", err)
+		}
+
+		for _, r := range raw {
+			ps := normalizeNBAPlayerStats(r, seasonType)
+			if err := fn(ps); err != nil {
+				return err
+			}
+		}
+
+		if resp.Meta.NextCursor == nil {
+			break
+		}
+		params.Set("cursor", strconv.Itoa(*resp.Meta.NextCursor))
+	}
+	return nil
+}
+
+func normalizeNBAPlayerStats(raw bdlPlayerStatsRaw, seasonType string) provider.PlayerStats {
+	player := normalizeNBAPlayer(raw.Player)
+	stats := normalizeStatKeys("NBA", raw.Stats)
+	stats["season_type"] = seasonType
+
+	rawJSON, _ := json.Marshal(raw)
+
+	return provider.PlayerStats{
+		PlayerID: player.ID,
+		TeamID:   player.TeamID,
+		Player:   &player,
+		Stats:    stats,
+		Raw:      rawJSON,
+	}
+}
+
+// --------------------------------------------------------------------------
+// Team Stats (cursor-paginated season averages)
+// --------------------------------------------------------------------------
+
+type bdlTeamStatsRaw struct {
+	Team  bdlTeamRaw             `json:"team"`
+	Stats map[string]interface{} `json:"stats"`
+}
+
+// GetTeamStats fetches all team season averages in canonical format.
+func (h *NBAHandler) GetTeamStats(ctx context.Context, season int, seasonType string) ([]provider.TeamStats, error) {
+	return h.getTeamStats(ctx, season, seasonType, nil)
+}
+
+// GetTeamStatsByTeams fetches team season averages restricted to the given
+// team IDs.
+func (h *NBAHandler) GetTeamStatsByTeams(ctx context.Context, season int, teamIDs []int, seasonType string) ([]provider.TeamStats, error) {
+	return h.getTeamStats(ctx, season, seasonType, teamIDs)
+}
+
+func (h *NBAHandler) getTeamStats(ctx context.Context, season int, seasonType string, teamIDs []int) ([]provider.TeamStats, error) {
+	params := url.Values{
+		"season":      {strconv.Itoa(season)},
+		"season_type": {seasonType},
+		"type":        {"base"},
+		"per_page":    {"100"},
+	}
+	for _, id := range teamIDs {
+		params.Add("team_ids[]", strconv.Itoa(id))
+	}
+
+	var all []provider.TeamStats
+
+	for {
+		resp, err := h.client.get(ctx, "/team_season_averages/general", params)
+		if err != nil {
+			return nil, fmt.Errorf("fetch NBA team stats: %w", err)
+		}
+
+		var raw []bdlTeamStatsRaw
+		if err := json.Unmarshal(resp.Data, &raw); err != nil {
+			return nil, fmt.Errorf("decode NBA team stats: %w", err)
+		}
+
+		for _, r := range raw {
+			ts := normalizeNBATeamStats(r, seasonType)
+			all = append(all, ts)
+		}
+
+		if resp.Meta.NextCursor == nil {
+			break
+		}
+		params.Set("cursor", strconv.Itoa(*resp.Meta.NextCursor))
+	}
+
+	return all, nil
+}
+
+func normalizeNBATeamStats(raw bdlTeamStatsRaw, seasonType string) provider.TeamStats {
+	stats := normalizeStatKeys("NBA", raw.Stats)
+	stats["season_type"] = seasonType
+
+	rawJSON, _ := json.Marshal(raw)
+
+	return provider.TeamStats{
+		TeamID: raw.Team.ID,
+		Stats:  stats,
+		Raw:    rawJSON,
+	}
+}
+
+// --------------------------------------------------------------------------
+// Game Stats (per-game box scores, cursor-paginated)
+// --------------------------------------------------------------------------
+
+// bdlGameStatsRaw captures the /stats endpoint's nested player/team/game
+// fields. The stat fields themselves (pts, ast, reb, ...) are inlined at the
+// same level rather than nested, so normalizeNBAGameStats decodes each line
+// a second time into a plain map and strips these keys out to get at them.
+type bdlGameStatsRaw struct {
+	Player bdlPlayerRaw `json:"player"`
+	Team   bdlTeamRaw   `json:"team"`
+	Game   struct {
+		ID int `json:"id"`
+	} `json:"game"`
+}
+
+// GetGameStats iterates box-score stat lines for the given BDL game IDs,
+// calling fn once per player per game. Unlike GetPlayerStats/
+// GetPlayerStatsByTeams (season aggregates), this is the per-game path
+// fixture seeding needs: a fixture has exactly one box-score line per
+// player, not one row per player per season.
+func (h *NBAHandler) GetGameStats(ctx context.Context, gameIDs []int, fn func(provider.GameStats) error) error {
+	params := url.Values{"per_page": {"100"}}
+	for _, id := range gameIDs {
+		params.Add("game_ids[]", strconv.Itoa(id))
+	}
+
+	for {
+		resp, err := h.client.get(ctx, "/stats", params)
+		if err != nil {
+			return fmt.Errorf("fetch NBA game stats: %w", err)
+		}
+
+		var lines []json.RawMessage
+		if err := json.Unmarshal(resp.Data, &lines); err != nil {
+			return fmt.Errorf("decode NBA game stats: %w", err)
+		}
+
+		for _, line := range lines {
+			gs, err := normalizeNBAGameStats(line)
+			if err != nil {
+				return fmt.Errorf("decode NBA game stats line: %w", err)
+			}
+			if err := fn(gs); err != nil {
+				return err
+			}
+		}
+
+		if resp.Meta.NextCursor == nil {
+			break
+		}
+		params.Set("cursor", strconv.Itoa(*resp.Meta.NextCursor))
+	}
+	return nil
+}
+
+func normalizeNBAGameStats(line json.RawMessage) (provider.GameStats, error) {
+	var meta bdlGameStatsRaw
+	if err := json.Unmarshal(line, &meta); err != nil {
+		return provider.GameStats{}, err
+	}
+
+	var statsMap map[string]interface{}
+	if err := json.Unmarshal(line, &statsMap); err != nil {
+		return provider.GameStats{}, err
+	}
+	for _, k := range []string{"id", "player", "team", "game"} {
+		delete(statsMap, k)
+	}
+
+	player := normalizeNBAPlayer(meta.Player)
+	teamID := meta.Team.ID
+
+	return provider.GameStats{
+		GameID:   meta.Game.ID,
+		PlayerID: player.ID,
+		TeamID:   &teamID,
+		Player:   &player,
+		Stats:    normalizeStatKeys("NBA", statsMap),
+		Raw:      line,
+	}, nil
+}
+
+// --------------------------------------------------------------------------
+// Shared stat key normalization
+// --------------------------------------------------------------------------
+
+// strictStatSchema controls whether normalizeStatKeys drops a raw stat key
+// with no schema.Field entry (true) or keeps it verbatim under its original
+// name (false, the default — BDL occasionally adds new fields before this
+// package's schema is updated to match, and dropping them silently would
+// lose data rather than just skip the rename).
+const strictStatSchema = false
+
+// normalizeStatKeys renames BDL stat keys to the canonical names sport's
+// schema.Schema declares, coercing each value to its declared Kind and
+// filling in any field the schema expects but raw didn't report as an
+// explicit null. Shared by both NBA and NFL handlers since BDL's stat
+// renaming (and the schema package it now delegates to) isn't sport-specific
+// machinery, just sport-specific data.
+func normalizeStatKeys(sport string, stats map[string]interface{}) map[string]interface{} {
+	return schema.Normalize(sport, stats, strictStatSchema, nil)
+}
@@ -0,0 +1,231 @@
+// Package breaker provides a half-open circuit breaker and adaptive,
+// per-endpoint rate limiting shared by the BDL and SportMonks HTTP clients,
+// so one flaky endpoint doesn't burn the whole requests-per-minute budget
+// or starve the other endpoints on the same client.
+package breaker
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// --------------------------------------------------------------------------
+// Circuit breaker
+// --------------------------------------------------------------------------
+
+// State is the circuit breaker's current state.
+type State int
+
+const (
+	StateClosed State = iota
+	StateOpen
+	StateHalfOpen
+)
+
+// ErrOpen is returned when the breaker rejects a call because it is open.
+var ErrOpen = fmt.Errorf("circuit breaker open")
+
+// Breaker opens after FailureThreshold consecutive failures within Window,
+// stays open for Cooldown, then lets a single half-open probe through before
+// closing again (or re-opening immediately if the probe also fails).
+type Breaker struct {
+	FailureThreshold int
+	Window           time.Duration
+	Cooldown         time.Duration
+
+	mu          sync.Mutex
+	state       State
+	failures    int
+	windowStart time.Time
+	openedAt    time.Time
+	probing     bool
+}
+
+// New creates a closed Breaker.
+func New(failureThreshold int, window, cooldown time.Duration) *Breaker {
+	return &Breaker{FailureThreshold: failureThreshold, Window: window, Cooldown: cooldown}
+}
+
+// Allow reports whether a call may proceed, transitioning open -> half-open
+// once Cooldown has elapsed.
+func (b *Breaker) Allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case StateOpen:
+		if time.Since(b.openedAt) < b.Cooldown {
+			return false
+		}
+		b.state = StateHalfOpen
+		b.probing = true
+		return true
+	case StateHalfOpen:
+		if b.probing {
+			return false // only one probe in flight at a time
+		}
+		b.probing = true
+		return true
+	default: // StateClosed
+		return true
+	}
+}
+
+// RecordSuccess closes the breaker.
+func (b *Breaker) RecordSuccess() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.state = StateClosed
+	b.failures = 0
+	b.probing = false
+}
+
+// RecordFailure counts a failure, opening the breaker once FailureThreshold
+// consecutive failures occur within Window, or immediately on a failed
+// half-open probe.
+func (b *Breaker) RecordFailure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == StateHalfOpen {
+		b.open()
+		return
+	}
+
+	now := time.Now()
+	if b.windowStart.IsZero() || now.Sub(b.windowStart) > b.Window {
+		b.windowStart = now
+		b.failures = 0
+	}
+	b.failures++
+	if b.failures >= b.FailureThreshold {
+		b.open()
+	}
+}
+
+func (b *Breaker) open() {
+	b.state = StateOpen
+	b.openedAt = time.Now()
+	b.failures = 0
+	b.probing = false
+}
+
+// State returns the current state, for metrics/logging.
+func (b *Breaker) State() State {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state
+}
+
+// --------------------------------------------------------------------------
+// Adaptive per-endpoint rate limiting
+// --------------------------------------------------------------------------
+
+const (
+	minAdaptiveRPS   = 0.1
+	lowRemainingHint = 5 // X-RateLimit-Remaining at or below this triggers throttling
+)
+
+// RateLimiter is what an HTTP client depends on for rate limiting, so a
+// distributed implementation (RedisLimiter) is a drop-in
+// replacement for the in-process default (Limiters). class identifies which
+// tier a call belongs to (typically an endpoint group, not a literal path —
+// see sportmonks' classify) so unrelated endpoint tiers don't starve each
+// other's budget.
+type RateLimiter interface {
+	// Wait blocks until class's bucket admits one request.
+	Wait(ctx context.Context, class string) error
+	// AdjustFromHeaders lowers (or restores) class's rate based on the
+	// upstream response's rate-limit headers.
+	AdjustFromHeaders(class string, header http.Header)
+}
+
+// Limiters holds one in-process token-bucket limiter per class, so a class
+// running hot doesn't also throttle the others. It's the default RateLimiter
+// for a single-process client; see RedisLimiter for a
+// multi-process-safe alternative.
+type Limiters struct {
+	mu         sync.Mutex
+	limiters   map[string]*rate.Limiter
+	defaultRPS float64
+}
+
+// NewLimiters creates a per-class limiter group. defaultRPS is applied to
+// any class the first time it's seen, and restored once headroom returns.
+func NewLimiters(defaultRPS float64) *Limiters {
+	return &Limiters{limiters: make(map[string]*rate.Limiter), defaultRPS: defaultRPS}
+}
+
+// Wait blocks until class's limiter admits one request.
+func (l *Limiters) Wait(ctx context.Context, class string) error {
+	return l.get(class).Wait(ctx)
+}
+
+func (l *Limiters) get(class string) *rate.Limiter {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	lim, ok := l.limiters[class]
+	if !ok {
+		lim = rate.NewLimiter(rate.Limit(l.defaultRPS), 1)
+		l.limiters[class] = lim
+	}
+	return lim
+}
+
+// AdjustFromHeaders lowers class's limiter when the response signals the
+// upstream budget is running low (Retry-After, or X-RateLimit-Remaining /
+// X-RateLimit-Reset), and restores the default rate once headroom returns.
+func (l *Limiters) AdjustFromHeaders(class string, header http.Header) {
+	lim := l.get(class)
+	if rps, ok := AdjustedRPS(header, l.defaultRPS); ok {
+		lim.SetLimit(rate.Limit(rps))
+	}
+}
+
+// AdjustedRPS computes the rate a class's limiter should move to given an
+// upstream response's rate-limit headers, or ok=false if header has no
+// rate-limit signal at all (leave the current rate alone). Shared by
+// Limiters and RedisLimiter so both implementations react to the
+// same headers identically.
+func AdjustedRPS(header http.Header, defaultRPS float64) (rps float64, ok bool) {
+	if ra := header.Get("Retry-After"); ra != "" {
+		if secs, err := strconv.Atoi(ra); err == nil && secs > 0 {
+			return 1.0 / float64(secs), true
+		}
+	}
+
+	remaining, hasRemaining := parseIntHeader(header, "X-RateLimit-Remaining")
+	if !hasRemaining {
+		return 0, false
+	}
+	if remaining > lowRemainingHint {
+		return defaultRPS, true
+	}
+
+	if resetSecs, ok := parseIntHeader(header, "X-RateLimit-Reset"); ok && resetSecs > 0 {
+		rps := float64(remaining) / float64(resetSecs)
+		if rps < minAdaptiveRPS {
+			rps = minAdaptiveRPS
+		}
+		return rps, true
+	}
+	return minAdaptiveRPS, true
+}
+
+func parseIntHeader(header http.Header, key string) (int, bool) {
+	v := header.Get(key)
+	if v == "" {
+		return 0, false
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, false
+	}
+	return n, true
+}
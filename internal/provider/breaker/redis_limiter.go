@@ -0,0 +1,162 @@
+package breaker
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// redisLimiterOpTimeout bounds every Redis round trip the limiter makes, so
+// a slow or unreachable Redis degrades to the in-process fallback rather
+// than blocking the caller.
+const redisLimiterOpTimeout = 500 * time.Millisecond
+
+// redisLimiterBucketTTL is how long an idle class's bucket hash survives in
+// Redis — long enough to span a burst, short enough not to accumulate keys
+// for classes nobody's called in a while.
+const redisLimiterBucketTTL = 10 * time.Minute
+
+// redisLimiterBurst caps how many requests a class can burst before it
+// starts paying the per-token wait, mirroring the burst=1 the in-process
+// Limiters uses per class.
+const redisLimiterBurst = 1
+
+// tokenBucketScript atomically refills and takes one token from a class's
+// bucket, so concurrent pods sharing the same Redis never oversubscribe it.
+// The bucket hash stores tokens/last_refill_ms/rps so a rate lowered by
+// AdjustFromHeaders on one pod is honored by every pod's next Wait call.
+// Returns the number of milliseconds the caller must wait before a token is
+// available (0 meaning one was taken immediately).
+var tokenBucketScript = redis.NewScript(`
+local bucket_key = KEYS[1]
+local now_ms = tonumber(ARGV[1])
+local default_rps = tonumber(ARGV[2])
+local burst = tonumber(ARGV[3])
+
+local data = redis.call('HMGET', bucket_key, 'tokens', 'last_refill_ms', 'rps')
+local tokens = tonumber(data[1])
+local last_ms = tonumber(data[2])
+local rps = tonumber(data[3])
+if rps == nil then
+  rps = default_rps
+end
+if tokens == nil or last_ms == nil then
+  tokens = burst
+  last_ms = now_ms
+end
+
+local elapsed_ms = math.max(0, now_ms - last_ms)
+tokens = math.min(burst, tokens + (elapsed_ms / 1000.0) * rps)
+
+local wait_ms = 0
+if tokens < 1 then
+  wait_ms = math.ceil((1 - tokens) / rps * 1000)
+else
+  tokens = tokens - 1
+end
+
+redis.call('HSET', bucket_key, 'tokens', tokens, 'last_refill_ms', now_ms, 'rps', rps)
+redis.call('PEXPIRE', bucket_key, ARGV[4])
+return wait_ms
+`)
+
+// RedisLimiter is a Redis-backed token bucket, one per class, shared by
+// every process pointed at the same Redis — so a fleet of ingest pods pulls
+// from one quota instead of each enforcing its own per-process limit (which
+// is how a multi-pod deploy blows through a daily API quota even though
+// every pod individually respects its configured rate). Falls back to an
+// in-process Limiters group when Redis is unreachable, so an outage
+// degrades to per-pod limiting rather than failing calls outright.
+type RedisLimiter struct {
+	client     *redis.Client
+	keyPrefix  string
+	defaultRPS float64
+	logger     *slog.Logger
+
+	fallback *Limiters
+}
+
+// NewRedisLimiter connects to redisURL and returns a RedisLimiter whose keys
+// are namespaced by a hash of apiToken (so two tokens sharing a Redis
+// instance, e.g. staging and prod, never collide, and the token itself
+// never appears in a key). requestsPerMinute is the default rate for a class
+// the first time it's seen; AdjustFromHeaders can lower it per class from
+// there.
+func NewRedisLimiter(redisURL, apiToken string, requestsPerMinute int, logger *slog.Logger) (*RedisLimiter, error) {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	opts, err := redis.ParseURL(redisURL)
+	if err != nil {
+		return nil, fmt.Errorf("parse redis url: %w", err)
+	}
+	rps := float64(requestsPerMinute) / 60.0
+	return &RedisLimiter{
+		client:     redis.NewClient(opts),
+		keyPrefix:  fmt.Sprintf("sportmonks:ratelimit:%x:", sha256.Sum256([]byte(apiToken))),
+		defaultRPS: rps,
+		logger:     logger,
+		fallback:   NewLimiters(rps),
+	}, nil
+}
+
+func (r *RedisLimiter) bucketKey(class string) string {
+	return r.keyPrefix + class
+}
+
+// Wait blocks until class's shared bucket admits one request, retrying
+// against Redis's reported wait duration. Degrades to the in-process
+// fallback for the lifetime of this call if Redis can't be reached.
+func (r *RedisLimiter) Wait(ctx context.Context, class string) error {
+	key := r.bucketKey(class)
+	for {
+		waitMs, err := r.tryAcquire(ctx, key)
+		if err != nil {
+			r.logger.Warn("redis rate limiter unreachable, falling back to in-process limit", "class", class, "error", err)
+			return r.fallback.Wait(ctx, class)
+		}
+		if waitMs <= 0 {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Duration(waitMs) * time.Millisecond):
+		}
+	}
+}
+
+func (r *RedisLimiter) tryAcquire(ctx context.Context, key string) (waitMs int64, err error) {
+	opCtx, cancel := context.WithTimeout(ctx, redisLimiterOpTimeout)
+	defer cancel()
+	res, err := tokenBucketScript.Run(opCtx, r.client, []string{key},
+		time.Now().UnixMilli(), r.defaultRPS, redisLimiterBurst, int64(redisLimiterBucketTTL/time.Millisecond)).Int64()
+	if err != nil {
+		return 0, err
+	}
+	return res, nil
+}
+
+// AdjustFromHeaders persists class's adjusted rate into Redis, so every pod
+// sharing the bucket picks it up on their next Wait rather than only the pod
+// that happened to see the throttling response.
+func (r *RedisLimiter) AdjustFromHeaders(class string, header http.Header) {
+	rps, ok := AdjustedRPS(header, r.defaultRPS)
+	if !ok {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), redisLimiterOpTimeout)
+	defer cancel()
+	key := r.bucketKey(class)
+	if err := r.client.HSet(ctx, key, "rps", rps).Err(); err != nil {
+		r.logger.Warn("redis rate limiter adjust failed, falling back to in-process limit", "class", class, "error", err)
+		r.fallback.AdjustFromHeaders(class, header)
+		return
+	}
+	r.client.PExpire(ctx, key, redisLimiterBucketTTL)
+}
@@ -0,0 +1,18 @@
+package provider
+
+import "encoding/json"
+
+// Cursor is the per-endpoint conditional-request state a provider handler
+// needs to skip re-fetching data that hasn't changed since the last seed
+// run. ETag/LastModified come straight off the provider's response headers
+// and round-trip as If-None-Match/If-Modified-Since on the next call.
+// Payload is caller-defined and only consulted when a later call reports
+// notModified, so the caller can fall back to the data it saved alongside
+// this cursor instead of re-deriving it from a response it never received
+// (mirrors Checkpointer.SaveCursor's caller-defined last_cursor in
+// internal/seed). The zero value always forces a full fetch.
+type Cursor struct {
+	ETag         string          `json:"etag,omitempty"`
+	LastModified string          `json:"last_modified,omitempty"`
+	Payload      json.RawMessage `json:"payload,omitempty"`
+}
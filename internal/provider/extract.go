@@ -1,18 +1,292 @@
 package provider
 
-import "strconv"
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
 
-// ExtractValue normalizes a stat value from various API response formats.
-//
-// SportMonks returns dicts like {"total": 15, "goals": 12, "penalties": 3}.
-// BDL returns flat numbers. This handles both, extracting the aggregate.
+// ExtractValue normalizes a stat value from various API response formats
+// using the default StatPath: try the value itself as a scalar, then
+// (for SportMonks-style nested objects) $.total || $.all || $.count ||
+// $.average. Per-stat overrides live in stat_definitions.extraction_path
+// and are compiled via CompileStatPath — see Extractor.
 //
 // Returns the scalar float64 value, and ok=false if not extractable.
 func ExtractValue(val interface{}) (float64, bool) {
+	return defaultStatPath.Extract(val)
+}
+
+// defaultStatPath reproduces ExtractValue's historical fixed-key behavior,
+// and is what every stat_definitions row with a NULL extraction_path still
+// gets via Extractor.Extract.
+var defaultStatPath = mustCompileStatPath("$.total || $.all || $.count || $.average")
+
+// Extractor resolves a per-stat extraction path, falling back to
+// ExtractValue's default behavior for any key without one. Build one via
+// NewExtractor (or seed.LoadExtractionPaths, which reads stat_definitions).
+// A nil *Extractor is valid and behaves as if no overrides exist.
+type Extractor struct {
+	paths map[string]StatPath
+}
+
+// NewExtractor builds an Extractor from key_name -> compiled StatPath.
+func NewExtractor(paths map[string]StatPath) *Extractor {
+	return &Extractor{paths: paths}
+}
+
+// Extract resolves key's value from val, using key's compiled path if one
+// was registered, or the default path otherwise.
+func (ex *Extractor) Extract(key string, val interface{}) (float64, bool) {
+	if ex != nil {
+		if p, ok := ex.paths[key]; ok {
+			return p.Extract(val)
+		}
+	}
+	return ExtractValue(val)
+}
+
+// StatPath is a compiled stat-path expression — a fallback chain of one or
+// more alternatives, each a sequence of steps evaluated left to right
+// against an interface{} tree (the decoded JSON value of one stat).
+//
+// Syntax, compiled by CompileStatPath:
+//
+//	$.total            field access
+//	$.goals.total      chained field access
+//	$[0].value         array index
+//	$.sum(*.count)     wildcard aggregation: sum/avg/max over every child
+//	                   of the current value (object values or array
+//	                   elements), optionally reading .count off each child
+//	                   first
+//	$.total || $.all   fallback chain — try the next alternative if the
+//	                   previous one doesn't resolve to a scalar
+//
+// A bare scalar input (number, or numeric string) always resolves directly,
+// regardless of path, matching ExtractValue's historical behavior of
+// short-circuiting on non-object values.
+type StatPath struct {
+	alternatives [][]pathStep
+}
+
+type pathStep struct {
+	field    string // field access, e.g. "total"
+	index    int    // array index access
+	isIndex  bool
+	aggOp    string // "sum", "avg", "max" — wildcard aggregation
+	aggField string // optional field read off each wildcard child first
+}
+
+// CompileStatPath parses expr into a StatPath. Compiled once per stat
+// definition (see seed.LoadExtractionPaths) and reused across every row
+// that stat appears in.
+func CompileStatPath(expr string) (StatPath, error) {
+	var sp StatPath
+	for _, alt := range strings.Split(expr, "||") {
+		alt = strings.TrimSpace(alt)
+		steps, err := compileAlternative(alt)
+		if err != nil {
+			return StatPath{}, fmt.Errorf("compile stat path %q: %w", expr, err)
+		}
+		sp.alternatives = append(sp.alternatives, steps)
+	}
+	return sp, nil
+}
+
+func mustCompileStatPath(expr string) StatPath {
+	sp, err := CompileStatPath(expr)
+	if err != nil {
+		panic(err)
+	}
+	return sp
+}
+
+func compileAlternative(alt string) ([]pathStep, error) {
+	if !strings.HasPrefix(alt, "$") {
+		return nil, fmt.Errorf("path must start with $: %q", alt)
+	}
+	rest := alt[1:]
+	var steps []pathStep
+	for len(rest) > 0 {
+		switch rest[0] {
+		case '.':
+			rest = rest[1:]
+			name, remainder := splitIdent(rest)
+			if name == "" {
+				return nil, fmt.Errorf("empty field name in %q", alt)
+			}
+			if remainder != "" && remainder[0] == '(' {
+				argEnd := strings.IndexByte(remainder, ')')
+				if argEnd < 0 {
+					return nil, fmt.Errorf("unclosed ( in %q", alt)
+				}
+				switch name {
+				case "sum", "avg", "max":
+				default:
+					return nil, fmt.Errorf("unknown aggregation %q in %q", name, alt)
+				}
+				arg := remainder[1:argEnd]
+				step := pathStep{aggOp: name}
+				if arg != "*" {
+					aggField := strings.TrimPrefix(arg, "*.")
+					if aggField == arg {
+						return nil, fmt.Errorf("unsupported aggregation argument %q in %q", arg, alt)
+					}
+					step.aggField = aggField
+				}
+				steps = append(steps, step)
+				rest = remainder[argEnd+1:]
+				continue
+			}
+			steps = append(steps, pathStep{field: name})
+			rest = remainder
+		case '[':
+			end := strings.IndexByte(rest, ']')
+			if end < 0 {
+				return nil, fmt.Errorf("unclosed [ in %q", alt)
+			}
+			idx, err := strconv.Atoi(rest[1:end])
+			if err != nil {
+				return nil, fmt.Errorf("bad array index in %q: %w", alt, err)
+			}
+			steps = append(steps, pathStep{index: idx, isIndex: true})
+			rest = rest[end+1:]
+		default:
+			return nil, fmt.Errorf("unexpected %q in %q", rest[0:1], alt)
+		}
+	}
+	return steps, nil
+}
+
+func splitIdent(s string) (ident, rest string) {
+	i := 0
+	for i < len(s) && (isIdentByte(s[i])) {
+		i++
+	}
+	return s[:i], s[i:]
+}
+
+func isIdentByte(b byte) bool {
+	return b == '_' || (b >= 'a' && b <= 'z') || (b >= 'A' && b <= 'Z') || (b >= '0' && b <= '9')
+}
+
+// Extract evaluates p against val, trying each alternative in order and
+// returning the first that resolves to a scalar. val itself resolving to a
+// scalar directly (BDL's flat numbers) always short-circuits.
+func (p StatPath) Extract(val interface{}) (float64, bool) {
 	if val == nil {
 		return 0, false
 	}
+	if f, ok := coerceScalar(val); ok {
+		return f, true
+	}
+	for _, alt := range p.alternatives {
+		cur, ok := evalSteps(val, alt)
+		if !ok {
+			continue
+		}
+		if f, ok := coerceScalar(cur); ok {
+			return f, true
+		}
+		// The path landed on another object/array (e.g. "goals" before
+		// ".total"); recurse with the default key chain so a caller's
+		// custom prefix can still terminate on whichever key is present.
+		if f, ok := defaultStatPath.Extract(cur); ok {
+			return f, true
+		}
+	}
+	return 0, false
+}
+
+func evalSteps(val interface{}, steps []pathStep) (interface{}, bool) {
+	cur := val
+	for _, s := range steps {
+		switch {
+		case s.aggOp != "":
+			children, ok := wildcardChildren(cur)
+			if !ok {
+				return nil, false
+			}
+			vals := make([]float64, 0, len(children))
+			for _, c := range children {
+				v := c
+				if s.aggField != "" {
+					m, ok := c.(map[string]interface{})
+					if !ok {
+						continue
+					}
+					cv, exists := m[s.aggField]
+					if !exists {
+						continue
+					}
+					v = cv
+				}
+				if f, ok := coerceScalar(v); ok {
+					vals = append(vals, f)
+				}
+			}
+			if len(vals) == 0 {
+				return nil, false
+			}
+			cur = aggregate(s.aggOp, vals)
+		case s.isIndex:
+			arr, ok := cur.([]interface{})
+			if !ok || s.index < 0 || s.index >= len(arr) {
+				return nil, false
+			}
+			cur = arr[s.index]
+		default:
+			m, ok := cur.(map[string]interface{})
+			if !ok {
+				return nil, false
+			}
+			v, exists := m[s.field]
+			if !exists || v == nil {
+				return nil, false
+			}
+			cur = v
+		}
+	}
+	return cur, true
+}
 
+func wildcardChildren(val interface{}) ([]interface{}, bool) {
+	switch v := val.(type) {
+	case []interface{}:
+		return v, true
+	case map[string]interface{}:
+		children := make([]interface{}, 0, len(v))
+		for _, cv := range v {
+			children = append(children, cv)
+		}
+		return children, true
+	}
+	return nil, false
+}
+
+func aggregate(op string, vals []float64) float64 {
+	sum := 0.0
+	max := vals[0]
+	for _, v := range vals {
+		sum += v
+		if v > max {
+			max = v
+		}
+	}
+	switch op {
+	case "avg":
+		return sum / float64(len(vals))
+	case "max":
+		return max
+	default: // "sum"
+		return sum
+	}
+}
+
+// coerceScalar converts val to a float64 if it's already a number or a
+// numeric string — the leaf case for both plain BDL values and the tail
+// end of a resolved SportMonks path.
+func coerceScalar(val interface{}) (float64, bool) {
 	switch v := val.(type) {
 	case float64:
 		return v, true
@@ -24,16 +298,6 @@ func ExtractValue(val interface{}) (float64, bool) {
 		if f, err := strconv.ParseFloat(v, 64); err == nil {
 			return f, true
 		}
-		return 0, false
-	case map[string]interface{}:
-		// SportMonks nested objects: try "total", "all", "count", "average"
-		for _, key := range []string{"total", "all", "count", "average"} {
-			if inner, exists := v[key]; exists && inner != nil {
-				return ExtractValue(inner)
-			}
-		}
-		return 0, false
-	default:
-		return 0, false
 	}
+	return 0, false
 }
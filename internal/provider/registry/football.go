@@ -0,0 +1,129 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/albapepper/scoracle-data/internal/db"
+	"github.com/albapepper/scoracle-data/internal/provider"
+	"github.com/albapepper/scoracle-data/internal/provider/sportmonks"
+	"github.com/albapepper/scoracle-data/internal/seed"
+)
+
+const footballBaseURL = "https://api.sportmonks.com/v3/football"
+
+// FootballSeeder adapts sportmonks.FootballHandler to the SportSeeder
+// contract.
+type FootballSeeder struct {
+	Handler *sportmonks.FootballHandler
+}
+
+// NewFootballEntry builds the registry Entry for Football, requiring the
+// SportMonks API token.
+func NewFootballEntry(handler *sportmonks.FootballHandler) Entry {
+	return Entry{
+		Sport:       "FOOTBALL",
+		Seeder:      &FootballSeeder{Handler: handler},
+		RequiredEnv: []string{"SPORTMONKS_API_TOKEN"},
+		BaseURL:     footballBaseURL,
+	}
+}
+
+// SeedFixture seeds the season's teams, then player stats and standings for
+// the fixture's two participating teams, restricted by squad/team filters so
+// a single fixture doesn't pull the whole league's stats.
+func (s *FootballSeeder) SeedFixture(ctx context.Context, pool *pgxpool.Pool, fx FixtureContext, logger *slog.Logger) (seed.SeedResult, error) {
+	var result seed.SeedResult
+
+	leagueID := 0
+	if fx.LeagueID != nil {
+		leagueID = *fx.LeagueID
+	}
+
+	smSeasonID, err := seed.ResolveProviderSeasonID(ctx, pool, leagueID, fx.Season)
+	if err != nil {
+		result.AddErr("FOOTBALL", "resolve season", err)
+		return result, nil
+	}
+
+	logger.Info("Seeding football teams...", "sm_season_id", smSeasonID)
+	teams, err := s.Handler.GetTeams(ctx, smSeasonID)
+	if err != nil {
+		result.AddErr("FOOTBALL", "fetch teams", err)
+	} else if n, err := seed.UpsertTeamsBatch(ctx, pool, "FOOTBALL", teams); err != nil {
+		result.AddErr("FOOTBALL", "upsert teams", err)
+	} else {
+		result.TeamsUpserted += n
+	}
+
+	var dbSmID *int
+	var leagueName string
+	err = db.QueryRowLeagueLookup(ctx, pool, leagueID).Scan(&dbSmID, &leagueName)
+	if err != nil || dbSmID == nil {
+		if err == nil {
+			err = fmt.Errorf("no sportmonks_id for league %d", leagueID)
+		}
+		result.AddErr("FOOTBALL", "resolve sportmonks league id", err)
+		return result, nil
+	}
+	smLeagueID := *dbSmID
+
+	fixtureTeamIDs := []int{fx.HomeTeamID, fx.AwayTeamID}
+	logger.Info("Seeding football player stats...", "team_ids", fixtureTeamIDs)
+
+	var players []provider.Player
+	var playerStats []provider.PlayerStats
+	err = s.Handler.GetPlayersWithStats(ctx, smSeasonID, fixtureTeamIDs, smLeagueID,
+		func(ps provider.PlayerStats) error {
+			if ps.Player != nil {
+				players = append(players, *ps.Player)
+			}
+			if len(ps.Stats) > 0 {
+				playerStats = append(playerStats, ps)
+			}
+			return nil
+		})
+	if err != nil {
+		result.AddErr("FOOTBALL", "fetch players/stats", err)
+	}
+
+	if n, err := seed.UpsertPlayersBatch(ctx, pool, "FOOTBALL", players); err != nil {
+		result.AddErr("FOOTBALL", "upsert players", err)
+	} else {
+		result.PlayersUpserted += n
+	}
+	if n, changed, err := seed.UpsertPlayerStatsBatch(ctx, pool, "FOOTBALL", fx.Season, leagueID, playerStats); err != nil {
+		result.AddErr("FOOTBALL", "upsert player stats", err)
+	} else {
+		result.PlayerStatsUpserted += n
+		result.PlayerStatsChanged += changed
+	}
+	logger.Info("Football player stats done", "count", result.PlayerStatsUpserted, "changed", result.PlayerStatsChanged)
+
+	logger.Info("Seeding football standings...", "team_ids", fixtureTeamIDs)
+	teamStats, err := s.Handler.GetTeamStatsByTeams(ctx, smSeasonID, fixtureTeamIDs)
+	if err != nil {
+		result.AddErr("FOOTBALL", "fetch standings", err)
+	} else {
+		var standingsTeams []provider.Team
+		for _, ts := range teamStats {
+			if ts.Team != nil {
+				standingsTeams = append(standingsTeams, *ts.Team)
+			}
+		}
+		_, _ = seed.UpsertTeamsBatch(ctx, pool, "FOOTBALL", standingsTeams)
+
+		if n, changed, err := seed.UpsertTeamStatsBatch(ctx, pool, "FOOTBALL", fx.Season, leagueID, teamStats); err != nil {
+			result.AddErr("FOOTBALL", "upsert team stats", err)
+		} else {
+			result.TeamStatsUpserted += n
+			result.TeamStatsChanged += changed
+		}
+	}
+	logger.Info("Football standings done", "count", result.TeamStatsUpserted)
+
+	return result, nil
+}
@@ -0,0 +1,96 @@
+package registry
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/albapepper/scoracle-data/internal/provider"
+	"github.com/albapepper/scoracle-data/internal/provider/bdl"
+	"github.com/albapepper/scoracle-data/internal/seed"
+)
+
+const nbaBaseURL = "https://api.balldontlie.io/v1"
+
+// NBASeeder adapts bdl.NBAHandler to the SportSeeder contract.
+type NBASeeder struct {
+	Handler *bdl.NBAHandler
+}
+
+// NewNBAEntry builds the registry Entry for NBA, requiring the BallDontLie
+// API key.
+func NewNBAEntry(handler *bdl.NBAHandler) Entry {
+	return Entry{
+		Sport:       "NBA",
+		Seeder:      &NBASeeder{Handler: handler},
+		RequiredEnv: []string{"BALLDONTLIE_API_KEY"},
+		BaseURL:     nbaBaseURL,
+	}
+}
+
+// SeedFixture seeds player and team stats for the fixture's two participating
+// teams, using the team-filtered BDL endpoints so a single fixture doesn't
+// pull the whole league. Rows are buffered off the provider's streaming
+// callback and written via the batch upsert helpers, so a fixture group with
+// hundreds of player-stat rows costs a handful of round trips instead of one
+// per row.
+func (s *NBASeeder) SeedFixture(ctx context.Context, pool *pgxpool.Pool, fx FixtureContext, logger *slog.Logger) (seed.SeedResult, error) {
+	var result seed.SeedResult
+	teamIDs := []int{fx.HomeTeamID, fx.AwayTeamID}
+
+	logger.Info("Seeding NBA player stats...", "season", fx.Season, "team_ids", teamIDs)
+	var players []provider.Player
+	var playerStats []provider.PlayerStats
+	err := s.Handler.GetPlayerStatsByTeams(ctx, fx.Season, teamIDs, "regular", func(ps provider.PlayerStats) error {
+		if ps.Player != nil {
+			players = append(players, *ps.Player)
+		}
+		playerStats = append(playerStats, ps)
+		return nil
+	})
+	if err != nil {
+		result.AddErr("NBA", "fetch NBA player stats", err)
+		return result, nil
+	}
+
+	if n, err := seed.UpsertPlayersBatch(ctx, pool, "NBA", players); err != nil {
+		result.AddErr("NBA", "upsert players", err)
+	} else {
+		result.PlayersUpserted += n
+	}
+	if n, changed, err := seed.UpsertPlayerStatsBatch(ctx, pool, "NBA", fx.Season, 0, playerStats); err != nil {
+		result.AddErr("NBA", "upsert player stats", err)
+	} else {
+		result.PlayerStatsUpserted += n
+		result.PlayerStatsChanged += changed
+	}
+	logger.Info("NBA player stats done", "count", result.PlayerStatsUpserted, "changed", result.PlayerStatsChanged)
+
+	logger.Info("Seeding NBA team stats...", "season", fx.Season)
+	teamStats, err := s.Handler.GetTeamStatsByTeams(ctx, fx.Season, teamIDs, "regular")
+	if err != nil {
+		result.AddErr("NBA", "fetch NBA team stats", err)
+		return result, nil
+	}
+	if n, changed, err := seed.UpsertTeamStatsBatch(ctx, pool, "NBA", fx.Season, 0, teamStats); err != nil {
+		result.AddErr("NBA", "upsert team stats", err)
+	} else {
+		result.TeamStatsUpserted += n
+		result.TeamStatsChanged += changed
+	}
+	logger.Info("NBA team stats done", "count", result.TeamStatsUpserted, "changed", result.TeamStatsChanged)
+
+	if fx.ExternalID != nil {
+		logger.Info("Seeding NBA game stats...", "game_id", *fx.ExternalID)
+		gameResult := seed.SeedFixtureGameStats(ctx, pool, s.Handler, seed.GameSeedRequest{
+			FixtureID: fx.FixtureID,
+			GameID:    *fx.ExternalID,
+			Sport:     "NBA",
+		})
+		result.Add(gameResult)
+		logger.Info("NBA game stats done", "count", gameResult.PlayerStatsUpserted, "changed", gameResult.PlayerStatsChanged)
+	}
+
+	return result, nil
+}
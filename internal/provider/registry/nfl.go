@@ -0,0 +1,85 @@
+package registry
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/albapepper/scoracle-data/internal/provider"
+	"github.com/albapepper/scoracle-data/internal/provider/bdl"
+	"github.com/albapepper/scoracle-data/internal/seed"
+)
+
+const nflBaseURL = "https://api.balldontlie.io/nfl/v1"
+
+// NFLSeeder adapts bdl.NFLHandler to the SportSeeder contract.
+type NFLSeeder struct {
+	Handler *bdl.NFLHandler
+}
+
+// NewNFLEntry builds the registry Entry for NFL, requiring the BallDontLie
+// API key.
+func NewNFLEntry(handler *bdl.NFLHandler) Entry {
+	return Entry{
+		Sport:       "NFL",
+		Seeder:      &NFLSeeder{Handler: handler},
+		RequiredEnv: []string{"BALLDONTLIE_API_KEY"},
+		BaseURL:     nflBaseURL,
+	}
+}
+
+// SeedFixture seeds player and team stats for the fixture's two participating
+// teams, using the team-filtered BDL endpoints so a single fixture doesn't
+// pull the whole league. Rows are buffered off the provider's streaming
+// callback and written via the batch upsert helpers, so a fixture group with
+// hundreds of player-stat rows costs a handful of round trips instead of one
+// per row.
+func (s *NFLSeeder) SeedFixture(ctx context.Context, pool *pgxpool.Pool, fx FixtureContext, logger *slog.Logger) (seed.SeedResult, error) {
+	var result seed.SeedResult
+	teamIDs := []int{fx.HomeTeamID, fx.AwayTeamID}
+
+	logger.Info("Seeding NFL player stats...", "season", fx.Season, "team_ids", teamIDs)
+	var players []provider.Player
+	var playerStats []provider.PlayerStats
+	err := s.Handler.GetPlayerStatsByTeams(ctx, fx.Season, teamIDs, false, func(ps provider.PlayerStats) error {
+		if ps.Player != nil {
+			players = append(players, *ps.Player)
+		}
+		playerStats = append(playerStats, ps)
+		return nil
+	})
+	if err != nil {
+		result.AddErr("NFL", "fetch NFL player stats", err)
+		return result, nil
+	}
+
+	if n, err := seed.UpsertPlayersBatch(ctx, pool, "NFL", players); err != nil {
+		result.AddErr("NFL", "upsert players", err)
+	} else {
+		result.PlayersUpserted += n
+	}
+	if n, changed, err := seed.UpsertPlayerStatsBatch(ctx, pool, "NFL", fx.Season, 0, playerStats); err != nil {
+		result.AddErr("NFL", "upsert player stats", err)
+	} else {
+		result.PlayerStatsUpserted += n
+		result.PlayerStatsChanged += changed
+	}
+	logger.Info("NFL player stats done", "count", result.PlayerStatsUpserted, "changed", result.PlayerStatsChanged)
+
+	logger.Info("Seeding NFL team stats...", "season", fx.Season)
+	teamStats, err := s.Handler.GetTeamStatsByTeams(ctx, fx.Season, teamIDs, "regular")
+	if err != nil {
+		result.AddErr("NFL", "fetch NFL team stats", err)
+		return result, nil
+	}
+	if n, changed, err := seed.UpsertTeamStatsBatch(ctx, pool, "NFL", fx.Season, 0, teamStats); err != nil {
+		result.AddErr("NFL", "upsert team stats", err)
+	} else {
+		result.TeamStatsUpserted += n
+		result.TeamStatsChanged += changed
+	}
+	logger.Info("NFL team stats done", "count", result.TeamStatsUpserted, "changed", result.TeamStatsChanged)
+
+	return result, nil
+}
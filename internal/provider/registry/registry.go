@@ -0,0 +1,125 @@
+// Package registry lets sport-specific seeders self-register under a sport
+// key, so fixture.SeedFixture can dispatch generically instead of hard-coding
+// a switch over every supported sport. Adding a new sport (or a third-party
+// one) means adding an Entry here, not touching fixture/.
+package registry
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/albapepper/scoracle-data/internal/cache"
+	"github.com/albapepper/scoracle-data/internal/config"
+	"github.com/albapepper/scoracle-data/internal/provider/bdl"
+	"github.com/albapepper/scoracle-data/internal/provider/breaker"
+	"github.com/albapepper/scoracle-data/internal/provider/sportmonks"
+	"github.com/albapepper/scoracle-data/internal/seed"
+)
+
+// FixtureContext is the subset of fixture data a sport seeder needs. Passed
+// by value so this package doesn't have to import fixture (which imports
+// this package to dispatch).
+type FixtureContext struct {
+	FixtureID  int
+	ExternalID *int // provider's external game/fixture ID, for per-game box-score fetches
+	Season     int
+	LeagueID   *int
+	HomeTeamID int
+	AwayTeamID int
+}
+
+// SportSeeder seeds stats for a single fixture of one sport.
+type SportSeeder interface {
+	SeedFixture(ctx context.Context, pool *pgxpool.Pool, fx FixtureContext, logger *slog.Logger) (seed.SeedResult, error)
+}
+
+// Entry is a sport's registration: its seeder plus the config it requires,
+// so callers (cmd/ingest's main) can validate config and print which sports
+// are enabled at startup.
+type Entry struct {
+	Sport       string
+	Seeder      SportSeeder
+	RequiredEnv []string // env vars that must be set for this sport to work
+	BaseURL     string   // upstream provider base URL, for startup logging
+}
+
+// Registry holds self-registered sport seeders, keyed by sport.
+type Registry struct {
+	mu      sync.RWMutex
+	entries map[string]Entry
+}
+
+// New creates an empty Registry.
+func New() *Registry {
+	return &Registry{entries: make(map[string]Entry)}
+}
+
+// Register adds a sport's entry. Intended to be called once at startup for
+// each sport whose required config is present.
+func (r *Registry) Register(e Entry) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.entries[e.Sport] = e
+}
+
+// Lookup returns the registered seeder for sport, or an error if none is
+// registered (e.g. its API key wasn't configured).
+func (r *Registry) Lookup(sport string) (SportSeeder, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	e, ok := r.entries[sport]
+	if !ok {
+		return nil, fmt.Errorf("no seeder registered for sport: %s", sport)
+	}
+	return e.Seeder, nil
+}
+
+// Build constructs a Registry, registering a sport's seeder only when its
+// required API credentials are configured — so callers that touch fixture
+// processing (cmd/ingest's `fixtures` commands, the admin SSE handler) skip
+// sports that aren't set up instead of failing at startup.
+func Build(cfg *config.Config, logger *slog.Logger) *Registry {
+	reg := New()
+	if cfg.BDLAPIKey != "" {
+		reg.Register(NewNBAEntry(bdl.NewNBAHandler(cfg.BDLAPIKey, nil, logger)))
+		reg.Register(NewNFLEntry(bdl.NewNFLHandler(cfg.BDLAPIKey, nil, logger)))
+	}
+	if cfg.SportMonksAPIToken != "" {
+		reg.Register(NewFootballEntry(sportmonks.NewFootballHandler(cfg.SportMonksAPIToken, cache.New(cfg), sportMonksLimiter(cfg, logger), logger)))
+	}
+	return reg
+}
+
+// sportMonksLimiter builds the SportMonks rate limiter per config: a
+// Redis-backed limiter shared across every process (so a multi-pod ingest
+// deploy draws from one quota) when CacheBackend is "redis" and RedisURL is
+// set and parses, or nil for the client's in-process default otherwise.
+// Reachability is not checked here; never fails the caller — an unreachable
+// Redis makes each limiter call fall back to per-process limiting rather
+// than blocking startup.
+func sportMonksLimiter(cfg *config.Config, logger *slog.Logger) breaker.RateLimiter {
+	if cfg.CacheBackend != "redis" || cfg.RedisURL == "" {
+		return nil
+	}
+	limiter, err := breaker.NewRedisLimiter(cfg.RedisURL, cfg.SportMonksAPIToken, 300, logger)
+	if err != nil {
+		logger.Warn("sportmonks redis rate limiter unavailable, falling back to in-process limiting", "error", err)
+		return nil
+	}
+	return limiter
+}
+
+// Enabled returns the sports with a registered seeder, for startup logging.
+func (r *Registry) Enabled() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	sports := make([]string, 0, len(r.entries))
+	for sport := range r.entries {
+		sports = append(sports, sport)
+	}
+	return sports
+}
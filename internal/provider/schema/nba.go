@@ -0,0 +1,33 @@
+package schema
+
+// NBA season-average stat keys, as normalized from BallDontLie's
+// /season_averages/general response (PlayerStats/TeamStats) and /stats
+// per-game box scores (GameStats). Field coverage mirrors what BDL's v1 API
+// documents today; a key BDL adds later just needs a new Field here, not a
+// new case in a provider-side switch.
+func init() {
+	Register("NBA", []Field{
+		{Key: "games_played", Kind: KindInt, Aliases: []string{"gp"}},
+		{Key: "wins", Kind: KindInt, Aliases: []string{"w"}},
+		{Key: "losses", Kind: KindInt, Aliases: []string{"l"}},
+		{Key: "turnover", Kind: KindFloat, Aliases: []string{"tov"}},
+		{Key: "min", Kind: KindString},
+		{Key: "pts", Kind: KindFloat},
+		{Key: "ast", Kind: KindFloat},
+		{Key: "reb", Kind: KindFloat},
+		{Key: "oreb", Kind: KindFloat},
+		{Key: "dreb", Kind: KindFloat},
+		{Key: "stl", Kind: KindFloat},
+		{Key: "blk", Kind: KindFloat},
+		{Key: "pf", Kind: KindFloat},
+		{Key: "fgm", Kind: KindFloat},
+		{Key: "fga", Kind: KindFloat},
+		{Key: "fg_pct", Kind: KindFloat, Unit: "percent"},
+		{Key: "fg3m", Kind: KindFloat},
+		{Key: "fg3a", Kind: KindFloat},
+		{Key: "fg3_pct", Kind: KindFloat, Unit: "percent"},
+		{Key: "ftm", Kind: KindFloat},
+		{Key: "fta", Kind: KindFloat},
+		{Key: "ft_pct", Kind: KindFloat, Unit: "percent"},
+	})
+}
@@ -0,0 +1,29 @@
+package schema
+
+// NFL season stat keys, as normalized from BallDontLie's /season_stats and
+// /team_season_averages/general responses. The team-level renames (w/l/gp,
+// tov) mirror NBA's — BDL uses the same shorthand across sports for those —
+// while the rest of the field list covers the offensive stat categories
+// /season_stats exposes per player. As with schema/nba.go, a BDL field this
+// list is missing is a new Field here, not a provider-side code change.
+func init() {
+	Register("NFL", []Field{
+		{Key: "games_played", Kind: KindInt, Aliases: []string{"gp"}},
+		{Key: "wins", Kind: KindInt, Aliases: []string{"w"}},
+		{Key: "losses", Kind: KindInt, Aliases: []string{"l"}},
+		{Key: "turnover", Kind: KindFloat, Aliases: []string{"tov"}},
+		{Key: "passing_yards", Kind: KindFloat},
+		{Key: "passing_touchdowns", Kind: KindFloat},
+		{Key: "passing_interceptions", Kind: KindFloat},
+		{Key: "passing_completions", Kind: KindFloat},
+		{Key: "passing_attempts", Kind: KindFloat},
+		{Key: "rushing_yards", Kind: KindFloat},
+		{Key: "rushing_touchdowns", Kind: KindFloat},
+		{Key: "rushing_attempts", Kind: KindFloat},
+		{Key: "receiving_yards", Kind: KindFloat},
+		{Key: "receiving_touchdowns", Kind: KindFloat},
+		{Key: "receptions", Kind: KindFloat},
+		{Key: "fumbles", Kind: KindFloat},
+		{Key: "sacks", Kind: KindFloat},
+	})
+}
@@ -0,0 +1,188 @@
+// Package schema declares, per sport, the canonical set of stat keys that
+// provider.PlayerStats/provider.TeamStats' Stats maps are expected to carry
+// — their Go type, an informational unit, and the upstream provider aliases
+// that map onto them. It replaces bdl's old normalizeStatKeys ad-hoc rename
+// switch with a table a new sport only needs to extend, not a new case in
+// every provider handler's normalization code.
+package schema
+
+import (
+	"fmt"
+	"log/slog"
+)
+
+// Kind is a stat value's canonical Go type, used to coerce raw JSON numbers
+// (which decode as float64 regardless of whether the upstream sent an int)
+// into the type Postgres JSONB queries expect.
+type Kind int
+
+const (
+	KindFloat Kind = iota
+	KindInt
+	KindString
+)
+
+// Field describes one canonical stat key.
+type Field struct {
+	Key     string // canonical key, as stored in the stats JSONB column
+	Kind    Kind
+	Unit    string   // e.g. "percent", "minutes" — informational only, not enforced
+	Aliases []string // upstream provider keys that normalize onto Key
+}
+
+// Schema is one sport's full set of expected stat fields.
+type Schema struct {
+	Sport  string
+	Fields []Field
+
+	byKey   map[string]Field
+	byAlias map[string]string // alias (including the canonical key itself) -> canonical key
+}
+
+var registry = map[string]*Schema{}
+
+// Register adds a sport's schema, keyed by sport. Intended to be called once
+// from each schema file's package init.
+func Register(sport string, fields []Field) *Schema {
+	s := &Schema{
+		Sport:   sport,
+		Fields:  fields,
+		byKey:   make(map[string]Field, len(fields)),
+		byAlias: make(map[string]string, len(fields)),
+	}
+	for _, f := range fields {
+		s.byKey[f.Key] = f
+		s.byAlias[f.Key] = f.Key
+		for _, a := range f.Aliases {
+			s.byAlias[a] = f.Key
+		}
+	}
+	registry[sport] = s
+	return s
+}
+
+// Normalize maps raw's upstream keys onto sport's canonical keys, coercing
+// each value to its schema-declared Kind, and fills in any declared field
+// raw didn't report as an explicit null — rather than an absent key — so a
+// Postgres JSONB query like `stats->>'turnover'` behaves the same whether
+// the provider reported the stat or not, instead of needing a `?` existence
+// check for partially-populated seasons.
+//
+// strict=true drops (and logs at Warn) any raw key with no schema entry;
+// strict=false keeps it verbatim under its original name, which matters for
+// a sport whose schema is still being filled in. A sport with no registered
+// schema returns raw unchanged, identical to the pre-schema behavior.
+func Normalize(sport string, raw map[string]interface{}, strict bool, logger *slog.Logger) map[string]interface{} {
+	s, ok := registry[sport]
+	if !ok {
+		return raw
+	}
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	out := make(map[string]interface{}, len(s.Fields))
+	seen := make(map[string]bool, len(raw))
+
+	for k, v := range raw {
+		if v == nil {
+			continue
+		}
+		canonical, ok := s.byAlias[k]
+		if !ok {
+			if strict {
+				logger.Warn("schema: dropping unrecognized stat key", "sport", sport, "key", k)
+				continue
+			}
+			out[k] = v
+			continue
+		}
+		field := s.byKey[canonical]
+		coerced, err := coerce(v, field.Kind)
+		if err != nil {
+			logger.Warn("schema: failed to coerce stat value, keeping raw", "sport", sport, "key", canonical, "error", err)
+			out[canonical] = v
+		} else {
+			out[canonical] = coerced
+		}
+		seen[canonical] = true
+	}
+
+	for _, f := range s.Fields {
+		if !seen[f.Key] {
+			out[f.Key] = nil
+		}
+	}
+	return out
+}
+
+// coerce converts v (as decoded from JSON) to kind, or returns an error if v
+// can't be sensibly represented as kind.
+func coerce(v interface{}, kind Kind) (interface{}, error) {
+	switch kind {
+	case KindInt:
+		switch n := v.(type) {
+		case float64:
+			return int64(n), nil
+		case int:
+			return int64(n), nil
+		case int64:
+			return n, nil
+		default:
+			return nil, errUnexpectedType(v, "int")
+		}
+	case KindFloat:
+		switch n := v.(type) {
+		case float64:
+			return n, nil
+		case int:
+			return float64(n), nil
+		case int64:
+			return float64(n), nil
+		default:
+			return nil, errUnexpectedType(v, "float")
+		}
+	case KindString:
+		if s, ok := v.(string); ok {
+			return s, nil
+		}
+		return nil, errUnexpectedType(v, "string")
+	default:
+		return v, nil
+	}
+}
+
+func errUnexpectedType(v interface{}, want string) error {
+	return fmt.Errorf("unexpected type %T for %s field", v, want)
+}
+
+// Validate checks stats against sport's schema without mutating it,
+// returning one problem string per unrecognized key or value that can't
+// coerce to its field's Kind. Unlike Normalize — which bdl's handlers call
+// up front to rename and coerce in one pass — Validate is meant for the
+// Upsert layer (see seed.UpsertPlayerStats/UpsertTeamStats), so stats that
+// reach Postgres via a path that never calls Normalize (sportmonks's
+// extractor pipeline doesn't yet) still gets flagged instead of silently
+// writing inconsistent JSONB. A sport with no registered schema always
+// returns nil, same as Normalize.
+func Validate(sport string, stats map[string]interface{}) []string {
+	s, ok := registry[sport]
+	if !ok {
+		return nil
+	}
+	var problems []string
+	for k, v := range stats {
+		if v == nil {
+			continue
+		}
+		canonical, ok := s.byAlias[k]
+		if !ok {
+			problems = append(problems, fmt.Sprintf("unrecognized key %q", k))
+			continue
+		}
+		if _, err := coerce(v, s.byKey[canonical].Kind); err != nil {
+			problems = append(problems, fmt.Sprintf("key %q: %v", k, err))
+		}
+	}
+	return problems
+}
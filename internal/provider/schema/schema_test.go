@@ -0,0 +1,98 @@
+package schema
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestValidateUnregisteredSport(t *testing.T) {
+	if problems := Validate("NOT_A_SPORT", map[string]interface{}{"foo": 1}); problems != nil {
+		t.Fatalf("want nil for an unregistered sport, got %v", problems)
+	}
+}
+
+func TestValidateNBA(t *testing.T) {
+	tests := []struct {
+		name  string
+		stats map[string]interface{}
+		want  []string
+	}{
+		{
+			name:  "all recognized and well-typed",
+			stats: map[string]interface{}{"gp": float64(10), "pts": float64(22.5), "min": "34:12"},
+			want:  nil,
+		},
+		{
+			name:  "unrecognized key",
+			stats: map[string]interface{}{"not_a_stat": float64(1)},
+			want:  []string{`unrecognized key "not_a_stat"`},
+		},
+		{
+			name:  "wrong type for a string field",
+			stats: map[string]interface{}{"min": float64(34)},
+			want:  []string{`key "min": unexpected type float64 for string field`},
+		},
+		{
+			name:  "nil values are skipped",
+			stats: map[string]interface{}{"pts": nil},
+			want:  nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Validate("NBA", tt.stats)
+			sort.Strings(got)
+			sort.Strings(tt.want)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Validate(%v) = %v, want %v", tt.stats, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeUnregisteredSport(t *testing.T) {
+	raw := map[string]interface{}{"foo": "bar"}
+	got := Normalize("NOT_A_SPORT", raw, true, nil)
+	if !reflect.DeepEqual(got, raw) {
+		t.Fatalf("want raw returned unchanged for an unregistered sport, got %v", got)
+	}
+}
+
+func TestNormalizeNBA(t *testing.T) {
+	raw := map[string]interface{}{
+		"gp":  float64(10), // alias for games_played
+		"tov": float64(3),  // alias for turnover
+		"pts": float64(20), // already canonical
+	}
+	got := Normalize("NBA", raw, true, nil)
+
+	if got["games_played"] != int64(10) {
+		t.Errorf("games_played = %v, want int64(10)", got["games_played"])
+	}
+	if got["turnover"] != float64(3) {
+		t.Errorf("turnover = %v, want float64(3)", got["turnover"])
+	}
+	if got["pts"] != float64(20) {
+		t.Errorf("pts = %v, want float64(20)", got["pts"])
+	}
+	// Declared fields raw didn't report are filled in as explicit nulls.
+	if v, ok := got["ast"]; !ok || v != nil {
+		t.Errorf("ast = %v, ok=%v, want nil, ok=true", v, ok)
+	}
+}
+
+func TestNormalizeStrictDropsUnrecognized(t *testing.T) {
+	raw := map[string]interface{}{"some_future_stat": float64(1)}
+
+	strict := Normalize("NBA", raw, true, nil)
+	if _, ok := strict["some_future_stat"]; ok {
+		t.Errorf("strict=true should drop unrecognized keys, got %v", strict)
+	}
+
+	lenient := Normalize("NBA", raw, false, nil)
+	if lenient["some_future_stat"] != float64(1) {
+		t.Errorf("strict=false should keep unrecognized keys verbatim, got %v", lenient)
+	}
+}
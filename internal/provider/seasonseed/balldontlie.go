@@ -0,0 +1,47 @@
+package seasonseed
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/albapepper/scoracle-data/internal/config"
+	"github.com/albapepper/scoracle-data/internal/provider/bdl"
+	"github.com/albapepper/scoracle-data/internal/seed"
+)
+
+func init() {
+	Register("balldontlie", func(cfg *config.Config, logger *slog.Logger) (SeasonSeeder, error) {
+		if cfg.BDLAPIKey == "" {
+			return nil, fmt.Errorf("BALLDONTLIE_API_KEY is required")
+		}
+		return &balldontlieSeeder{cfg: cfg, logger: logger}, nil
+	})
+}
+
+// balldontlieSeeder adapts seed.SeedNBA and seed.SeedNFL to the
+// SeasonSeeder contract; BallDontLie has no league concept, so LeagueID is
+// ignored for both.
+type balldontlieSeeder struct {
+	cfg    *config.Config
+	logger *slog.Logger
+}
+
+func (s *balldontlieSeeder) Capabilities() Capabilities {
+	return Capabilities{Sports: []string{"NBA", "NFL"}}
+}
+
+func (s *balldontlieSeeder) SeedSeason(ctx context.Context, pool *pgxpool.Pool, sport string, season int, opts Options) (seed.SeedResult, error) {
+	switch sport {
+	case "NBA":
+		handler := bdl.NewNBAHandler(s.cfg.BDLAPIKey, nil, s.logger)
+		return seed.SeedNBA(ctx, pool, handler, season, s.logger, opts.Checkpointer, opts.State, opts.DryRun), nil
+	case "NFL":
+		handler := bdl.NewNFLHandler(s.cfg.BDLAPIKey, nil, s.logger)
+		return seed.SeedNFL(ctx, pool, handler, season, s.logger, opts.Checkpointer, opts.State, opts.DryRun), nil
+	default:
+		return seed.SeedResult{}, fmt.Errorf("balldontlie does not support sport %q", sport)
+	}
+}
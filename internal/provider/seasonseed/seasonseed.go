@@ -0,0 +1,143 @@
+// Package seasonseed is a provider-keyed registry of full-season seeders —
+// one implementation per upstream vendor (balldontlie, sportmonks, ...),
+// each declaring which sports it knows how to seed. This is a different
+// axis from internal/provider/registry, which dispatches per-fixture
+// seeding by sport once a fixture's result is in; here the caller (the
+// `seed` CLI command) picks the provider explicitly, and the provider
+// reports back whether it actually supports the requested sport/league.
+package seasonseed
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"sync"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/albapepper/scoracle-data/internal/config"
+	"github.com/albapepper/scoracle-data/internal/seed"
+)
+
+// Capabilities describes what a SeasonSeeder supports.
+type Capabilities struct {
+	// Sports is every sport code this provider can seed (e.g. ["NBA",
+	// "NFL"] for balldontlie).
+	Sports []string
+	// NeedsLeague is true if SeedSeason requires opts.LeagueID to resolve
+	// a specific league-season (true for SportMonks football; BDL sports
+	// have no league concept).
+	NeedsLeague bool
+}
+
+// Supports reports whether sport is in c.Sports.
+func (c Capabilities) Supports(sport string) bool {
+	for _, s := range c.Sports {
+		if s == sport {
+			return true
+		}
+	}
+	return false
+}
+
+// Options bundles SeedSeason's run-scoped arguments: which league-season to
+// target, how to checkpoint progress, and whether to skip database writes.
+// Checkpointer and State mirror seed.SeedNBA/SeedFootballSeason's own
+// parameters — see seed.Checkpointer for what resuming a run actually does.
+type Options struct {
+	LeagueID     int
+	Checkpointer seed.Checkpointer
+	State        *seed.RunState
+	DryRun       bool
+}
+
+// SeasonSeeder runs a full-season seed for one sport against one upstream
+// provider.
+type SeasonSeeder interface {
+	SeedSeason(ctx context.Context, pool *pgxpool.Pool, sport string, season int, opts Options) (seed.SeedResult, error)
+	Capabilities() Capabilities
+}
+
+// Factory builds a SeasonSeeder from config, failing if the provider's
+// credentials aren't configured.
+type Factory func(cfg *config.Config, logger *slog.Logger) (SeasonSeeder, error)
+
+// Registry is a named map of provider factories. The zero value is not
+// usable; construct one with New (or use the package-level default
+// registry via Register/Build/Providers).
+type Registry struct {
+	mu        sync.RWMutex
+	factories map[string]Factory
+}
+
+// New returns an empty Registry.
+func New() *Registry {
+	return &Registry{factories: map[string]Factory{}}
+}
+
+// Register adds or replaces the factory for name (e.g. "balldontlie").
+func (r *Registry) Register(name string, f Factory) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.factories[name] = f
+}
+
+// Build looks up name and invokes its factory.
+func (r *Registry) Build(name string, cfg *config.Config, logger *slog.Logger) (SeasonSeeder, error) {
+	r.mu.RLock()
+	f, ok := r.factories[name]
+	r.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("provider %q is not registered (known: %s)", name, joinSorted(r.providerNames()))
+	}
+	return f(cfg, logger)
+}
+
+// Providers returns every registered provider name, sorted.
+func (r *Registry) Providers() []string {
+	return r.providerNames()
+}
+
+func (r *Registry) providerNames() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	names := make([]string, 0, len(r.factories))
+	for name := range r.factories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func joinSorted(names []string) string {
+	out := ""
+	for i, n := range names {
+		if i > 0 {
+			out += ", "
+		}
+		out += n
+	}
+	return out
+}
+
+// defaultRegistry is populated by each provider's init() (see
+// balldontlie.go, sportmonks.go), mirroring the self-registration pattern
+// in internal/sport and internal/provider/registry.
+var defaultRegistry = New()
+
+// Register adds a provider to the default registry.
+func Register(name string, f Factory) {
+	defaultRegistry.Register(name, f)
+}
+
+// Build builds a SeasonSeeder from the default registry.
+func Build(name string, cfg *config.Config, logger *slog.Logger) (SeasonSeeder, error) {
+	return defaultRegistry.Build(name, cfg, logger)
+}
+
+// Providers returns every provider name registered on the default registry,
+// sorted.
+func Providers() []string {
+	return defaultRegistry.Providers()
+}
@@ -0,0 +1,68 @@
+package seasonseed
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/albapepper/scoracle-data/internal/cache"
+	"github.com/albapepper/scoracle-data/internal/config"
+	"github.com/albapepper/scoracle-data/internal/provider/breaker"
+	"github.com/albapepper/scoracle-data/internal/provider/sportmonks"
+	"github.com/albapepper/scoracle-data/internal/seed"
+)
+
+func init() {
+	Register("sportmonks", func(cfg *config.Config, logger *slog.Logger) (SeasonSeeder, error) {
+		if cfg.SportMonksAPIToken == "" {
+			return nil, fmt.Errorf("SPORTMONKS_API_TOKEN is required")
+		}
+		return &sportmonksSeeder{cfg: cfg, logger: logger}, nil
+	})
+}
+
+// sportmonksSeeder adapts seed.SeedFootballSeason to the SeasonSeeder
+// contract.
+type sportmonksSeeder struct {
+	cfg    *config.Config
+	logger *slog.Logger
+}
+
+func (s *sportmonksSeeder) Capabilities() Capabilities {
+	return Capabilities{Sports: []string{"FOOTBALL"}, NeedsLeague: true}
+}
+
+func (s *sportmonksSeeder) SeedSeason(ctx context.Context, pool *pgxpool.Pool, sport string, season int, opts Options) (seed.SeedResult, error) {
+	if sport != "FOOTBALL" {
+		return seed.SeedResult{}, fmt.Errorf("sportmonks does not support sport %q", sport)
+	}
+	handler := sportmonks.NewFootballHandler(s.cfg.SportMonksAPIToken, cache.New(s.cfg), sportMonksLimiter(s.cfg, s.logger), s.logger)
+
+	smSeasonID, err := seed.ResolveProviderSeasonID(ctx, pool, opts.LeagueID, season)
+	if err != nil {
+		return seed.SeedResult{}, fmt.Errorf("resolve season: %w", err)
+	}
+	s.logger.Info("Resolved provider season", "league_id", opts.LeagueID, "season", season, "sm_season_id", smSeasonID)
+
+	return seed.SeedFootballSeason(ctx, pool, handler, smSeasonID, opts.LeagueID, season, opts.LeagueID, s.logger, opts.Checkpointer, opts.State, opts.DryRun), nil
+}
+
+// sportMonksLimiter builds the SportMonks rate limiter per config: a
+// Redis-backed limiter shared across every process (so a multi-pod ingest
+// deploy draws from one quota) when CacheBackend is "redis" and RedisURL is
+// reachable, or nil for the client's in-process default otherwise. Never
+// fails the caller — an unreachable Redis falls back to per-process
+// limiting rather than blocking the seed run.
+func sportMonksLimiter(cfg *config.Config, logger *slog.Logger) breaker.RateLimiter {
+	if cfg.CacheBackend != "redis" || cfg.RedisURL == "" {
+		return nil
+	}
+	limiter, err := breaker.NewRedisLimiter(cfg.RedisURL, cfg.SportMonksAPIToken, 300, logger)
+	if err != nil {
+		logger.Warn("sportmonks redis rate limiter unavailable, falling back to in-process limiting", "error", err)
+		return nil
+	}
+	return limiter
+}
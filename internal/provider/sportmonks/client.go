@@ -0,0 +1,420 @@
+// Package sportmonks provides the HTTP client for the SportMonks Football API.
+//
+// SportMonks uses token-based auth (query parameter), page-based pagination,
+// and nested include-based relationships.
+package sportmonks
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+
+	"github.com/albapepper/scoracle-data/internal/cache"
+	"github.com/albapepper/scoracle-data/internal/provider/breaker"
+)
+
+const baseURL = "https://api.sportmonks.com/v3/football"
+
+// Circuit breaker tuning: open after breakerFailureThreshold consecutive
+// 5xx/429 within breakerWindow, cool down for breakerCooldown before probing.
+// Shared with bdl.Client via the breaker package.
+const (
+	breakerFailureThreshold = 5
+	breakerWindow           = time.Minute
+	breakerCooldown         = 30 * time.Second
+)
+
+// ErrCircuitOpen indicates the SportMonks circuit breaker is open and
+// rejecting calls after repeated upstream failures.
+var ErrCircuitOpen = errors.New("sportmonks: circuit breaker open")
+
+// ErrNotFound indicates the requested resource doesn't exist upstream. Also
+// returned for a cached negative result — see negativeCacheTTL.
+var ErrNotFound = errors.New("sportmonks: not found")
+
+// negativeCacheTTL bounds how long a 404 is cached, so a player/team ID that
+// genuinely doesn't exist stops costing a round trip on every re-seed
+// without permanently hiding it if SportMonks later backfills the record.
+const negativeCacheTTL = 10 * time.Minute
+
+// Retry tuning for 429/5xx/network errors: a handful of decorrelated-jitter
+// backoff retries within the same call, rather than surfacing the error up
+// to the caller immediately — the upstream budget or a transient 5xx
+// usually clears within a few seconds. These are the defaults NewClient
+// falls back to when maxRetries/maxBackoff is <= 0; see its doc comment.
+const (
+	defaultMaxRetries  = 3
+	rateLimitBaseDelay = 1 * time.Second
+	defaultMaxBackoff  = 30 * time.Second
+)
+
+// Client is the HTTP client for SportMonks Football endpoints.
+type Client struct {
+	httpClient *http.Client
+	apiToken   string
+	limiters   breaker.RateLimiter
+	cb         *breaker.Breaker
+	logger     *slog.Logger
+
+	cache  cache.Cache
+	sf     singleflight.Group
+	hits   int64
+	misses int64
+
+	maxRetries int
+	maxBackoff time.Duration
+}
+
+// NewClient creates a SportMonks HTTP client with a circuit breaker
+// guarding against a flaky endpoint burning the whole requests-per-minute
+// budget. respCache backs get/getPaginated's response cache — pass nil to
+// disable caching (every call goes upstream). limiter rate-limits requests,
+// keyed by classify(path); pass nil for the in-process default (per-process
+// limiting only — see breaker.RedisLimiter for a distributed alternative
+// shared across every pod hitting the same SportMonks token). maxRetries
+// and maxBackoff tune how hard get retries a 429/5xx/network error before
+// giving up (see fetch) — pass <= 0 for either to use the package defaults.
+func NewClient(apiToken string, requestsPerMinute int, logger *slog.Logger, respCache cache.Cache, limiter breaker.RateLimiter, maxRetries int, maxBackoff time.Duration) *Client {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	if limiter == nil {
+		limiter = breaker.NewLimiters(float64(requestsPerMinute) / 60.0)
+	}
+	if maxRetries <= 0 {
+		maxRetries = defaultMaxRetries
+	}
+	if maxBackoff <= 0 {
+		maxBackoff = defaultMaxBackoff
+	}
+	return &Client{
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+		apiToken:   apiToken,
+		limiters:   limiter,
+		cb:         breaker.New(breakerFailureThreshold, breakerWindow, breakerCooldown),
+		logger:     logger,
+		cache:      respCache,
+		maxRetries: maxRetries,
+		maxBackoff: maxBackoff,
+	}
+}
+
+// classify groups a SportMonks path into its rate-limit tier. Per-entity
+// endpoints are called once per squad member (potentially thousands of
+// distinct /players/{id} paths per season) — keying the limiter by the
+// literal path would give every one of those its own fresh, effectively
+// unthrottled bucket, so related endpoints share one tier instead. This
+// also keeps a hot /players tier from starving /standings, which every
+// fixture seed also needs.
+func classify(path string) string {
+	for _, tier := range []string{"/players/", "/teams/", "/squads/", "/standings/", "/leagues/"} {
+		if strings.HasPrefix(path, tier) {
+			return strings.Trim(tier, "/")
+		}
+	}
+	return path
+}
+
+// paginatedResponse is the common SportMonks response wrapper.
+type paginatedResponse struct {
+	Data       json.RawMessage `json:"data"`
+	Pagination *struct {
+		HasMore bool `json:"has_more"`
+	} `json:"pagination"`
+}
+
+// negativeSentinel marks a cached 404, distinguishing it from an actual
+// cached paginatedResponse payload.
+var negativeSentinel = []byte("\x00not-found")
+
+// get performs a rate-limited, circuit-breaker-guarded GET request to a
+// SportMonks endpoint, serving from cache when a prior call already fetched
+// path+params within ttl. Concurrent callers requesting the same path+params
+// while it's uncached collapse into a single upstream call via singleflight.
+// Each path also gets its own rate limiter, adjusted from Retry-After /
+// X-RateLimit-* response headers. Pass ttl<=0 to skip caching the result.
+func (c *Client) get(ctx context.Context, path string, params url.Values, ttl time.Duration) (*paginatedResponse, error) {
+	if params == nil {
+		params = url.Values{}
+	}
+	key := cacheKeyFor(path, params)
+
+	if c.cache != nil {
+		if data, _, ok := c.cache.Get(key); ok {
+			atomic.AddInt64(&c.hits, 1)
+			if string(data) == string(negativeSentinel) {
+				return nil, fmt.Errorf("sportmonks %s: %w", path, ErrNotFound)
+			}
+			var cached paginatedResponse
+			if err := json.Unmarshal(data, &cached); err == nil {
+				return &cached, nil
+			}
+		} else {
+			atomic.AddInt64(&c.misses, 1)
+		}
+	}
+
+	v, err, _ := c.sf.Do(key, func() (interface{}, error) {
+		return c.fetch(ctx, path, params, ttl, key)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.(*paginatedResponse), nil
+}
+
+// retryableError wraps a transient failure (429, 5xx, or a network error)
+// that fetch's retry loop should back off and retry, carrying the
+// upstream's requested Retry-After when the response included one.
+type retryableError struct {
+	err        error
+	retryAfter time.Duration // 0 when not signaled by the response
+}
+
+func (e *retryableError) Error() string { return e.err.Error() }
+func (e *retryableError) Unwrap() error { return e.err }
+
+// fetch wraps fetchOnce with a retry loop for 429/5xx/network errors,
+// honoring Retry-After when the response carries one and falling back to
+// decorrelated-jitter exponential backoff otherwise — so many pods hitting
+// the same 429 or transient outage don't all retry in lockstep. Gives up
+// after c.maxRetries and returns the last error.
+func (c *Client) fetch(ctx context.Context, path string, params url.Values, ttl time.Duration, key string) (*paginatedResponse, error) {
+	var lastErr error
+	delay := rateLimitBaseDelay
+	for attempt := 0; attempt <= c.maxRetries; attempt++ {
+		result, err := c.fetchOnce(ctx, path, params, ttl, key)
+		var re *retryableError
+		if !errors.As(err, &re) {
+			return result, err
+		}
+		lastErr = err
+		if attempt == c.maxRetries {
+			break
+		}
+
+		wait := re.retryAfter
+		if wait <= 0 {
+			delay = decorrelatedBackoff(delay, rateLimitBaseDelay, c.maxBackoff)
+			wait = delay
+		} else if wait > c.maxBackoff {
+			wait = c.maxBackoff
+		}
+		c.logger.Warn("sportmonks request failed, retrying", "path", path, "attempt", attempt+1, "delay", wait, "error", re.err)
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+	return nil, lastErr
+}
+
+// decorrelatedBackoff computes the next retry delay per AWS's decorrelated
+// jitter algorithm: a random duration between base and 3x the previous
+// delay, capped at maxBackoff. This spreads retries out more than a plain
+// exponential-with-jitter backoff, which matters when many seed workers hit
+// the same transient outage at once.
+func decorrelatedBackoff(prev, base, maxBackoff time.Duration) time.Duration {
+	if prev < base {
+		prev = base
+	}
+	upper := prev * 3
+	if upper > maxBackoff {
+		upper = maxBackoff
+	}
+	if upper <= base {
+		return base
+	}
+	return base + time.Duration(rand.Int63n(int64(upper-base)))
+}
+
+// retryAfterDuration parses a Retry-After header, accepting both the
+// delay-seconds form and the HTTP-date form, or 0 if absent/invalid/past.
+func retryAfterDuration(header http.Header) time.Duration {
+	ra := header.Get("Retry-After")
+	if ra == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(ra); err == nil {
+		if secs <= 0 {
+			return 0
+		}
+		return time.Duration(secs) * time.Second
+	}
+	if t, err := http.ParseTime(ra); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
+
+// fetchOnce performs a single upstream GET for get, bypassing the cache read
+// (the caller already checked it) but populating the cache — including a
+// short-lived negative entry for a 404 — on a successful round trip.
+func (c *Client) fetchOnce(ctx context.Context, path string, params url.Values, ttl time.Duration, key string) (*paginatedResponse, error) {
+	if !c.cb.Allow() {
+		return nil, fmt.Errorf("sportmonks %s: %w", path, ErrCircuitOpen)
+	}
+
+	class := classify(path)
+	if err := c.limiters.Wait(ctx, class); err != nil {
+		return nil, fmt.Errorf("rate limit wait: %w", err)
+	}
+
+	reqParams := cloneValues(params)
+	reqParams.Set("api_token", c.apiToken)
+
+	u := baseURL + path + "?" + reqParams.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, &retryableError{err: fmt.Errorf("http request %s: %w", path, err)}
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read response body: %w", err)
+	}
+
+	c.limiters.AdjustFromHeaders(class, resp.Header)
+
+	if resp.StatusCode == http.StatusNotFound {
+		if c.cache != nil {
+			c.cache.Set(key, negativeSentinel, negativeCacheTTL)
+		}
+		return nil, fmt.Errorf("sportmonks %s: %w", path, ErrNotFound)
+	}
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		c.cb.RecordFailure()
+		return nil, &retryableError{
+			err:        fmt.Errorf("sportmonks %s: rate limited (429)", path),
+			retryAfter: retryAfterDuration(resp.Header),
+		}
+	}
+
+	if resp.StatusCode >= 500 {
+		c.cb.RecordFailure()
+		return nil, &retryableError{
+			err:        fmt.Errorf("sportmonks %s returned %d: %s", path, resp.StatusCode, truncate(body, 200)),
+			retryAfter: retryAfterDuration(resp.Header),
+		}
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("SportMonks %s returned %d: %s", path, resp.StatusCode, truncate(body, 200))
+	}
+	c.cb.RecordSuccess()
+
+	var result paginatedResponse
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("decode response: %w", err)
+	}
+
+	if c.cache != nil && ttl > 0 {
+		if encoded, err := json.Marshal(result); err == nil {
+			c.cache.Set(key, encoded, ttl)
+		}
+	}
+
+	return &result, nil
+}
+
+// cacheKeyFor derives a cache key from path+params, hashed so the key is
+// fixed-length and never includes the api_token (added later, in fetch).
+func cacheKeyFor(path string, params url.Values) string {
+	sum := sha256.Sum256([]byte(path + "?" + params.Encode()))
+	return fmt.Sprintf("sportmonks:%x", sum)
+}
+
+// cloneValues copies params so fetch's api_token/per_page/page mutations
+// never leak back into the caller's url.Values (getPaginated reuses the
+// same one across pages).
+func cloneValues(v url.Values) url.Values {
+	clone := make(url.Values, len(v))
+	for k, vals := range v {
+		clone[k] = append([]string(nil), vals...)
+	}
+	return clone
+}
+
+// Stats returns cache hit/miss counts plus the underlying cache backend's
+// own diagnostics, when caching is enabled.
+func (c *Client) Stats() map[string]interface{} {
+	stats := map[string]interface{}{
+		"cache_hits":   atomic.LoadInt64(&c.hits),
+		"cache_misses": atomic.LoadInt64(&c.misses),
+	}
+	if c.cache != nil {
+		stats["cache_backend"] = c.cache.Stats()
+	}
+	return stats
+}
+
+// getPaginated fetches all pages from a paginated endpoint.
+func (c *Client) getPaginated(ctx context.Context, path string, params url.Values, perPage int, ttl time.Duration) ([]json.RawMessage, error) {
+	if params == nil {
+		params = url.Values{}
+	}
+	params.Set("per_page", strconv.Itoa(perPage))
+
+	var allData []json.RawMessage
+	page := 1
+
+	for {
+		params.Set("page", strconv.Itoa(page))
+		resp, err := c.get(ctx, path, params, ttl)
+		if err != nil {
+			return nil, err
+		}
+
+		// Data can be array or object
+		var items []json.RawMessage
+		if err := json.Unmarshal(resp.Data, &items); err != nil {
+			// Single item response
+			allData = append(allData, resp.Data)
+			break
+		}
+
+		allData = append(allData, items...)
+
+		if resp.Pagination == nil || !resp.Pagination.HasMore {
+			break
+		}
+		page++
+	}
+
+	return allData, nil
+}
+
+// truncate returns a truncated string for error messages.
+func truncate(b []byte, maxLen int) string {
+	if len(b) <= maxLen {
+		return string(b)
+	}
+	return string(b[:maxLen]) + "..."
+}
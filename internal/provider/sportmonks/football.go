@@ -0,0 +1,696 @@
+package sportmonks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/albapepper/scoracle-data/internal/cache"
+	"github.com/albapepper/scoracle-data/internal/provider"
+	"github.com/albapepper/scoracle-data/internal/provider/breaker"
+)
+
+// Cache TTLs for SportMonks responses, chosen per endpoint's actual rate of
+// change: season/squad composition is essentially static once a season
+// starts, a player's in-season stats shift after every match they play, and
+// standings shift after every fixture in the league.
+const (
+	ttlSeasons   = 24 * time.Hour
+	ttlPlayers   = 1 * time.Hour
+	ttlStandings = 10 * time.Minute
+)
+
+// Observer receives structured progress events from GetPlayersWithStats, so
+// a caller running a long backfill can surface progress (e.g. an admin
+// dashboard) without GetPlayersWithStats depending on any particular UI.
+// Implementations must be safe for concurrent use — events for different
+// players within the same team fire from concurrent fetch goroutines.
+type Observer interface {
+	// TeamDone fires once a team's squad has been fully processed.
+	TeamDone(teamID int, playerCount int)
+	// PlayerTimedOut fires when a player's fetch exhausted its retries
+	// without completing within playerFetchTimeout.
+	PlayerTimedOut(playerID int, teamID int)
+	// PlayerRetried fires before each retry of a player's fetch, attempt
+	// being the 1-based retry number.
+	PlayerRetried(playerID int, teamID int, attempt int)
+}
+
+type noopObserver struct{}
+
+func (noopObserver) TeamDone(int, int)           {}
+func (noopObserver) PlayerTimedOut(int, int)     {}
+func (noopObserver) PlayerRetried(int, int, int) {}
+
+// defaultPlayerConcurrency bounds how many /players/{id} requests a single
+// team's squad fans out at once; override with SetPlayerConcurrency.
+const defaultPlayerConcurrency = 8
+
+// playerFetchTimeout bounds a single player's fetch so one slow lookup can
+// be abandoned without stalling the rest of the squad.
+const playerFetchTimeout = 15 * time.Second
+
+// playerFetchMaxRetries bounds how many times a timed-out player fetch is
+// retried before PlayerTimedOut fires and it's skipped.
+const playerFetchMaxRetries = 1
+
+// FootballHandler fetches and normalizes Football data from SportMonks.
+type FootballHandler struct {
+	client            *Client
+	logger            *slog.Logger
+	observer          Observer
+	playerConcurrency int
+	playerExtractor   *provider.Extractor
+	teamExtractor     *provider.Extractor
+}
+
+// NewFootballHandler creates a Football handler. respCache backs the
+// client's response cache (see Client.get) — pass nil to disable caching.
+// limiter rate-limits the client's requests — pass nil for the in-process
+// default, or a breaker.RedisLimiter to share the budget across every pod
+// hitting the same SportMonks token.
+func NewFootballHandler(apiToken string, respCache cache.Cache, limiter breaker.RateLimiter, logger *slog.Logger) *FootballHandler {
+	return &FootballHandler{
+		client:            NewClient(apiToken, 300, logger, respCache, limiter, 0, 0),
+		logger:            logger,
+		observer:          noopObserver{},
+		playerConcurrency: defaultPlayerConcurrency,
+	}
+}
+
+// SetObserver installs obs to receive GetPlayersWithStats progress events.
+// Pass nil to go back to a no-op observer.
+func (h *FootballHandler) SetObserver(obs Observer) {
+	if obs == nil {
+		obs = noopObserver{}
+	}
+	h.observer = obs
+}
+
+// SetPlayerExtractor installs ex to resolve player stat values, overriding
+// the default $.total || $.all || $.count || $.average chain per key (see
+// provider.Extractor). Pass nil to go back to the default for every key.
+func (h *FootballHandler) SetPlayerExtractor(ex *provider.Extractor) {
+	h.playerExtractor = ex
+}
+
+// SetTeamExtractor is SetPlayerExtractor for standings/team stat values.
+func (h *FootballHandler) SetTeamExtractor(ex *provider.Extractor) {
+	h.teamExtractor = ex
+}
+
+// SetPlayerConcurrency overrides how many /players/{id} requests a single
+// team's squad fans out at once. n <= 0 resets to defaultPlayerConcurrency.
+func (h *FootballHandler) SetPlayerConcurrency(n int) {
+	if n <= 0 {
+		n = defaultPlayerConcurrency
+	}
+	h.playerConcurrency = n
+}
+
+// Stats returns the underlying client's cache hit/miss counts and backend
+// diagnostics.
+func (h *FootballHandler) Stats() map[string]interface{} {
+	return h.client.Stats()
+}
+
+// --------------------------------------------------------------------------
+// Code override maps — SportMonks codes that don't match our canonical keys
+// after simple hyphen-to-underscore replacement.
+// --------------------------------------------------------------------------
+
+var playerCodeOverrides = map[string]string{
+	"passes":              "passes_total",
+	"accurate-passes":     "passes_accurate",
+	"total-crosses":       "crosses_total",
+	"accurate-crosses":    "crosses_accurate",
+	"blocked-shots":       "blocks",
+	"total-duels":         "duels_total",
+	"dribble-attempts":    "dribbles_attempts",
+	"successful-dribbles": "dribbles_success",
+	"yellowcards":         "yellow_cards",
+	"redcards":            "red_cards",
+	"fouls":               "fouls_committed",
+	"expected-goals":      "expected_goals",
+}
+
+var standingCodeOverrides = map[string]string{
+	"overall-matches-played": "matches_played",
+	"overall-won":            "wins",
+	"overall-draw":           "draws",
+	"overall-lost":           "losses",
+	"overall-goals-for":      "goals_for",
+	"overall-goals-against":  "goals_against",
+	"home-matches-played":    "home_played",
+	"away-matches-played":    "away_played",
+}
+
+func normalizeCode(code string, overrides map[string]string) string {
+	if mapped, ok := overrides[code]; ok {
+		return mapped
+	}
+	return strings.ReplaceAll(code, "-", "_")
+}
+
+// --------------------------------------------------------------------------
+// Seasons
+// --------------------------------------------------------------------------
+
+type smSeason struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// DiscoverSeasonIDs maps target years to SportMonks season IDs for a league.
+func (h *FootballHandler) DiscoverSeasonIDs(ctx context.Context, leagueID int, targetYears []int) (map[int]int, error) {
+	resp, err := h.client.get(ctx, fmt.Sprintf("/leagues/%d", leagueID), url.Values{
+		"include": {"seasons"},
+	}, ttlSeasons)
+	if err != nil {
+		return nil, fmt.Errorf("fetch league seasons: %w", err)
+	}
+
+	var leagueData struct {
+		Seasons []smSeason `json:"seasons"`
+	}
+	if err := json.Unmarshal(resp.Data, &leagueData); err != nil {
+		return nil, fmt.Errorf("decode league seasons: %w", err)
+	}
+
+	// Build target year set
+	targetSet := make(map[int]bool, len(targetYears))
+	for _, y := range targetYears {
+		targetSet[y] = true
+	}
+
+	result := make(map[int]int)
+	for _, season := range leagueData.Seasons {
+		parts := strings.Split(season.Name, "/")
+		startYearStr := strings.TrimSpace(parts[0])
+		startYear, err := strconv.Atoi(startYearStr)
+		if err != nil {
+			continue
+		}
+		if targetSet[startYear] {
+			if _, exists := result[startYear]; !exists {
+				result[startYear] = season.ID
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// --------------------------------------------------------------------------
+// Teams
+// --------------------------------------------------------------------------
+
+type smTeamRaw struct {
+	ID        int    `json:"id"`
+	Name      string `json:"name"`
+	ShortCode string `json:"short_code"`
+	Founded   *int   `json:"founded"`
+	ImagePath string `json:"image_path"`
+	Country   *struct {
+		Name string `json:"name"`
+	} `json:"country"`
+	Venue *struct {
+		Name     string `json:"name"`
+		Capacity *int   `json:"capacity"`
+		City     string `json:"city"`
+		Surface  string `json:"surface"`
+	} `json:"venue"`
+}
+
+// GetTeams fetches all teams for a season in canonical format.
+func (h *FootballHandler) GetTeams(ctx context.Context, seasonID int) ([]provider.Team, error) {
+	rawItems, err := h.client.getPaginated(ctx,
+		fmt.Sprintf("/teams/seasons/%d", seasonID),
+		url.Values{"include": {"venue;country"}}, 50, ttlSeasons)
+	if err != nil {
+		return nil, fmt.Errorf("fetch football teams: %w", err)
+	}
+
+	teams := make([]provider.Team, 0, len(rawItems))
+	for _, raw := range rawItems {
+		var t smTeamRaw
+		if err := json.Unmarshal(raw, &t); err != nil {
+			h.logger.Warn("decode team", "error", err)
+			continue
+		}
+		teams = append(teams, normalizeTeam(t))
+	}
+	return teams, nil
+}
+
+func normalizeTeam(raw smTeamRaw) provider.Team {
+	team := provider.Team{
+		ID:        raw.ID,
+		Name:      raw.Name,
+		ShortCode: raw.ShortCode,
+		LogoURL:   raw.ImagePath,
+		Founded:   raw.Founded,
+		Meta:      make(map[string]interface{}),
+	}
+
+	if raw.Country != nil {
+		team.Country = raw.Country.Name
+	}
+	if raw.Venue != nil {
+		team.VenueName = raw.Venue.Name
+		team.VenueCapacity = raw.Venue.Capacity
+		if raw.Venue.City != "" {
+			team.Meta["venue_city"] = raw.Venue.City
+		}
+		if raw.Venue.Surface != "" {
+			team.Meta["venue_surface"] = raw.Venue.Surface
+		}
+	}
+
+	return team
+}
+
+// --------------------------------------------------------------------------
+// Players + Stats (fetched together via squad iteration)
+// --------------------------------------------------------------------------
+
+type smPlayerRaw struct {
+	ID               int         `json:"id"`
+	Firstname        string      `json:"firstname"`
+	Lastname         string      `json:"lastname"`
+	DisplayName      string      `json:"display_name"`
+	PositionID       *int        `json:"position_id"`
+	Position         interface{} `json:"position"`
+	DateOfBirth      string      `json:"date_of_birth"`
+	Height           *float64    `json:"height"` // cm
+	Weight           *float64    `json:"weight"` // kg
+	ImagePath        string      `json:"image_path"`
+	Nationality      interface{} `json:"nationality"` // string or object
+	DetailedPosition interface{} `json:"detailedposition"`
+	Statistics       []struct {
+		Details []struct {
+			Type  *struct{ Code string } `json:"type"`
+			Value interface{}            `json:"value"`
+		} `json:"details"`
+		Season *struct {
+			League *struct{ ID int } `json:"league"`
+		} `json:"season"`
+	} `json:"statistics"`
+}
+
+// GetPlayersWithStats iterates squads sequentially, fans each squad's
+// /players/{id} fetches out across a bounded worker pool, and calls fn once
+// per player in squad order — fn itself still runs strictly serially (never
+// from more than one goroutine, never out of order), so callers whose fn
+// mutates shared state (internal/seed, registry.FootballSeeder) don't need
+// their own locking even though the underlying fetches run concurrently.
+func (h *FootballHandler) GetPlayersWithStats(ctx context.Context, seasonID int, teamIDs []int, smLeagueID int, fn func(provider.PlayerStats) error) error {
+	for i, teamID := range teamIDs {
+		h.logger.Info("Fetching squad", "team_id", teamID, "progress", fmt.Sprintf("%d/%d", i+1, len(teamIDs)))
+
+		resp, err := h.client.get(ctx,
+			fmt.Sprintf("/squads/seasons/%d/teams/%d", seasonID, teamID), nil, ttlSeasons)
+		if err != nil {
+			h.logger.Warn("squad fetch failed", "team_id", teamID, "error", err)
+			continue
+		}
+
+		var squad []struct {
+			PlayerID int `json:"player_id"`
+			ID       int `json:"id"`
+		}
+		if err := json.Unmarshal(resp.Data, &squad); err != nil {
+			h.logger.Warn("squad decode failed", "team_id", teamID, "error", err)
+			continue
+		}
+
+		playerIDs := make([]int, 0, len(squad))
+		for _, entry := range squad {
+			pid := entry.PlayerID
+			if pid == 0 {
+				pid = entry.ID
+			}
+			if pid != 0 {
+				playerIDs = append(playerIDs, pid)
+			}
+		}
+
+		if err := h.fetchTeamPlayers(ctx, seasonID, teamID, smLeagueID, playerIDs, fn); err != nil {
+			return err
+		}
+		h.observer.TeamDone(teamID, len(playerIDs))
+	}
+	return nil
+}
+
+// playerFetchResult is what fetchOnePlayer reports back for one sequence
+// slot: ok is false when the player should be skipped (fetch failed or
+// exhausted its retries).
+type playerFetchResult struct {
+	player provider.PlayerStats
+	ok     bool
+}
+
+// fetchTeamPlayers fetches playerIDs' stats with up to h.playerConcurrency
+// fetches in flight at once, but delivers them to fn one at a time in
+// playerIDs' original order: each sequence index gets its own buffered
+// result channel, so a worker can finish (and block trying to send) without
+// waiting for earlier, still-in-flight players, while the single consumer
+// loop below only ever advances to index i+1 once index i's channel has
+// produced a value.
+func (h *FootballHandler) fetchTeamPlayers(ctx context.Context, seasonID, teamID, smLeagueID int, playerIDs []int, fn func(provider.PlayerStats) error) error {
+	results := make([]chan playerFetchResult, len(playerIDs))
+	for i := range results {
+		results[i] = make(chan playerFetchResult, 1)
+	}
+
+	sem := make(chan struct{}, h.playerConcurrency)
+	var wg sync.WaitGroup
+	for i, pid := range playerIDs {
+		wg.Add(1)
+		go func(i, pid int) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			player, ok := h.fetchOnePlayer(ctx, seasonID, teamID, smLeagueID, pid)
+			results[i] <- playerFetchResult{player: player, ok: ok}
+		}(i, pid)
+	}
+	go func() { wg.Wait() }()
+
+	for i := range playerIDs {
+		r := <-results[i]
+		if !r.ok {
+			continue
+		}
+		if err := fn(r.player); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fetchOnePlayer fetches and normalizes a single player's stats under its
+// own deadline, retrying up to playerFetchMaxRetries times on timeout before
+// reporting it to the Observer and giving up.
+func (h *FootballHandler) fetchOnePlayer(ctx context.Context, seasonID, teamID, smLeagueID, pid int) (provider.PlayerStats, bool) {
+	for attempt := 0; attempt <= playerFetchMaxRetries; attempt++ {
+		if attempt > 0 {
+			h.observer.PlayerRetried(pid, teamID, attempt)
+		}
+
+		fetchCtx, cancel := context.WithTimeout(ctx, playerFetchTimeout)
+		playerResp, err := h.client.get(fetchCtx, fmt.Sprintf("/players/%d", pid), url.Values{
+			"include": {"statistics.details.type;statistics.season.league;nationality;detailedPosition"},
+			"filters": {fmt.Sprintf("playerStatisticSeasons:%d", seasonID)},
+		}, ttlPlayers)
+		cancel()
+
+		if err != nil {
+			if errors.Is(err, context.DeadlineExceeded) {
+				if attempt < playerFetchMaxRetries {
+					continue
+				}
+				h.observer.PlayerTimedOut(pid, teamID)
+				return provider.PlayerStats{}, false
+			}
+			h.logger.Warn("player fetch failed", "player_id", pid, "error", err)
+			return provider.PlayerStats{}, false
+		}
+
+		var playerData smPlayerRaw
+		if err := json.Unmarshal(playerResp.Data, &playerData); err != nil {
+			h.logger.Warn("player decode failed", "player_id", pid, "error", err)
+			return provider.PlayerStats{}, false
+		}
+
+		stats := extractLeagueStats(playerData.Statistics, smLeagueID, h.playerExtractor)
+		player := normalizePlayer(playerData)
+		rawJSON, _ := json.Marshal(playerData)
+
+		return provider.PlayerStats{
+			PlayerID: playerData.ID,
+			TeamID:   &teamID,
+			Player:   &player,
+			Stats:    stats,
+			Raw:      rawJSON,
+		}, true
+	}
+	return provider.PlayerStats{}, false
+}
+
+func extractLeagueStats(statistics []struct {
+	Details []struct {
+		Type  *struct{ Code string } `json:"type"`
+		Value interface{}            `json:"value"`
+	} `json:"details"`
+	Season *struct {
+		League *struct{ ID int } `json:"league"`
+	} `json:"season"`
+}, smLeagueID int, extractor *provider.Extractor) map[string]interface{} {
+	for _, block := range statistics {
+		if block.Season == nil || block.Season.League == nil {
+			continue
+		}
+		if block.Season.League.ID == smLeagueID {
+			return normalizePlayerStats(block.Details, extractor)
+		}
+	}
+	return map[string]interface{}{}
+}
+
+func normalizePlayerStats(details []struct {
+	Type  *struct{ Code string } `json:"type"`
+	Value interface{}            `json:"value"`
+}, extractor *provider.Extractor) map[string]interface{} {
+	stats := make(map[string]interface{})
+	for _, detail := range details {
+		if detail.Type == nil || detail.Type.Code == "" {
+			continue
+		}
+		key := normalizeCode(detail.Type.Code, playerCodeOverrides)
+		if val, ok := extractor.Extract(key, detail.Value); ok {
+			stats[key] = val
+		}
+	}
+	return stats
+}
+
+func normalizePlayer(raw smPlayerRaw) provider.Player {
+	name := raw.DisplayName
+	if name == "" {
+		name = strings.TrimSpace(raw.Firstname + " " + raw.Lastname)
+	}
+	if name == "" {
+		name = fmt.Sprintf("Player %d", raw.ID)
+	}
+
+	// Position from position_id
+	var position string
+	switch v := raw.Position.(type) {
+	case string:
+		position = v
+	}
+	if position == "" && raw.PositionID != nil {
+		posMap := map[int]string{24: "Goalkeeper", 25: "Defender", 26: "Midfielder", 27: "Attacker"}
+		position = posMap[*raw.PositionID]
+	}
+
+	// Detailed position
+	var detailedPosition string
+	switch v := raw.DetailedPosition.(type) {
+	case map[string]interface{}:
+		if n, ok := v["name"].(string); ok {
+			detailedPosition = n
+		}
+	case string:
+		detailedPosition = v
+	}
+
+	// Nationality
+	var nationality string
+	switch v := raw.Nationality.(type) {
+	case map[string]interface{}:
+		if n, ok := v["name"].(string); ok {
+			nationality = n
+		}
+	case string:
+		nationality = v
+	}
+
+	// Height: cm -> feet-inches
+	var height string
+	if raw.Height != nil && *raw.Height > 0 {
+		height = cmToFeetInches(*raw.Height)
+	}
+
+	// Weight: kg -> lbs
+	var weight string
+	if raw.Weight != nil && *raw.Weight > 0 {
+		weight = strconv.Itoa(int(math.Round(*raw.Weight * 2.20462)))
+	}
+
+	meta := make(map[string]interface{})
+	if raw.DisplayName != "" {
+		meta["display_name"] = raw.DisplayName
+	}
+	if raw.PositionID != nil {
+		meta["position_id"] = *raw.PositionID
+	}
+
+	return provider.Player{
+		ID:               raw.ID,
+		Name:             name,
+		FirstName:        raw.Firstname,
+		LastName:         raw.Lastname,
+		Position:         position,
+		DetailedPosition: detailedPosition,
+		Nationality:      nationality,
+		Height:           height,
+		Weight:           weight,
+		DateOfBirth:      raw.DateOfBirth,
+		PhotoURL:         raw.ImagePath,
+		Meta:             meta,
+	}
+}
+
+func cmToFeetInches(cm float64) string {
+	totalInches := cm / 2.54
+	if totalInches <= 0 {
+		return ""
+	}
+	feet := int(totalInches / 12)
+	inches := int(math.Round(math.Mod(totalInches, 12)))
+	if inches == 12 {
+		feet++
+		inches = 0
+	}
+	return fmt.Sprintf("%d-%d", feet, inches)
+}
+
+// --------------------------------------------------------------------------
+// Team Stats (Standings)
+// --------------------------------------------------------------------------
+
+type smStandingRaw struct {
+	ParticipantID int             `json:"participant_id"`
+	Participant   json.RawMessage `json:"participant"`
+	Points        *int            `json:"points"`
+	Position      *int            `json:"position"`
+	Form          string          `json:"form"`
+	Details       []struct {
+		Type  *struct{ Code string } `json:"type"`
+		Value interface{}            `json:"value"`
+	} `json:"details"`
+}
+
+// GetTeamStats fetches standings for a season in canonical format.
+func (h *FootballHandler) GetTeamStats(ctx context.Context, seasonID int) ([]provider.TeamStats, error) {
+	resp, err := h.client.get(ctx,
+		fmt.Sprintf("/standings/seasons/%d", seasonID),
+		url.Values{"include": {"participant;details.type"}}, ttlStandings)
+	if err != nil {
+		return nil, fmt.Errorf("fetch football standings: %w", err)
+	}
+
+	var raw []smStandingRaw
+	if err := json.Unmarshal(resp.Data, &raw); err != nil {
+		return nil, fmt.Errorf("decode standings: %w", err)
+	}
+
+	result := make([]provider.TeamStats, 0, len(raw))
+	for _, standing := range raw {
+		ts := normalizeStanding(standing, h.teamExtractor)
+		result = append(result, ts)
+	}
+
+	// Sort by position
+	sort.Slice(result, func(i, j int) bool {
+		pi, _ := result[i].Stats["position"].(float64)
+		pj, _ := result[j].Stats["position"].(float64)
+		return pi < pj
+	})
+
+	return result, nil
+}
+
+// GetTeamStatsByTeams fetches standings for a season and filters the result
+// to the given team IDs. The standings endpoint has no server-side team
+// filter, so this restricts client-side — used for per-fixture targeted
+// seeding where only the two participating teams matter.
+func (h *FootballHandler) GetTeamStatsByTeams(ctx context.Context, seasonID int, teamIDs []int) ([]provider.TeamStats, error) {
+	all, err := h.GetTeamStats(ctx, seasonID)
+	if err != nil {
+		return nil, err
+	}
+
+	wanted := make(map[int]bool, len(teamIDs))
+	for _, id := range teamIDs {
+		wanted[id] = true
+	}
+
+	filtered := make([]provider.TeamStats, 0, len(teamIDs))
+	for _, ts := range all {
+		if wanted[ts.TeamID] {
+			filtered = append(filtered, ts)
+		}
+	}
+	return filtered, nil
+}
+
+func normalizeStanding(raw smStandingRaw, extractor *provider.Extractor) provider.TeamStats {
+	stats := make(map[string]interface{})
+
+	for _, detail := range raw.Details {
+		if detail.Type == nil || detail.Type.Code == "" {
+			continue
+		}
+		key := normalizeCode(detail.Type.Code, standingCodeOverrides)
+		if val, ok := extractor.Extract(key, detail.Value); ok {
+			stats[key] = val
+		}
+	}
+
+	if raw.Points != nil {
+		stats["points"] = float64(*raw.Points)
+	}
+	if raw.Position != nil {
+		stats["position"] = float64(*raw.Position)
+	}
+	if raw.Form != "" {
+		stats["form"] = raw.Form
+	}
+
+	// Try to parse the participant for team data
+	var team *provider.Team
+	if raw.Participant != nil {
+		var t smTeamRaw
+		if err := json.Unmarshal(raw.Participant, &t); err == nil && t.ID != 0 {
+			normalized := normalizeTeam(t)
+			team = &normalized
+		}
+	}
+
+	teamID := raw.ParticipantID
+	if team != nil && teamID == 0 {
+		teamID = team.ID
+	}
+
+	rawJSON, _ := json.Marshal(raw)
+
+	return provider.TeamStats{
+		TeamID: teamID,
+		Team:   team,
+		Stats:  stats,
+		Raw:    rawJSON,
+	}
+}
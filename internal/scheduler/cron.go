@@ -0,0 +1,94 @@
+package scheduler
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule is a parsed 5-field cron expression (minute hour day-of-month
+// month day-of-week), checked against the current minute by Scheduler's
+// ticker rather than computing an explicit "next run" time. Only the
+// subset actually needed by this codebase's job configs is supported:
+// "*", a literal integer, or a "*/N" step — no ranges ("1-5") or lists
+// ("1,15,30"). That covers every schedule this repo's jobs use so far
+// (e.g. "*/10 * * * *"); extending the parser to ranges/lists is
+// straightforward if a future job needs one.
+type Schedule struct {
+	minute, hour, dom, month, dow cronField
+}
+
+type cronField struct {
+	any  bool
+	step int // >0 means a "*/N" step field
+	val  int // exact value; meaningful when !any && step == 0
+}
+
+func (f cronField) matches(v int) bool {
+	switch {
+	case f.any:
+		return true
+	case f.step > 0:
+		return v%f.step == 0
+	default:
+		return v == f.val
+	}
+}
+
+func parseField(s string, min, max int) (cronField, error) {
+	if s == "*" {
+		return cronField{any: true}, nil
+	}
+	if rest, ok := strings.CutPrefix(s, "*/"); ok {
+		n, err := strconv.Atoi(rest)
+		if err != nil || n <= 0 {
+			return cronField{}, fmt.Errorf("invalid step field %q", s)
+		}
+		return cronField{step: n}, nil
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil || n < min || n > max {
+		return cronField{}, fmt.Errorf("invalid field %q (want %d-%d)", s, min, max)
+	}
+	return cronField{val: n}, nil
+}
+
+// ParseSchedule parses a standard 5-field cron expression; see Schedule's
+// doc comment for the supported subset.
+func ParseSchedule(expr string) (Schedule, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return Schedule{}, fmt.Errorf("cron expression %q: want 5 space-separated fields, got %d", expr, len(fields))
+	}
+	minute, err := parseField(fields[0], 0, 59)
+	if err != nil {
+		return Schedule{}, fmt.Errorf("minute field: %w", err)
+	}
+	hour, err := parseField(fields[1], 0, 23)
+	if err != nil {
+		return Schedule{}, fmt.Errorf("hour field: %w", err)
+	}
+	dom, err := parseField(fields[2], 1, 31)
+	if err != nil {
+		return Schedule{}, fmt.Errorf("day-of-month field: %w", err)
+	}
+	month, err := parseField(fields[3], 1, 12)
+	if err != nil {
+		return Schedule{}, fmt.Errorf("month field: %w", err)
+	}
+	dow, err := parseField(fields[4], 0, 6)
+	if err != nil {
+		return Schedule{}, fmt.Errorf("day-of-week field: %w", err)
+	}
+	return Schedule{minute: minute, hour: hour, dom: dom, month: month, dow: dow}, nil
+}
+
+// Due reports whether t matches the schedule, down to the minute.
+func (s Schedule) Due(t time.Time) bool {
+	return s.minute.matches(t.Minute()) &&
+		s.hour.matches(t.Hour()) &&
+		s.dom.matches(t.Day()) &&
+		s.month.matches(int(t.Month())) &&
+		s.dow.matches(int(t.Weekday()))
+}
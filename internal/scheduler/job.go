@@ -0,0 +1,117 @@
+// Package scheduler runs declarative, YAML-configured jobs (fixture
+// processing, percentile recalculation, and similar periodic work) from a
+// single long-running daemon process, replacing the external cron entries
+// `scoracle-ingest fixtures process`/`percentiles` previously required. See
+// cmd/ingest's `daemon` command for how jobs are registered and the config
+// loaded.
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"gopkg.in/yaml.v3"
+)
+
+// JobFunc runs one configured job. args is that job's Args map from its
+// YAML entry, passed through uninterpreted — each JobFunc pulls out what
+// it needs (with its own defaults) the same way cmd/ingest's flag-bound
+// subcommands do; see ArgString/ArgInt/ArgBool.
+type JobFunc func(ctx context.Context, pool *pgxpool.Pool, args map[string]any, logger *slog.Logger) error
+
+// Registry is a named map of job functions, keyed by the `cmd` string a
+// JobConfig references (e.g. "fixtures.ProcessPending"). Mirrors the
+// registry-by-key idiom used throughout (internal/sport,
+// internal/provider/registry, internal/provider/seasonseed) — adding a new
+// schedulable job is a new Register call, not a new case in a switch.
+type Registry struct {
+	mu   sync.RWMutex
+	jobs map[string]JobFunc
+}
+
+// NewRegistry returns an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{jobs: map[string]JobFunc{}}
+}
+
+// Register adds or replaces the JobFunc for cmd.
+func (r *Registry) Register(cmd string, fn JobFunc) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.jobs[cmd] = fn
+}
+
+func (r *Registry) lookup(cmd string) (JobFunc, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	fn, ok := r.jobs[cmd]
+	return fn, ok
+}
+
+// JobConfig is one declarative job entry from the daemon's YAML config,
+// e.g.:
+//
+//	jobs:
+//	  - name: nba-fixtures
+//	    cron: "*/10 * * * *"
+//	    cmd: fixtures.ProcessPending
+//	    args: {sport: NBA, max: 50}
+type JobConfig struct {
+	Name string         `yaml:"name"`
+	Cron string         `yaml:"cron"`
+	Cmd  string         `yaml:"cmd"`
+	Args map[string]any `yaml:"args"`
+}
+
+// Config is the daemon's full YAML config: a flat list of jobs.
+type Config struct {
+	Jobs []JobConfig `yaml:"jobs"`
+}
+
+// LoadConfig reads and parses a daemon YAML config from path.
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read scheduler config %s: %w", path, err)
+	}
+	var cfg Config
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("parse scheduler config %s: %w", path, err)
+	}
+	return &cfg, nil
+}
+
+// ArgString returns args[key] as a string, or def if absent or not a string.
+func ArgString(args map[string]any, key, def string) string {
+	if v, ok := args[key].(string); ok {
+		return v
+	}
+	return def
+}
+
+// ArgInt returns args[key] as an int, or def if absent or not a number.
+// YAML unmarshals integer literals into `int` when decoding into
+// map[string]any, but we also accept float64 in case a caller built the
+// map from JSON instead.
+func ArgInt(args map[string]any, key string, def int) int {
+	switch v := args[key].(type) {
+	case int:
+		return v
+	case float64:
+		return int(v)
+	default:
+		return def
+	}
+}
+
+// ArgBool returns args[key] as a bool, or def if absent or not a bool.
+func ArgBool(args map[string]any, key string, def bool) bool {
+	if v, ok := args[key].(bool); ok {
+		return v
+	}
+	return def
+}
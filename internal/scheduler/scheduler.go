@@ -0,0 +1,255 @@
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/albapepper/scoracle-data/internal/maintenance"
+	"github.com/albapepper/scoracle-data/internal/metrics"
+)
+
+// defaultJobLeaseTTL bounds how long a job's overlap-prevention lease is
+// held before another tick is allowed to retry it, in case a replica dies
+// mid-run without ever releasing it. It's deliberately generous — stealing
+// the lease from a job that's merely slow (not dead) would let two
+// replicas run the same job concurrently, which is the one thing this
+// lease exists to prevent.
+const defaultJobLeaseTTL = 1 * time.Hour
+
+// job pairs a configured JobConfig with its resolved schedule and
+// function, plus the mutable run stats exposed via /healthz and /metrics.
+type job struct {
+	cfg      JobConfig
+	schedule Schedule
+	fn       JobFunc
+
+	mu           sync.Mutex
+	lastRun      time.Time
+	lastDuration time.Duration
+	lastErr      error
+	runs         int
+	errors       int
+}
+
+func (j *job) snapshot() (lastRun time.Time, lastDuration time.Duration, lastErr error, runs, errs int) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.lastRun, j.lastDuration, j.lastErr, j.runs, j.errors
+}
+
+func (j *job) record(start time.Time, duration time.Duration, err error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.lastRun = start
+	j.lastDuration = duration
+	j.lastErr = err
+	j.runs++
+	if err != nil {
+		j.errors++
+	}
+}
+
+// Scheduler runs a fixed set of configured jobs, checking once a minute
+// for jobs that are due and launching them. coordinator prevents two
+// daemon replicas (or a still-running job on the next matching tick) from
+// running the same job concurrently; it reuses maintenance.Coordinator's
+// lease table rather than a second, separate Postgres advisory-lock
+// primitive, so the codebase has one place that knows how to elect a
+// leader, not two.
+type Scheduler struct {
+	pool        *pgxpool.Pool
+	coordinator maintenance.Coordinator
+	metrics     *metrics.Registry
+	logger      *slog.Logger
+	jobs        []*job
+
+	wg sync.WaitGroup
+}
+
+// New builds a Scheduler from cfg, resolving each JobConfig's Cmd against
+// reg and its Cron expression via ParseSchedule, and registering per-job
+// gauges on metricsReg (pass nil to skip metrics). Returns an error naming
+// the offending job if any Cmd is unregistered or any Cron fails to parse
+// — a daemon that silently drops a misconfigured job is worse than one
+// that refuses to start. coordinator may be nil, defaulting to
+// maintenance.NewNoopCoordinator for single-node dev.
+func New(cfg *Config, reg *Registry, pool *pgxpool.Pool, coordinator maintenance.Coordinator, metricsReg *metrics.Registry, logger *slog.Logger) (*Scheduler, error) {
+	if coordinator == nil {
+		coordinator = maintenance.NewNoopCoordinator(pool)
+	}
+	s := &Scheduler{pool: pool, coordinator: coordinator, metrics: metricsReg, logger: logger}
+
+	for _, jc := range cfg.Jobs {
+		fn, ok := reg.lookup(jc.Cmd)
+		if !ok {
+			return nil, fmt.Errorf("job %q: unregistered cmd %q", jc.Name, jc.Cmd)
+		}
+		sched, err := ParseSchedule(jc.Cron)
+		if err != nil {
+			return nil, fmt.Errorf("job %q: %w", jc.Name, err)
+		}
+		s.jobs = append(s.jobs, &job{cfg: jc, schedule: sched, fn: fn})
+	}
+
+	if metricsReg != nil {
+		for _, j := range s.jobs {
+			j := j
+			name := sanitizeMetricName(j.cfg.Name)
+			metricsReg.RegisterGaugeFunc("scheduler_job_"+name+"_last_run_timestamp_seconds",
+				"Unix timestamp of this job's last run", func() float64 {
+					lastRun, _, _, _, _ := j.snapshot()
+					if lastRun.IsZero() {
+						return 0
+					}
+					return float64(lastRun.Unix())
+				})
+			metricsReg.RegisterGaugeFunc("scheduler_job_"+name+"_last_duration_seconds",
+				"Duration of this job's last run", func() float64 {
+					_, d, _, _, _ := j.snapshot()
+					return d.Seconds()
+				})
+		}
+	}
+
+	return s, nil
+}
+
+func sanitizeMetricName(name string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			return r
+		default:
+			return '_'
+		}
+	}, name)
+}
+
+// Run blocks, checking every job once per minute and launching any that
+// are due, until ctx is cancelled. On cancellation it stops scheduling new
+// runs and waits for any already in flight to finish before returning —
+// the `daemon` command's SIGTERM handling (via signal.NotifyContext)
+// relies on this to drain cleanly instead of killing a job mid-write.
+func (s *Scheduler) Run(ctx context.Context) {
+	s.logger.Info("Scheduler started", "jobs", len(s.jobs))
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+
+	s.tick(ctx, time.Now())
+	for {
+		select {
+		case t := <-ticker.C:
+			s.tick(ctx, t)
+		case <-ctx.Done():
+			s.logger.Info("Scheduler stopping, waiting for in-flight jobs...")
+			s.wg.Wait()
+			s.logger.Info("Scheduler stopped")
+			return
+		}
+	}
+}
+
+func (s *Scheduler) tick(ctx context.Context, now time.Time) {
+	now = now.Truncate(time.Minute)
+	for _, j := range s.jobs {
+		if !j.schedule.Due(now) {
+			continue
+		}
+		j := j
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			s.runJob(ctx, j)
+		}()
+	}
+}
+
+func (s *Scheduler) runJob(ctx context.Context, j *job) {
+	logger := s.logger.With("job", j.cfg.Name, "cmd", j.cfg.Cmd)
+
+	ok, err := s.coordinator.TryAcquire(ctx, "scheduler:"+j.cfg.Name, defaultJobLeaseTTL)
+	if err != nil {
+		logger.Warn("scheduler lease attempt failed", "error", err)
+		return
+	}
+	if !ok {
+		logger.Debug("scheduler job skipped, already running elsewhere")
+		return
+	}
+
+	start := time.Now()
+	logger.Info("scheduler job starting")
+	runErr := j.fn(ctx, s.pool, j.cfg.Args, logger)
+	duration := time.Since(start)
+	j.record(start, duration, runErr)
+
+	if s.metrics != nil {
+		status := "ok"
+		if runErr != nil {
+			status = "error"
+		}
+		s.metrics.IncCounter("scheduler_job_runs_total", "Total scheduler job runs by outcome",
+			map[string]string{"job": j.cfg.Name, "status": status})
+	}
+
+	if runErr != nil {
+		logger.Error("scheduler job failed", "duration", duration, "error", runErr)
+		return
+	}
+	logger.Info("scheduler job finished", "duration", duration)
+}
+
+// jobStatus is one job's entry in the /healthz response.
+type jobStatus struct {
+	Name         string `json:"name"`
+	Cmd          string `json:"cmd"`
+	Cron         string `json:"cron"`
+	LastRun      string `json:"last_run,omitempty"`
+	LastDuration string `json:"last_duration,omitempty"`
+	LastError    string `json:"last_error,omitempty"`
+	Runs         int    `json:"runs"`
+	Errors       int    `json:"errors"`
+}
+
+func (s *Scheduler) healthzHandler(w http.ResponseWriter, r *http.Request) {
+	statuses := make([]jobStatus, 0, len(s.jobs))
+	for _, j := range s.jobs {
+		lastRun, dur, lastErr, runs, errs := j.snapshot()
+		st := jobStatus{Name: j.cfg.Name, Cmd: j.cfg.Cmd, Cron: j.cfg.Cron, Runs: runs, Errors: errs}
+		if !lastRun.IsZero() {
+			st.LastRun = lastRun.Format(time.RFC3339)
+			st.LastDuration = dur.String()
+		}
+		if lastErr != nil {
+			st.LastError = lastErr.Error()
+		}
+		statuses = append(statuses, st)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]any{"status": "ok", "jobs": statuses})
+}
+
+// ListenAndServeAdmin starts a dedicated HTTP server exposing /healthz
+// (per-job run status as JSON) and, if s.metrics is set, /metrics (its
+// Prometheus exposition) on addr. Mirrors
+// metrics.Registry.ListenAndServeAdmin; blocks until the server errors,
+// intended to be called with `go`.
+func (s *Scheduler) ListenAndServeAdmin(addr string) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", s.healthzHandler)
+	if s.metrics != nil {
+		mux.Handle("/metrics", s.metrics.Handler())
+	}
+	s.logger.Info("Scheduler admin listener started", "addr", addr)
+	if err := http.ListenAndServe(addr, mux); err != nil {
+		s.logger.Error("Scheduler admin listener failed", "error", err)
+	}
+}
@@ -0,0 +1,351 @@
+package seed
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/albapepper/scoracle-data/internal/config"
+	"github.com/albapepper/scoracle-data/internal/provider"
+	"github.com/albapepper/scoracle-data/internal/provider/schema"
+)
+
+// batchChunkSize bounds how many rows go into a single multi-row INSERT.
+// Player stats rows bind 8 params each, so 500 rows keeps every batch well
+// under Postgres' 65535 bind-parameter limit while still cutting a
+// hundred-plus-row fixture group down to one or two round trips.
+const batchChunkSize = 500
+
+// UpsertTeamsBatch writes multiple canonical teams in one multi-row INSERT
+// per chunk instead of one round trip per team. Conflict handling is
+// identical to UpsertTeam, just applied row-by-row within the same
+// statement.
+func UpsertTeamsBatch(ctx context.Context, pool *pgxpool.Pool, sport string, teams []provider.Team) (int, error) {
+	upserted := 0
+	for _, chunk := range chunkTeams(teams, batchChunkSize) {
+		start := time.Now()
+		err := upsertTeamsChunk(ctx, pool, sport, chunk)
+		observeUpsert(config.TeamsTable, sport, start, err)
+		if err != nil {
+			return upserted, err
+		}
+		upserted += len(chunk)
+	}
+	return upserted, nil
+}
+
+func upsertTeamsChunk(ctx context.Context, pool *pgxpool.Pool, sport string, teams []provider.Team) error {
+	const cols = 13
+	var sb strings.Builder
+	sb.WriteString(`INSERT INTO ` + config.TeamsTable + ` (
+		id, sport, name, short_code, city, country, conference,
+		division, venue_name, venue_capacity, founded, logo_url, meta
+	) VALUES `)
+
+	args := make([]interface{}, 0, len(teams)*cols)
+	for i, team := range teams {
+		if i > 0 {
+			sb.WriteString(",")
+		}
+		writePlaceholderGroup(&sb, i*cols, cols)
+		meta, _ := json.Marshal(nonNilMap(team.Meta))
+		args = append(args,
+			team.ID, sport, team.Name, nilEmpty(team.ShortCode), nilEmpty(team.City),
+			nilEmpty(team.Country), nilEmpty(team.Conference), nilEmpty(team.Division),
+			nilEmpty(team.VenueName), team.VenueCapacity, team.Founded,
+			nilEmpty(team.LogoURL), meta,
+		)
+	}
+	sb.WriteString(`
+		ON CONFLICT (id, sport) DO UPDATE SET
+			name = EXCLUDED.name,
+			short_code = EXCLUDED.short_code,
+			city = EXCLUDED.city,
+			country = EXCLUDED.country,
+			conference = EXCLUDED.conference,
+			division = EXCLUDED.division,
+			venue_name = EXCLUDED.venue_name,
+			venue_capacity = EXCLUDED.venue_capacity,
+			founded = EXCLUDED.founded,
+			logo_url = EXCLUDED.logo_url,
+			meta = EXCLUDED.meta,
+			updated_at = NOW()`)
+
+	_, err := pool.Exec(ctx, sb.String(), args...)
+	return err
+}
+
+// UpsertPlayersBatch writes multiple canonical players in one multi-row
+// INSERT per chunk. Conflict handling matches UpsertPlayer's
+// COALESCE-over-existing-value semantics.
+func UpsertPlayersBatch(ctx context.Context, pool *pgxpool.Pool, sport string, players []provider.Player) (int, error) {
+	upserted := 0
+	for _, chunk := range chunkPlayers(players, batchChunkSize) {
+		start := time.Now()
+		err := upsertPlayersChunk(ctx, pool, sport, chunk)
+		observeUpsert(config.PlayersTable, sport, start, err)
+		if err != nil {
+			return upserted, err
+		}
+		upserted += len(chunk)
+	}
+	return upserted, nil
+}
+
+func upsertPlayersChunk(ctx context.Context, pool *pgxpool.Pool, sport string, players []provider.Player) error {
+	const cols = 14
+	var sb strings.Builder
+	sb.WriteString(`INSERT INTO ` + config.PlayersTable + ` (
+		id, sport, name, first_name, last_name, position,
+		detailed_position, nationality, height, weight,
+		date_of_birth, photo_url, team_id, meta
+	) VALUES `)
+
+	args := make([]interface{}, 0, len(players)*cols)
+	for i, player := range players {
+		if i > 0 {
+			sb.WriteString(",")
+		}
+		writePlaceholderGroup(&sb, i*cols, cols)
+		meta, _ := json.Marshal(nonNilMap(player.Meta))
+		args = append(args,
+			player.ID, sport, player.Name, nilEmpty(player.FirstName), nilEmpty(player.LastName),
+			nilEmpty(player.Position), nilEmpty(player.DetailedPosition), nilEmpty(player.Nationality),
+			nilEmpty(player.Height), nilEmpty(player.Weight), nilEmpty(player.DateOfBirth),
+			nilEmpty(player.PhotoURL), player.TeamID, meta,
+		)
+	}
+	t := config.PlayersTable
+	sb.WriteString(`
+		ON CONFLICT (id, sport) DO UPDATE SET
+			name = COALESCE(EXCLUDED.name, ` + t + `.name),
+			first_name = COALESCE(EXCLUDED.first_name, ` + t + `.first_name),
+			last_name = COALESCE(EXCLUDED.last_name, ` + t + `.last_name),
+			position = COALESCE(EXCLUDED.position, ` + t + `.position),
+			detailed_position = COALESCE(EXCLUDED.detailed_position, ` + t + `.detailed_position),
+			nationality = COALESCE(EXCLUDED.nationality, ` + t + `.nationality),
+			height = COALESCE(EXCLUDED.height, ` + t + `.height),
+			weight = COALESCE(EXCLUDED.weight, ` + t + `.weight),
+			date_of_birth = COALESCE(EXCLUDED.date_of_birth, ` + t + `.date_of_birth),
+			photo_url = COALESCE(EXCLUDED.photo_url, ` + t + `.photo_url),
+			team_id = COALESCE(EXCLUDED.team_id, ` + t + `.team_id),
+			meta = COALESCE(EXCLUDED.meta, ` + t + `.meta),
+			updated_at = NOW()`)
+
+	_, err := pool.Exec(ctx, sb.String(), args...)
+	return err
+}
+
+// UpsertPlayerStatsBatch writes multiple players' season stats in one
+// multi-row INSERT per chunk, returning the total rows written and how many
+// actually changed (same row_hash-gated semantics as UpsertPlayerStats).
+// Stats-updated notifications fire individually for each changed row, same
+// as the single-row path.
+func UpsertPlayerStatsBatch(ctx context.Context, pool *pgxpool.Pool, sport string, season, leagueID int, items []provider.PlayerStats) (upserted, changed int, err error) {
+	for _, chunk := range chunkPlayerStats(items, batchChunkSize) {
+		start := time.Now()
+		changedIDs, cErr := upsertPlayerStatsChunk(ctx, pool, sport, season, leagueID, chunk)
+		observeUpsert(config.PlayerStatsTable, sport, start, cErr)
+		if cErr != nil {
+			return upserted, changed, cErr
+		}
+		upserted += len(chunk)
+		changed += len(changedIDs)
+		for _, id := range changedIDs {
+			notifyStatsUpdated(ctx, pool, "player", id, sport, season, leagueID)
+		}
+	}
+	return upserted, changed, nil
+}
+
+func upsertPlayerStatsChunk(ctx context.Context, pool *pgxpool.Pool, sport string, season, leagueID int, items []provider.PlayerStats) ([]int, error) {
+	const cols = 8
+	var sb strings.Builder
+	sb.WriteString(`INSERT INTO ` + config.PlayerStatsTable + ` (
+		player_id, sport, season, league_id, team_id,
+		stats, raw_response, row_hash
+	) VALUES `)
+
+	args := make([]interface{}, 0, len(items)*cols)
+	for i, data := range items {
+		if i > 0 {
+			sb.WriteString(",")
+		}
+		writePlaceholderGroup(&sb, i*cols, cols)
+		if problems := schema.Validate(sport, data.Stats); len(problems) > 0 {
+			slog.Default().Warn("player stats failed schema validation", "sport", sport, "player_id", data.PlayerID, "problems", problems)
+			observeSeedError(sport, ErrKindSchemaMismatch)
+		}
+		stats, _ := json.Marshal(nonNilMapI(data.Stats))
+		raw := data.Raw
+		if raw == nil {
+			raw = []byte("{}")
+		}
+		args = append(args, data.PlayerID, sport, season, leagueID, data.TeamID, stats, raw, statsHash(stats))
+	}
+	sb.WriteString(`
+		ON CONFLICT (player_id, sport, season, league_id) DO UPDATE SET
+			team_id = EXCLUDED.team_id,
+			stats = EXCLUDED.stats,
+			raw_response = EXCLUDED.raw_response,
+			row_hash = EXCLUDED.row_hash,
+			updated_at = NOW()
+		WHERE ` + config.PlayerStatsTable + `.row_hash IS DISTINCT FROM EXCLUDED.row_hash
+		RETURNING player_id`)
+
+	rows, err := pool.Query(ctx, sb.String(), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var changedIDs []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		changedIDs = append(changedIDs, id)
+	}
+	return changedIDs, rows.Err()
+}
+
+// UpsertTeamStatsBatch is UpsertPlayerStatsBatch's counterpart for team
+// stats; see its doc comment for the upserted/changed semantics.
+func UpsertTeamStatsBatch(ctx context.Context, pool *pgxpool.Pool, sport string, season, leagueID int, items []provider.TeamStats) (upserted, changed int, err error) {
+	for _, chunk := range chunkTeamStats(items, batchChunkSize) {
+		start := time.Now()
+		changedIDs, cErr := upsertTeamStatsChunk(ctx, pool, sport, season, leagueID, chunk)
+		observeUpsert(config.TeamStatsTable, sport, start, cErr)
+		if cErr != nil {
+			return upserted, changed, cErr
+		}
+		upserted += len(chunk)
+		changed += len(changedIDs)
+		for _, id := range changedIDs {
+			notifyStatsUpdated(ctx, pool, "team", id, sport, season, leagueID)
+		}
+	}
+	return upserted, changed, nil
+}
+
+func upsertTeamStatsChunk(ctx context.Context, pool *pgxpool.Pool, sport string, season, leagueID int, items []provider.TeamStats) ([]int, error) {
+	const cols = 7
+	var sb strings.Builder
+	sb.WriteString(`INSERT INTO ` + config.TeamStatsTable + ` (
+		team_id, sport, season, league_id,
+		stats, raw_response, row_hash
+	) VALUES `)
+
+	args := make([]interface{}, 0, len(items)*cols)
+	for i, data := range items {
+		if i > 0 {
+			sb.WriteString(",")
+		}
+		writePlaceholderGroup(&sb, i*cols, cols)
+		if problems := schema.Validate(sport, data.Stats); len(problems) > 0 {
+			slog.Default().Warn("team stats failed schema validation", "sport", sport, "team_id", data.TeamID, "problems", problems)
+			observeSeedError(sport, ErrKindSchemaMismatch)
+		}
+		stats, _ := json.Marshal(nonNilMapI(data.Stats))
+		raw := data.Raw
+		if raw == nil {
+			raw = []byte("{}")
+		}
+		args = append(args, data.TeamID, sport, season, leagueID, stats, raw, statsHash(stats))
+	}
+	sb.WriteString(`
+		ON CONFLICT (team_id, sport, season, league_id) DO UPDATE SET
+			stats = EXCLUDED.stats,
+			raw_response = EXCLUDED.raw_response,
+			row_hash = EXCLUDED.row_hash,
+			updated_at = NOW()
+		WHERE ` + config.TeamStatsTable + `.row_hash IS DISTINCT FROM EXCLUDED.row_hash
+		RETURNING team_id`)
+
+	rows, err := pool.Query(ctx, sb.String(), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var changedIDs []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		changedIDs = append(changedIDs, id)
+	}
+	return changedIDs, rows.Err()
+}
+
+// writePlaceholderGroup appends "($offset+1,$offset+2,...,$offset+n)" to sb,
+// the multi-row INSERT building block shared by every batch helper above.
+func writePlaceholderGroup(sb *strings.Builder, offset, n int) {
+	sb.WriteString("(")
+	for i := 1; i <= n; i++ {
+		if i > 1 {
+			sb.WriteString(",")
+		}
+		fmt.Fprintf(sb, "$%d", offset+i)
+	}
+	sb.WriteString(")")
+}
+
+// --------------------------------------------------------------------------
+// Chunking
+// --------------------------------------------------------------------------
+
+func chunkTeams(teams []provider.Team, size int) [][]provider.Team {
+	var chunks [][]provider.Team
+	for i := 0; i < len(teams); i += size {
+		end := i + size
+		if end > len(teams) {
+			end = len(teams)
+		}
+		chunks = append(chunks, teams[i:end])
+	}
+	return chunks
+}
+
+func chunkPlayers(players []provider.Player, size int) [][]provider.Player {
+	var chunks [][]provider.Player
+	for i := 0; i < len(players); i += size {
+		end := i + size
+		if end > len(players) {
+			end = len(players)
+		}
+		chunks = append(chunks, players[i:end])
+	}
+	return chunks
+}
+
+func chunkPlayerStats(items []provider.PlayerStats, size int) [][]provider.PlayerStats {
+	var chunks [][]provider.PlayerStats
+	for i := 0; i < len(items); i += size {
+		end := i + size
+		if end > len(items) {
+			end = len(items)
+		}
+		chunks = append(chunks, items[i:end])
+	}
+	return chunks
+}
+
+func chunkTeamStats(items []provider.TeamStats, size int) [][]provider.TeamStats {
+	var chunks [][]provider.TeamStats
+	for i := 0; i < len(items); i += size {
+		end := i + size
+		if end > len(items) {
+			end = len(items)
+		}
+		chunks = append(chunks, items[i:end])
+	}
+	return chunks
+}
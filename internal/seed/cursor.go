@@ -0,0 +1,70 @@
+package seed
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/albapepper/scoracle-data/internal/provider"
+)
+
+// GetProviderCursor and SaveProviderCursor persist the conditional-request
+// state a provider handler needs for incremental fetches (see
+// provider.Cursor), keyed by (provider, endpoint) — e.g. ("bdl_nba",
+// "teams"). Backed by a provider_cursors table (no migrations directory
+// exists in this repo):
+//
+//	CREATE TABLE provider_cursors (
+//	    provider      text NOT NULL,
+//	    endpoint      text NOT NULL,
+//	    etag          text,
+//	    last_modified text,
+//	    payload       jsonb,
+//	    updated_at    timestamptz NOT NULL DEFAULT now(),
+//	    PRIMARY KEY (provider, endpoint)
+//	);
+
+// GetProviderCursor loads the saved cursor for (providerName, endpoint). A
+// missing row is reported as pgx.ErrNoRows, which callers treat the same as
+// a zero-value Cursor — always force a full fetch.
+func GetProviderCursor(ctx context.Context, pool *pgxpool.Pool, providerName, endpoint string) (provider.Cursor, error) {
+	var c provider.Cursor
+	var etag, lastModified *string
+	err := pool.QueryRow(ctx,
+		`SELECT etag, last_modified, payload FROM provider_cursors WHERE provider = $1 AND endpoint = $2`,
+		providerName, endpoint,
+	).Scan(&etag, &lastModified, &c.Payload)
+	if err != nil {
+		return provider.Cursor{}, err
+	}
+	if etag != nil {
+		c.ETag = *etag
+	}
+	if lastModified != nil {
+		c.LastModified = *lastModified
+	}
+	return c, nil
+}
+
+// SaveProviderCursor upserts the cursor for (providerName, endpoint).
+func SaveProviderCursor(ctx context.Context, pool *pgxpool.Pool, providerName, endpoint string, c provider.Cursor) error {
+	_, err := pool.Exec(ctx,
+		`INSERT INTO provider_cursors (provider, endpoint, etag, last_modified, payload)
+		 VALUES ($1,$2,$3,$4,$5)
+		 ON CONFLICT (provider, endpoint) DO UPDATE SET
+		     etag = EXCLUDED.etag,
+		     last_modified = EXCLUDED.last_modified,
+		     payload = EXCLUDED.payload,
+		     updated_at = NOW()`,
+		providerName, endpoint, nilEmpty(c.ETag), nilEmpty(c.LastModified), nullablePayload(c.Payload),
+	)
+	return err
+}
+
+// nullablePayload maps an empty/nil Payload to SQL NULL.
+func nullablePayload(p []byte) interface{} {
+	if len(p) == 0 {
+		return nil
+	}
+	return p
+}
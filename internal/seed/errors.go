@@ -0,0 +1,60 @@
+package seed
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/albapepper/scoracle-data/internal/provider/bdl"
+)
+
+// ErrorKind classifies a seed failure so operators can alert on specific
+// failure classes (e.g. page a human for db_conflict, but let rate_limit
+// retry on its own) instead of grepping SeedResult.Errors' message text.
+type ErrorKind string
+
+const (
+	ErrKindNetwork        ErrorKind = "network"
+	ErrKindDecode         ErrorKind = "decode"
+	ErrKindRateLimit      ErrorKind = "rate_limit"
+	ErrKindUpstream5xx    ErrorKind = "upstream_5xx"
+	ErrKindDBConflict     ErrorKind = "db_conflict"
+	ErrKindSchemaMismatch ErrorKind = "schema_mismatch"
+)
+
+// Error is a seed failure tagged with the Kind an operator would alert on,
+// plus Op describing what was being attempted (e.g. "upsert team 14") when
+// it failed.
+type Error struct {
+	Kind ErrorKind
+	Op   string
+	Err  error
+}
+
+func (e Error) Error() string { return fmt.Sprintf("%s: %v", e.Op, e.Err) }
+func (e Error) Unwrap() error { return e.Err }
+
+// classifyKind infers an ErrorKind from err, preferring bdl's sentinel
+// errors (see bdl.classifyStatus) over guessing from the message, since
+// every fetch error seed sees has already passed through one of BDL or
+// SportMonks' handlers by the time it reaches here.
+func classifyKind(err error) ErrorKind {
+	if err == nil {
+		return ErrKindDBConflict
+	}
+	switch {
+	case errors.Is(err, bdl.ErrRateLimited):
+		return ErrKindRateLimit
+	case errors.Is(err, bdl.ErrServerError):
+		return ErrKindUpstream5xx
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return ErrKindNetwork
+	}
+	if strings.Contains(err.Error(), "decode") {
+		return ErrKindDecode
+	}
+	return ErrKindDBConflict
+}
@@ -2,17 +2,59 @@ package seed
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log/slog"
+	"sync"
+	"time"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 
+	"github.com/albapepper/scoracle-data/internal/db"
 	"github.com/albapepper/scoracle-data/internal/provider"
 	"github.com/albapepper/scoracle-data/internal/provider/sportmonks"
 )
 
 const sportFootball = "FOOTBALL"
 
+// footballPhases is the fixed execution order SeedFootballSeason runs and
+// checkpoints against; see ResumePlan.
+var footballPhases = []string{"teams", "player_stats", "team_stats"}
+
+// teamCursor is the player_stats phase's checkpoint cursor: the team IDs
+// whose squads have already been fully fetched and upserted, so a resumed
+// run only re-fetches the teams that were left when it was interrupted,
+// instead of the whole league (see checkpointObserver).
+type teamCursor struct {
+	Done []int `json:"done"`
+}
+
+// checkpointObserver saves football's player_stats progress to cp as each
+// team's squad completes. sportmonks.FootballHandler processes teams
+// sequentially (see GetPlayersWithStats), so TeamDone never fires
+// concurrently in practice, but the mutex keeps this safe even if that ever
+// changes. It ignores per-player events; only team completion is coarse
+// enough to make a useful checkpoint.
+type checkpointObserver struct {
+	ctx   context.Context
+	cp    Checkpointer
+	runID string
+
+	mu   sync.Mutex
+	done []int
+}
+
+func (o *checkpointObserver) TeamDone(teamID, playerCount int) {
+	o.mu.Lock()
+	o.done = append(o.done, teamID)
+	cursor := teamCursor{Done: append([]int(nil), o.done...)}
+	o.mu.Unlock()
+	_ = o.cp.SaveCursor(o.ctx, o.runID, "player_stats", cursor)
+}
+
+func (o *checkpointObserver) PlayerTimedOut(playerID, teamID int)         {}
+func (o *checkpointObserver) PlayerRetried(playerID, teamID, attempt int) {}
+
 // SeedFootballSeason seeds all data for a single Football league-season.
 //
 // Args:
@@ -20,6 +62,21 @@ const sportFootball = "FOOTBALL"
 //   - leagueID: Our internal league ID (8, 82, 301, 384, 564)
 //   - seasonYear: Year (e.g. 2024 for 2024-25 season)
 //   - smLeagueID: SportMonks league ID (same as our internal ID for football)
+//
+// cp and state checkpoint progress the same way as SeedNBA (see its doc
+// comment), except player_stats: its cursor records which teams' squads
+// have already been fetched (see teamCursor), so resuming mid-phase only
+// re-fetches the teams that were left rather than the whole league.
+// dryRun fetches from the provider as normal but skips every database
+// write.
+//
+// There's no `--since`/delta mode yet: that needs the provider to filter
+// by an updated_at-style timestamp (SportMonks exposes one via
+// filters[...] on some endpoints), but neither provider.PlayerStats/Team
+// nor FootballHandler currently carry or accept one, so a nightly run
+// still re-fetches the whole league rather than just what changed. The
+// team-level resumability above at least keeps a retried run from
+// re-paying for teams it already finished.
 func SeedFootballSeason(
 	ctx context.Context,
 	pool *pgxpool.Pool,
@@ -29,16 +86,24 @@ func SeedFootballSeason(
 	seasonYear int,
 	smLeagueID int,
 	logger *slog.Logger,
+	cp Checkpointer,
+	state *RunState,
+	dryRun bool,
 ) SeedResult {
 	var result SeedResult
+	runID := state.RunID
+	skip := ResumePlan(state, footballPhases)
 
 	// Resolve SportMonks league ID from DB if not provided
 	if smLeagueID == 0 {
 		var dbSmID *int
 		var leagueName string
-		err := pool.QueryRow(ctx, "league_lookup", leagueID).Scan(&dbSmID, &leagueName)
+		err := db.QueryRowLeagueLookup(ctx, pool, leagueID).Scan(&dbSmID, &leagueName)
 		if err != nil || dbSmID == nil {
-			result.AddErrorf("no sportmonks_id found for league %d: %v", leagueID, err)
+			if err == nil {
+				err = fmt.Errorf("no sportmonks_id found for league %d", leagueID)
+			}
+			result.AddErr(sportFootball, "resolve sportmonks league id", err)
 			return result
 		}
 		smLeagueID = *dbSmID
@@ -49,88 +114,209 @@ func SeedFootballSeason(
 		"sm_season_id", smSeasonID, "league_id", leagueID,
 		"season_year", seasonYear, "sm_league_id", smLeagueID)
 
-	// 1. Teams
+	// 1. Teams. Always fetched (phase 2 needs teamIDs below), but the
+	// upsert loop and checkpoint are skipped if a prior attempt already
+	// completed this phase.
+	phaseStart := time.Now()
 	logger.Info("Phase 1/3: Seeding teams...")
 	teams, err := handler.GetTeams(ctx, smSeasonID)
 	if err != nil {
-		result.AddErrorf("fetch teams: %v", err)
-	} else {
+		result.AddErr(sportFootball, "fetch teams", err)
+	} else if !skip["teams"] {
 		for _, team := range teams {
+			if dryRun {
+				result.TeamsUpserted++
+				continue
+			}
 			if err := UpsertTeam(ctx, pool, sportFootball, team); err != nil {
-				result.AddErrorf("upsert team %d: %v", team.ID, err)
+				result.AddErr(sportFootball, fmt.Sprintf("upsert team %d", team.ID), err)
 			} else {
 				result.TeamsUpserted++
 			}
 		}
+		logger.Info("Teams done", "count", result.TeamsUpserted, "dry_run", dryRun)
+		observeSeedPhase(sportFootball, "teams", phaseStart)
+		observeSeedRows(sportFootball, "team", result.TeamsUpserted)
+		_ = cp.CompletePhase(ctx, runID, "teams")
 	}
-	logger.Info("Teams done", "count", result.TeamsUpserted)
 
 	// 2. Players + Player Stats (via squad iteration)
-	logger.Info("Phase 2/3: Seeding players + stats...")
 	teamIDs := make([]int, len(teams))
 	for i, t := range teams {
 		teamIDs[i] = t.ID
 	}
 
-	count := 0
-	err = handler.GetPlayersWithStats(ctx, smSeasonID, teamIDs, smLeagueID,
-		func(ps provider.PlayerStats) error {
-			if ps.Player != nil {
-				if err := UpsertPlayer(ctx, pool, sportFootball, *ps.Player); err != nil {
-					result.AddErrorf("upsert player %d: %v", ps.PlayerID, err)
-				} else {
-					result.PlayersUpserted++
+	if !skip["player_stats"] {
+		phaseStart = time.Now()
+		logger.Info("Phase 2/3: Seeding players + stats...")
+		if extractor, err := LoadExtractionPaths(ctx, pool, sportFootball, "player", logger); err != nil {
+			logger.Warn("extraction paths unavailable, using defaults", "error", err)
+		} else {
+			handler.SetPlayerExtractor(extractor)
+		}
+
+		// If a prior attempt at this run got partway through player_stats,
+		// its cursor lists the teams already fetched and upserted; only
+		// fetch what's left instead of restarting the whole league.
+		pendingTeamIDs := teamIDs
+		if state.Phase == "player_stats" && len(state.LastCursor) > 0 {
+			var cursor teamCursor
+			if err := json.Unmarshal(state.LastCursor, &cursor); err != nil {
+				logger.Warn("unreadable player_stats cursor, refetching all teams", "error", err)
+			} else {
+				doneTeams := make(map[int]bool, len(cursor.Done))
+				for _, id := range cursor.Done {
+					doneTeams[id] = true
 				}
-			}
-			if len(ps.Stats) > 0 {
-				if err := UpsertPlayerStats(ctx, pool, sportFootball, seasonYear, leagueID, ps); err != nil {
-					result.AddErrorf("upsert player stats %d: %v", ps.PlayerID, err)
-				} else {
-					result.PlayerStatsUpserted++
+				pendingTeamIDs = make([]int, 0, len(teamIDs))
+				for _, id := range teamIDs {
+					if !doneTeams[id] {
+						pendingTeamIDs = append(pendingTeamIDs, id)
+					}
 				}
+				logger.Info("Resuming player_stats", "teams_done", len(cursor.Done), "teams_pending", len(pendingTeamIDs))
 			}
-			count++
-			if count%50 == 0 {
-				logger.Info("Player progress", "count", count)
-			}
-			return nil
-		})
-	if err != nil {
-		result.AddErrorf("fetch players/stats: %v", err)
+		}
+
+		handler.SetObserver(&checkpointObserver{ctx: ctx, cp: cp, runID: runID})
+		count := 0
+		err = handler.GetPlayersWithStats(ctx, smSeasonID, pendingTeamIDs, smLeagueID,
+			func(ps provider.PlayerStats) error {
+				if ps.Player != nil {
+					if !dryRun {
+						if err := UpsertPlayer(ctx, pool, sportFootball, *ps.Player); err != nil {
+							result.AddErr(sportFootball, fmt.Sprintf("upsert player %d", ps.PlayerID), err)
+						} else {
+							result.PlayersUpserted++
+						}
+					} else {
+						result.PlayersUpserted++
+					}
+				}
+				if len(ps.Stats) > 0 {
+					if !dryRun {
+						if changed, err := UpsertPlayerStats(ctx, pool, sportFootball, seasonYear, leagueID, ps); err != nil {
+							result.AddErr(sportFootball, fmt.Sprintf("upsert player stats %d", ps.PlayerID), err)
+						} else {
+							result.PlayerStatsUpserted++
+							if changed {
+								result.PlayerStatsChanged++
+							} else {
+								result.PlayerStatsSkipped++
+							}
+						}
+					} else {
+						result.PlayerStatsUpserted++
+					}
+				}
+				count++
+				if count%50 == 0 {
+					logger.Info("Player progress", "count", count)
+				}
+				return nil
+			})
+		if err != nil {
+			result.AddErr(sportFootball, "fetch players/stats", err)
+		}
+		logger.Info("Players + stats done",
+			"players", result.PlayersUpserted, "stats", result.PlayerStatsUpserted, "dry_run", dryRun)
+		observeSeedPhase(sportFootball, "player_stats", phaseStart)
+		observeSeedRows(sportFootball, "player", result.PlayersUpserted)
+		observeSeedRows(sportFootball, "player_stats", result.PlayerStatsUpserted)
+		_ = cp.CompletePhase(ctx, runID, "player_stats")
 	}
-	logger.Info("Players + stats done",
-		"players", result.PlayersUpserted, "stats", result.PlayerStatsUpserted)
 
 	// 3. Team Stats (Standings)
-	logger.Info("Phase 3/3: Seeding standings...")
-	teamStats, err := handler.GetTeamStats(ctx, smSeasonID)
-	if err != nil {
-		result.AddErrorf("fetch standings: %v", err)
-	} else {
-		for _, ts := range teamStats {
-			if ts.Team != nil {
-				if err := UpsertTeam(ctx, pool, sportFootball, *ts.Team); err != nil {
-					result.AddErrorf("upsert team from standings %d: %v", ts.TeamID, err)
+	if !skip["team_stats"] {
+		phaseStart = time.Now()
+		logger.Info("Phase 3/3: Seeding standings...")
+		if extractor, err := LoadExtractionPaths(ctx, pool, sportFootball, "team", logger); err != nil {
+			logger.Warn("extraction paths unavailable, using defaults", "error", err)
+		} else {
+			handler.SetTeamExtractor(extractor)
+		}
+		teamStats, err := handler.GetTeamStats(ctx, smSeasonID)
+		if err != nil {
+			result.AddErr(sportFootball, "fetch standings", err)
+		} else {
+			for _, ts := range teamStats {
+				if dryRun {
+					result.TeamStatsUpserted++
+					continue
+				}
+				if ts.Team != nil {
+					if err := UpsertTeam(ctx, pool, sportFootball, *ts.Team); err != nil {
+						result.AddErr(sportFootball, fmt.Sprintf("upsert team from standings %d", ts.TeamID), err)
+					}
+				}
+				if changed, err := UpsertTeamStats(ctx, pool, sportFootball, seasonYear, leagueID, ts); err != nil {
+					result.AddErr(sportFootball, fmt.Sprintf("upsert team stats %d", ts.TeamID), err)
+				} else {
+					result.TeamStatsUpserted++
+					if changed {
+						result.TeamStatsChanged++
+					} else {
+						result.TeamStatsSkipped++
+					}
 				}
-			}
-			if err := UpsertTeamStats(ctx, pool, sportFootball, seasonYear, leagueID, ts); err != nil {
-				result.AddErrorf("upsert team stats %d: %v", ts.TeamID, err)
-			} else {
-				result.TeamStatsUpserted++
 			}
 		}
+		logger.Info("Standings done", "count", result.TeamStatsUpserted, "dry_run", dryRun)
+		observeSeedPhase(sportFootball, "team_stats", phaseStart)
+		observeSeedRows(sportFootball, "team_stats", result.TeamStatsUpserted)
+		_ = cp.CompletePhase(ctx, runID, "team_stats")
 	}
-	logger.Info("Standings done", "count", result.TeamStatsUpserted)
 
 	logger.Info("Football season seed complete",
 		"league_id", leagueID, "season", seasonYear, "summary", result.Summary())
 	return result
 }
 
+// LoadExtractionPaths builds a provider.Extractor from stat_definitions'
+// extraction_path column:
+//
+//	ALTER TABLE stat_definitions ADD COLUMN extraction_path text;
+//
+// extraction_path holds a provider.StatPath expression (e.g. "$.goals.total",
+// "$.sum(*.count)") for stats whose provider payload doesn't fit the
+// default $.total || $.all || $.count || $.average chain; NULL (the
+// default) means "use that chain," so existing definitions need no
+// changes. Rows with an unparseable path are skipped with a warning rather
+// than failing the whole seed — a typo in one definition shouldn't block
+// every other stat.
+func LoadExtractionPaths(ctx context.Context, pool *pgxpool.Pool, sport, entityType string, logger *slog.Logger) (*provider.Extractor, error) {
+	rows, err := pool.Query(ctx,
+		`SELECT key_name, extraction_path FROM stat_definitions
+		 WHERE sport = $1 AND entity_type = $2 AND extraction_path IS NOT NULL`,
+		sport, entityType)
+	if err != nil {
+		return nil, fmt.Errorf("load extraction paths: %w", err)
+	}
+	defer rows.Close()
+
+	paths := make(map[string]provider.StatPath)
+	for rows.Next() {
+		var key, expr string
+		if err := rows.Scan(&key, &expr); err != nil {
+			return nil, fmt.Errorf("scan extraction path: %w", err)
+		}
+		path, err := provider.CompileStatPath(expr)
+		if err != nil {
+			logger.Warn("skipping unparseable extraction_path", "sport", sport, "key", key, "error", err)
+			continue
+		}
+		paths[key] = path
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("load extraction paths: %w", err)
+	}
+	return provider.NewExtractor(paths), nil
+}
+
 // ResolveProviderSeasonID looks up the SportMonks season ID from the provider_seasons table.
 func ResolveProviderSeasonID(ctx context.Context, pool *pgxpool.Pool, leagueID, seasonYear int) (int, error) {
 	var smSeasonID *int
-	err := pool.QueryRow(ctx, "resolve_provider_season", leagueID, seasonYear).Scan(&smSeasonID)
+	err := db.QueryRowResolveProviderSeason(ctx, pool, leagueID, seasonYear).Scan(&smSeasonID)
 	if err != nil {
 		return 0, fmt.Errorf("resolve provider season: %w", err)
 	}
@@ -0,0 +1,121 @@
+package seed
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/albapepper/scoracle-data/internal/provider"
+)
+
+// GameSeedRequest is the subset of fixture data game-stats seeding needs.
+// Defined here rather than importing fixture.Row because internal/fixture
+// already imports internal/seed — the same reason registry.FixtureContext
+// exists instead of passing a fixture.Row across that boundary.
+type GameSeedRequest struct {
+	FixtureID int
+	GameID    int // the provider's external game/fixture ID; 0 means unknown
+	Sport     string
+}
+
+// GameStatsFetcher is the subset of a BDL sport handler's API that fetches
+// per-game box scores — bdl.NBAHandler satisfies it. seed doesn't import bdl
+// itself (bdl already imports provider, which would cycle back here), so
+// callers pass their handler in directly.
+type GameStatsFetcher interface {
+	GetGameStats(ctx context.Context, gameIDs []int, fn func(provider.GameStats) error) error
+}
+
+// SeedFixtureGameStats fetches per-game box-score stats for req's fixture
+// and upserts them into fixture_player_stats, keyed by (fixture_id,
+// player_id) rather than (player_id, season, league_id) — unlike
+// UpsertPlayerStats' season aggregates, a single fixture only ever has one
+// box-score row per player. A zero GameID (provider never reported an
+// external fixture ID) is a no-op, not an error: not every fixture source
+// can be matched to a provider game.
+//
+// Backed by a fixture_player_stats table (no migrations directory exists in
+// this repo):
+//
+//	CREATE TABLE fixture_player_stats (
+//	    fixture_id   int NOT NULL REFERENCES fixtures(id),
+//	    player_id    int NOT NULL,
+//	    team_id      int,
+//	    sport        text NOT NULL,
+//	    stats        jsonb NOT NULL,
+//	    raw_response jsonb NOT NULL DEFAULT '{}',
+//	    row_hash     text NOT NULL,
+//	    created_at   timestamptz NOT NULL DEFAULT now(),
+//	    updated_at   timestamptz NOT NULL DEFAULT now(),
+//	    PRIMARY KEY (fixture_id, player_id)
+//	);
+func SeedFixtureGameStats(ctx context.Context, pool *pgxpool.Pool, fetcher GameStatsFetcher, req GameSeedRequest) SeedResult {
+	var result SeedResult
+	if req.GameID == 0 {
+		return result
+	}
+
+	err := fetcher.GetGameStats(ctx, []int{req.GameID}, func(gs provider.GameStats) error {
+		if gs.Player != nil {
+			if err := UpsertPlayer(ctx, pool, req.Sport, *gs.Player); err != nil {
+				result.AddErr(req.Sport, fmt.Sprintf("upsert player %d", gs.PlayerID), err)
+			} else {
+				result.PlayersUpserted++
+			}
+		}
+
+		changed, err := upsertFixturePlayerStats(ctx, pool, req.Sport, req.FixtureID, gs)
+		if err != nil {
+			result.AddErr(req.Sport, fmt.Sprintf("upsert fixture player stats %d", gs.PlayerID), err)
+			return nil
+		}
+		result.PlayerStatsUpserted++
+		if changed {
+			result.PlayerStatsChanged++
+		} else {
+			result.PlayerStatsSkipped++
+		}
+		return nil
+	})
+	if err != nil {
+		result.AddErr(req.Sport, "fetch game stats", err)
+	}
+	return result
+}
+
+// upsertFixturePlayerStats writes one player's box-score line to
+// fixture_player_stats. Gated on row_hash like UpsertPlayerStats, so a
+// refetch of an already-seeded fixture (e.g. a retry) doesn't touch rows
+// whose stats haven't changed.
+func upsertFixturePlayerStats(ctx context.Context, pool *pgxpool.Pool, sport string, fixtureID int, gs provider.GameStats) (changed bool, err error) {
+	start := time.Now()
+	stats, _ := json.Marshal(nonNilMapI(gs.Stats))
+	raw := gs.Raw
+	if raw == nil {
+		raw = []byte("{}")
+	}
+	hash := statsHash(stats)
+
+	err = pool.QueryRow(ctx, `
+		WITH upsert AS (
+			INSERT INTO fixture_player_stats (
+				fixture_id, player_id, team_id, sport, stats, raw_response, row_hash
+			) VALUES ($1,$2,$3,$4,$5,$6,$7)
+			ON CONFLICT (fixture_id, player_id) DO UPDATE SET
+				team_id = EXCLUDED.team_id,
+				stats = EXCLUDED.stats,
+				raw_response = EXCLUDED.raw_response,
+				row_hash = EXCLUDED.row_hash,
+				updated_at = NOW()
+			WHERE fixture_player_stats.row_hash IS DISTINCT FROM EXCLUDED.row_hash
+			RETURNING 1
+		)
+		SELECT EXISTS(SELECT 1 FROM upsert)`,
+		fixtureID, gs.PlayerID, gs.TeamID, sport, stats, raw, hash,
+	).Scan(&changed)
+	observeUpsert("fixture_player_stats", sport, start, err)
+	return changed, err
+}
@@ -0,0 +1,72 @@
+package seed
+
+import (
+	"time"
+
+	"github.com/albapepper/scoracle-data/internal/metrics"
+)
+
+// reg is the process-wide metrics registry for seed operations, set once at
+// startup via SetMetrics. A nil reg (the default) makes every instrumented
+// call a no-op, so seed code works whether or not a caller opted in — seed
+// runs from both the long-lived API process and one-shot ingest commands,
+// and neither threads a registry through every Upsert call site, unlike
+// the per-request registry in internal/api/handler.
+var reg *metrics.Registry
+
+// SetMetrics wires a metrics registry into every subsequent Upsert* call.
+// Call once from cmd/ingest/main.go (or cmd/api/main.go, since fixture
+// processing can also run in-process) before seeding.
+func SetMetrics(r *metrics.Registry) {
+	reg = r
+}
+
+// observeUpsert records scoracle_upserts_total{table,sport} and
+// scoracle_upsert_duration_seconds{table} for one Upsert* call.
+func observeUpsert(table, sport string, start time.Time, err error) {
+	if reg == nil {
+		return
+	}
+	result := "ok"
+	if err != nil {
+		result = "error"
+	}
+	reg.IncCounter("scoracle_upserts_total", "Seed row upserts, labeled by table, sport, and result.",
+		map[string]string{"table": table, "sport": sport, "result": result})
+	reg.ObserveDuration("scoracle_upsert_duration_seconds", "Seed upsert latency in seconds, labeled by table.",
+		map[string]string{"table": table}, start)
+}
+
+// observeSeedPhase records scoracle_seed_duration_seconds{sport,phase} for
+// one phase (teams/player_stats/team_stats) of a SeedNBA/SeedFootballSeason
+// run, coarser than observeUpsert's per-row timing above.
+func observeSeedPhase(sport, phase string, start time.Time) {
+	if reg == nil {
+		return
+	}
+	reg.ObserveDuration("scoracle_seed_duration_seconds", "Seed phase latency in seconds, labeled by sport and phase.",
+		map[string]string{"sport": sport, "phase": phase}, start)
+}
+
+// observeSeedRows records scoracle_seed_rows_total{sport,entity}, adding n
+// rows upserted for entity ("team", "player", "player_stats", "team_stats")
+// during one seed phase.
+func observeSeedRows(sport, entity string, n int) {
+	if reg == nil || n == 0 {
+		return
+	}
+	reg.AddCounter("scoracle_seed_rows_total", "Seed rows upserted, labeled by sport and entity.",
+		map[string]string{"sport": sport, "entity": entity}, float64(n))
+}
+
+// observeSeedError records scoracle_seed_errors_total{sport,kind}, one per
+// SeedResult.AddErr call (or, for schema_mismatch, per failed
+// schema.Validate check in UpsertPlayerStats/UpsertTeamStats — those don't
+// fail the upsert, so they never reach AddErr).
+func observeSeedError(sport string, kind ErrorKind) {
+	if reg == nil {
+		return
+	}
+	reg.IncCounter("scoracle_seed_errors_total", "Seed failures, labeled by sport and ErrorKind.",
+		map[string]string{"sport": sport, "kind": string(kind)})
+}
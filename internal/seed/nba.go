@@ -2,8 +2,13 @@ package seed
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"log/slog"
+	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 
 	"github.com/albapepper/scoracle-data/internal/provider"
@@ -12,69 +17,197 @@ import (
 
 const sportNBA = "NBA"
 
+// nbaPhases is the fixed execution order SeedNBA runs and checkpoints
+// against; see ResumePlan.
+var nbaPhases = []string{"teams", "player_stats", "team_stats"}
+
 // SeedNBA runs the full NBA seed flow: teams -> player stats -> team stats.
 // Player profiles are upserted automatically during the player stats phase.
-func SeedNBA(ctx context.Context, pool *pgxpool.Pool, handler *bdl.NBAHandler, season int, logger *slog.Logger) SeedResult {
+//
+// cp and state checkpoint progress so an interrupted run can skip phases
+// already completed on a later --resume; pass NoopCheckpointer{} and the
+// state StartRun returns for a plain one-shot run (state.Phase == "" means
+// nothing to skip). dryRun fetches from the provider as normal but skips
+// every database write, for verifying provider responses in CI.
+func SeedNBA(ctx context.Context, pool *pgxpool.Pool, handler *bdl.NBAHandler, season int, logger *slog.Logger, cp Checkpointer, state *RunState, dryRun bool) SeedResult {
 	var result SeedResult
+	runID := state.RunID
+	skip := ResumePlan(state, nbaPhases)
 
-	// 1. Teams
+	// 1. Teams. Always fetched (phase 2 needs teamIDs below), but the
+	// upsert loop and checkpoint are skipped if a prior attempt already
+	// completed this phase. The fetch itself is conditional on a persisted
+	// provider.Cursor: if BallDontLie reports the team list unchanged since
+	// our last call (304), we skip straight to the cached team list we
+	// saved alongside that cursor instead of re-upserting anything.
+	phaseStart := time.Now()
 	logger.Info("Seeding NBA teams...")
-	teams, err := handler.GetTeams(ctx)
+	teamsCursor, err := GetProviderCursor(ctx, pool, "bdl_nba", "teams")
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		logger.Warn("load NBA teams cursor failed, fetching in full", "error", err)
+	}
+	teams, newTeamsCursor, notModified, err := handler.GetTeamsCached(ctx, teamsCursor)
 	if err != nil {
-		result.AddErrorf("fetch NBA teams: %v", err)
+		result.AddErr(sportNBA, "fetch NBA teams", err)
 		return result
 	}
-	for _, team := range teams {
-		if err := UpsertTeam(ctx, pool, sportNBA, team); err != nil {
-			result.AddErrorf("upsert team %d: %v", team.ID, err)
-		} else {
-			result.TeamsUpserted++
+	if notModified {
+		result.TeamsSkipped++
+		if err := json.Unmarshal(teamsCursor.Payload, &teams); err != nil {
+			logger.Warn("unreadable cached NBA teams payload, will refetch in full next run", "error", err)
+		}
+		logger.Info("NBA teams unchanged since last run, skipping upsert", "count", len(teams))
+	} else {
+		if !skip["teams"] {
+			for _, team := range teams {
+				if dryRun {
+					result.TeamsUpserted++
+					continue
+				}
+				if err := UpsertTeam(ctx, pool, sportNBA, team); err != nil {
+					result.AddErr(sportNBA, fmt.Sprintf("upsert team %d", team.ID), err)
+				} else {
+					result.TeamsUpserted++
+				}
+			}
+			logger.Info("NBA teams done", "count", result.TeamsUpserted, "dry_run", dryRun)
+			observeSeedPhase(sportNBA, "teams", phaseStart)
+			observeSeedRows(sportNBA, "team", result.TeamsUpserted)
+			_ = cp.CompletePhase(ctx, runID, "teams")
+		}
+		if payload, err := json.Marshal(teams); err == nil {
+			newTeamsCursor.Payload = payload
+		}
+		if err := SaveProviderCursor(ctx, pool, "bdl_nba", "teams", newTeamsCursor); err != nil {
+			logger.Warn("save NBA teams cursor failed", "error", err)
 		}
 	}
-	logger.Info("NBA teams done", "count", result.TeamsUpserted)
 
-	// 2. Player stats (profiles are auto-upserted)
-	logger.Info("Seeding NBA player stats...", "season", season)
-	count := 0
-	err = handler.GetPlayerStats(ctx, season, "regular", func(ps provider.PlayerStats) error {
-		if ps.Player != nil {
-			if err := UpsertPlayer(ctx, pool, sportNBA, *ps.Player); err != nil {
-				result.AddErrorf("upsert player %d: %v", ps.PlayerID, err)
-			} else {
-				result.PlayersUpserted++
+	// 2. Player stats (profiles are auto-upserted), fetched one team at a
+	// time via GetPlayerStatsByTeamsParallel rather than the whole-league
+	// GetPlayerStats, so a resumed run can skip teams a prior attempt
+	// already finished (see teamCursor, shared with SeedFootballSeason),
+	// while still pipelining each team's own pages.
+	teamIDs := make([]int, len(teams))
+	for i, t := range teams {
+		teamIDs[i] = t.ID
+	}
+
+	if !skip["player_stats"] {
+		phaseStart = time.Now()
+		logger.Info("Seeding NBA player stats...", "season", season)
+
+		// Upsert the full current roster first, pipelined via
+		// GetPlayersParallel, so a player with no stats yet this season
+		// (a rookie, or someone rostered but not yet active) still gets a
+		// profile row — GetPlayerStatsByTeamsParallel below only creates
+		// profiles for players it finds stats for.
+		if !dryRun {
+			logger.Info("Seeding NBA player roster...")
+			if err := handler.GetPlayersParallel(ctx, 0, func(p provider.Player) error {
+				if err := UpsertPlayer(ctx, pool, sportNBA, p); err != nil {
+					result.AddErr(sportNBA, fmt.Sprintf("upsert roster player %d", p.ID), err)
+				}
+				return nil
+			}); err != nil {
+				result.AddErr(sportNBA, "fetch NBA player roster", err)
 			}
 		}
-		if err := UpsertPlayerStats(ctx, pool, sportNBA, season, 0, ps); err != nil {
-			result.AddErrorf("upsert player stats %d: %v", ps.PlayerID, err)
-		} else {
-			result.PlayerStatsUpserted++
+
+		pendingTeamIDs := teamIDs
+		if state.Phase == "player_stats" && len(state.LastCursor) > 0 {
+			var cursor teamCursor
+			if err := json.Unmarshal(state.LastCursor, &cursor); err != nil {
+				logger.Warn("unreadable player_stats cursor, refetching all teams", "error", err)
+			} else {
+				doneTeams := make(map[int]bool, len(cursor.Done))
+				for _, id := range cursor.Done {
+					doneTeams[id] = true
+				}
+				pendingTeamIDs = nil
+				for _, id := range teamIDs {
+					if !doneTeams[id] {
+						pendingTeamIDs = append(pendingTeamIDs, id)
+					}
+				}
+				logger.Info("Resuming NBA player_stats", "teams_done", len(cursor.Done), "teams_pending", len(pendingTeamIDs))
+			}
 		}
-		count++
-		if count%50 == 0 {
-			logger.Info("NBA player stats progress", "processed", count)
+
+		count := 0
+		doneTeamIDs := make([]int, 0, len(pendingTeamIDs))
+		for _, teamID := range pendingTeamIDs {
+			err := handler.GetPlayerStatsByTeamsParallel(ctx, 0, season, []int{teamID}, "regular", func(ps provider.PlayerStats) error {
+				if ps.Player != nil && !dryRun {
+					if err := UpsertPlayer(ctx, pool, sportNBA, *ps.Player); err != nil {
+						result.AddErr(sportNBA, fmt.Sprintf("upsert player %d", ps.PlayerID), err)
+					} else {
+						result.PlayersUpserted++
+					}
+				} else if ps.Player != nil {
+					result.PlayersUpserted++
+				}
+				if !dryRun {
+					if changed, err := UpsertPlayerStats(ctx, pool, sportNBA, season, 0, ps); err != nil {
+						result.AddErr(sportNBA, fmt.Sprintf("upsert player stats %d", ps.PlayerID), err)
+					} else {
+						result.PlayerStatsUpserted++
+						if changed {
+							result.PlayerStatsChanged++
+						} else {
+							result.PlayerStatsSkipped++
+						}
+					}
+				} else {
+					result.PlayerStatsUpserted++
+				}
+				count++
+				return nil
+			})
+			if err != nil {
+				result.AddErr(sportNBA, fmt.Sprintf("fetch NBA player stats for team %d", teamID), err)
+			}
+			doneTeamIDs = append(doneTeamIDs, teamID)
+			logger.Info("NBA player stats progress", "processed", count, "teams_done", len(doneTeamIDs))
+			_ = cp.SaveCursor(ctx, runID, "player_stats", teamCursor{Done: append([]int(nil), doneTeamIDs...)})
 		}
-		return nil
-	})
-	if err != nil {
-		result.AddErrorf("fetch NBA player stats: %v", err)
+		logger.Info("NBA player stats done", "count", result.PlayerStatsUpserted, "dry_run", dryRun)
+		observeSeedPhase(sportNBA, "player_stats", phaseStart)
+		observeSeedRows(sportNBA, "player", result.PlayersUpserted)
+		observeSeedRows(sportNBA, "player_stats", result.PlayerStatsUpserted)
+		_ = cp.CompletePhase(ctx, runID, "player_stats")
 	}
-	logger.Info("NBA player stats done", "count", result.PlayerStatsUpserted)
 
 	// 3. Team stats
-	logger.Info("Seeding NBA team stats...", "season", season)
-	teamStats, err := handler.GetTeamStats(ctx, season, "regular")
-	if err != nil {
-		result.AddErrorf("fetch NBA team stats: %v", err)
-		return result
-	}
-	for _, ts := range teamStats {
-		if err := UpsertTeamStats(ctx, pool, sportNBA, season, 0, ts); err != nil {
-			result.AddErrorf("upsert team stats %d: %v", ts.TeamID, err)
-		} else {
-			result.TeamStatsUpserted++
+	if !skip["team_stats"] {
+		phaseStart = time.Now()
+		logger.Info("Seeding NBA team stats...", "season", season)
+		teamStats, err := handler.GetTeamStats(ctx, season, "regular")
+		if err != nil {
+			result.AddErr(sportNBA, "fetch NBA team stats", err)
+			return result
+		}
+		for _, ts := range teamStats {
+			if dryRun {
+				result.TeamStatsUpserted++
+				continue
+			}
+			if changed, err := UpsertTeamStats(ctx, pool, sportNBA, season, 0, ts); err != nil {
+				result.AddErr(sportNBA, fmt.Sprintf("upsert team stats %d", ts.TeamID), err)
+			} else {
+				result.TeamStatsUpserted++
+				if changed {
+					result.TeamStatsChanged++
+				} else {
+					result.TeamStatsSkipped++
+				}
+			}
 		}
+		logger.Info("NBA team stats done", "count", result.TeamStatsUpserted, "dry_run", dryRun)
+		observeSeedPhase(sportNBA, "team_stats", phaseStart)
+		observeSeedRows(sportNBA, "team_stats", result.TeamStatsUpserted)
+		_ = cp.CompletePhase(ctx, runID, "team_stats")
 	}
-	logger.Info("NBA team stats done", "count", result.TeamStatsUpserted)
 
 	logger.Info("NBA seed complete", "summary", result.Summary())
 	return result
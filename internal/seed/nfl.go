@@ -0,0 +1,173 @@
+package seed
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/albapepper/scoracle-data/internal/provider"
+	"github.com/albapepper/scoracle-data/internal/provider/bdl"
+)
+
+const sportNFL = "NFL"
+
+// nflPhases is the fixed execution order SeedNFL runs and checkpoints
+// against; see ResumePlan.
+var nflPhases = []string{"teams", "player_stats", "team_stats"}
+
+// SeedNFL runs the full NFL seed flow: teams -> player stats -> team stats.
+// Player profiles are upserted automatically during the player stats phase.
+// Mirrors SeedNBA's structure, but bdl.NFLHandler has no conditional-request
+// cache for teams (unlike NBAHandler.GetTeamsCached), so there is no
+// cursor/not-modified dance around the teams fetch here.
+//
+// cp and state checkpoint progress so an interrupted run can skip phases
+// already completed on a later --resume; pass NoopCheckpointer{} and the
+// state StartRun returns for a plain one-shot run (state.Phase == "" means
+// nothing to skip). dryRun fetches from the provider as normal but skips
+// every database write, for verifying provider responses in CI.
+func SeedNFL(ctx context.Context, pool *pgxpool.Pool, handler *bdl.NFLHandler, season int, logger *slog.Logger, cp Checkpointer, state *RunState, dryRun bool) SeedResult {
+	var result SeedResult
+	runID := state.RunID
+	skip := ResumePlan(state, nflPhases)
+
+	// 1. Teams
+	phaseStart := time.Now()
+	logger.Info("Seeding NFL teams...")
+	teams, err := handler.GetTeams(ctx)
+	if err != nil {
+		result.AddErr(sportNFL, "fetch NFL teams", err)
+		return result
+	}
+	if !skip["teams"] {
+		for _, team := range teams {
+			if dryRun {
+				result.TeamsUpserted++
+				continue
+			}
+			if err := UpsertTeam(ctx, pool, sportNFL, team); err != nil {
+				result.AddErr(sportNFL, fmt.Sprintf("upsert team %d", team.ID), err)
+			} else {
+				result.TeamsUpserted++
+			}
+		}
+		logger.Info("NFL teams done", "count", result.TeamsUpserted, "dry_run", dryRun)
+		observeSeedPhase(sportNFL, "teams", phaseStart)
+		observeSeedRows(sportNFL, "team", result.TeamsUpserted)
+		_ = cp.CompletePhase(ctx, runID, "teams")
+	}
+
+	// 2. Player stats (profiles are auto-upserted), fetched one team at a
+	// time via GetPlayerStatsByTeams so a resumed run can skip teams a
+	// prior attempt already finished (teamCursor is shared with SeedNBA).
+	teamIDs := make([]int, len(teams))
+	for i, t := range teams {
+		teamIDs[i] = t.ID
+	}
+
+	if !skip["player_stats"] {
+		phaseStart = time.Now()
+		logger.Info("Seeding NFL player stats...", "season", season)
+
+		pendingTeamIDs := teamIDs
+		if state.Phase == "player_stats" && len(state.LastCursor) > 0 {
+			var cursor teamCursor
+			if err := json.Unmarshal(state.LastCursor, &cursor); err != nil {
+				logger.Warn("unreadable player_stats cursor, refetching all teams", "error", err)
+			} else {
+				doneTeams := make(map[int]bool, len(cursor.Done))
+				for _, id := range cursor.Done {
+					doneTeams[id] = true
+				}
+				pendingTeamIDs = nil
+				for _, id := range teamIDs {
+					if !doneTeams[id] {
+						pendingTeamIDs = append(pendingTeamIDs, id)
+					}
+				}
+				logger.Info("Resuming NFL player_stats", "teams_done", len(cursor.Done), "teams_pending", len(pendingTeamIDs))
+			}
+		}
+
+		count := 0
+		doneTeamIDs := make([]int, 0, len(pendingTeamIDs))
+		for _, teamID := range pendingTeamIDs {
+			err := handler.GetPlayerStatsByTeams(ctx, season, []int{teamID}, false, func(ps provider.PlayerStats) error {
+				if ps.Player != nil && !dryRun {
+					if err := UpsertPlayer(ctx, pool, sportNFL, *ps.Player); err != nil {
+						result.AddErr(sportNFL, fmt.Sprintf("upsert player %d", ps.PlayerID), err)
+					} else {
+						result.PlayersUpserted++
+					}
+				} else if ps.Player != nil {
+					result.PlayersUpserted++
+				}
+				if !dryRun {
+					if changed, err := UpsertPlayerStats(ctx, pool, sportNFL, season, 0, ps); err != nil {
+						result.AddErr(sportNFL, fmt.Sprintf("upsert player stats %d", ps.PlayerID), err)
+					} else {
+						result.PlayerStatsUpserted++
+						if changed {
+							result.PlayerStatsChanged++
+						} else {
+							result.PlayerStatsSkipped++
+						}
+					}
+				} else {
+					result.PlayerStatsUpserted++
+				}
+				count++
+				return nil
+			})
+			if err != nil {
+				result.AddErr(sportNFL, fmt.Sprintf("fetch NFL player stats for team %d", teamID), err)
+			}
+			doneTeamIDs = append(doneTeamIDs, teamID)
+			logger.Info("NFL player stats progress", "processed", count, "teams_done", len(doneTeamIDs))
+			_ = cp.SaveCursor(ctx, runID, "player_stats", teamCursor{Done: append([]int(nil), doneTeamIDs...)})
+		}
+		logger.Info("NFL player stats done", "count", result.PlayerStatsUpserted, "dry_run", dryRun)
+		observeSeedPhase(sportNFL, "player_stats", phaseStart)
+		observeSeedRows(sportNFL, "player", result.PlayersUpserted)
+		observeSeedRows(sportNFL, "player_stats", result.PlayerStatsUpserted)
+		_ = cp.CompletePhase(ctx, runID, "player_stats")
+	}
+
+	// 3. Team stats
+	if !skip["team_stats"] {
+		phaseStart = time.Now()
+		logger.Info("Seeding NFL team stats...", "season", season)
+		teamStats, err := handler.GetTeamStats(ctx, season, "regular")
+		if err != nil {
+			result.AddErr(sportNFL, "fetch NFL team stats", err)
+			return result
+		}
+		for _, ts := range teamStats {
+			if dryRun {
+				result.TeamStatsUpserted++
+				continue
+			}
+			if changed, err := UpsertTeamStats(ctx, pool, sportNFL, season, 0, ts); err != nil {
+				result.AddErr(sportNFL, fmt.Sprintf("upsert team stats %d", ts.TeamID), err)
+			} else {
+				result.TeamStatsUpserted++
+				if changed {
+					result.TeamStatsChanged++
+				} else {
+					result.TeamStatsSkipped++
+				}
+			}
+		}
+		logger.Info("NFL team stats done", "count", result.TeamStatsUpserted, "dry_run", dryRun)
+		observeSeedPhase(sportNFL, "team_stats", phaseStart)
+		observeSeedRows(sportNFL, "team_stats", result.TeamStatsUpserted)
+		_ = cp.CompletePhase(ctx, runID, "team_stats")
+	}
+
+	logger.Info("NFL seed complete", "summary", result.Summary())
+	return result
+}
@@ -4,39 +4,59 @@ package seed
 import "fmt"
 
 // SeedResult tracks counts and errors from a seeding operation.
+//
+// Changed/Skipped pairs distinguish rows actually written from rows a
+// provider reported unchanged: PlayerStatsChanged/TeamStatsChanged count
+// upserts where row_hash gating (see UpsertPlayerStats) found the stats
+// actually differed; PlayerStatsSkipped/TeamStatsSkipped count the rest —
+// fetched and hashed, but left untouched. TeamsSkipped counts phases a
+// provider's conditional-request cache (see provider.Cursor) reported
+// unmodified via 304, so the fetch itself was skipped rather than just the
+// write.
 type SeedResult struct {
 	TeamsUpserted       int
+	TeamsSkipped        int
 	PlayersUpserted     int
 	PlayerStatsUpserted int
+	PlayerStatsChanged  int
+	PlayerStatsSkipped  int
 	TeamStatsUpserted   int
-	Errors              []string
+	TeamStatsChanged    int
+	TeamStatsSkipped    int
+	Errors              []Error
 }
 
 // Add merges another SeedResult into this one.
 func (r *SeedResult) Add(other SeedResult) {
 	r.TeamsUpserted += other.TeamsUpserted
+	r.TeamsSkipped += other.TeamsSkipped
 	r.PlayersUpserted += other.PlayersUpserted
 	r.PlayerStatsUpserted += other.PlayerStatsUpserted
+	r.PlayerStatsChanged += other.PlayerStatsChanged
+	r.PlayerStatsSkipped += other.PlayerStatsSkipped
 	r.TeamStatsUpserted += other.TeamStatsUpserted
+	r.TeamStatsChanged += other.TeamStatsChanged
+	r.TeamStatsSkipped += other.TeamStatsSkipped
 	r.Errors = append(r.Errors, other.Errors...)
 }
 
-// AddError records an error message.
-func (r *SeedResult) AddError(msg string) {
-	r.Errors = append(r.Errors, msg)
-}
-
-// AddErrorf records a formatted error message.
-func (r *SeedResult) AddErrorf(format string, args ...interface{}) {
-	r.Errors = append(r.Errors, fmt.Sprintf(format, args...))
+// AddErr records a failed operation, classifying err into an ErrorKind (see
+// classifyKind) and recording scoracle_seed_errors_total{sport,kind} so
+// operators can alert on specific failure classes instead of grepping
+// Errors' message text.
+func (r *SeedResult) AddErr(sport, op string, err error) {
+	kind := classifyKind(err)
+	r.Errors = append(r.Errors, Error{Kind: kind, Op: op, Err: err})
+	observeSeedError(sport, kind)
 }
 
 // Summary returns a human-readable summary of the seed operation.
 func (r *SeedResult) Summary() string {
 	return fmt.Sprintf(
-		"teams=%d players=%d player_stats=%d team_stats=%d errors=%d",
-		r.TeamsUpserted, r.PlayersUpserted,
-		r.PlayerStatsUpserted, r.TeamStatsUpserted,
+		"teams=%d teams_skipped=%d players=%d player_stats=%d (changed=%d skipped=%d) team_stats=%d (changed=%d skipped=%d) errors=%d",
+		r.TeamsUpserted, r.TeamsSkipped, r.PlayersUpserted,
+		r.PlayerStatsUpserted, r.PlayerStatsChanged, r.PlayerStatsSkipped,
+		r.TeamStatsUpserted, r.TeamStatsChanged, r.TeamStatsSkipped,
 		len(r.Errors),
 	)
 }
@@ -0,0 +1,229 @@
+package seed
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/albapepper/scoracle-data/internal/config"
+	"github.com/albapepper/scoracle-data/internal/db"
+	"github.com/albapepper/scoracle-data/internal/provider"
+	"github.com/albapepper/scoracle-data/internal/provider/schema"
+)
+
+// UpsertTeam writes a canonical team to the teams table.
+func UpsertTeam(ctx context.Context, pool *pgxpool.Pool, sport string, team provider.Team) error {
+	start := time.Now()
+	meta, _ := json.Marshal(nonNilMap(team.Meta))
+	_, err := pool.Exec(ctx, `
+		INSERT INTO `+config.TeamsTable+` (
+			id, sport, name, short_code, city, country, conference,
+			division, venue_name, venue_capacity, founded, logo_url, meta
+		) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13)
+		ON CONFLICT (id, sport) DO UPDATE SET
+			name = EXCLUDED.name,
+			short_code = EXCLUDED.short_code,
+			city = EXCLUDED.city,
+			country = EXCLUDED.country,
+			conference = EXCLUDED.conference,
+			division = EXCLUDED.division,
+			venue_name = EXCLUDED.venue_name,
+			venue_capacity = EXCLUDED.venue_capacity,
+			founded = EXCLUDED.founded,
+			logo_url = EXCLUDED.logo_url,
+			meta = EXCLUDED.meta,
+			updated_at = NOW()`,
+		team.ID, sport, team.Name, nilEmpty(team.ShortCode), nilEmpty(team.City),
+		nilEmpty(team.Country), nilEmpty(team.Conference), nilEmpty(team.Division),
+		nilEmpty(team.VenueName), team.VenueCapacity, team.Founded,
+		nilEmpty(team.LogoURL), meta,
+	)
+	observeUpsert(config.TeamsTable, sport, start, err)
+	return err
+}
+
+// UpsertPlayer writes a canonical player to the players table.
+func UpsertPlayer(ctx context.Context, pool *pgxpool.Pool, sport string, player provider.Player) error {
+	start := time.Now()
+	meta, _ := json.Marshal(nonNilMap(player.Meta))
+	_, err := pool.Exec(ctx, `
+		INSERT INTO `+config.PlayersTable+` (
+			id, sport, name, first_name, last_name, position,
+			detailed_position, nationality, height, weight,
+			date_of_birth, photo_url, team_id, meta
+		) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14)
+		ON CONFLICT (id, sport) DO UPDATE SET
+			name = COALESCE(EXCLUDED.name, `+config.PlayersTable+`.name),
+			first_name = COALESCE(EXCLUDED.first_name, `+config.PlayersTable+`.first_name),
+			last_name = COALESCE(EXCLUDED.last_name, `+config.PlayersTable+`.last_name),
+			position = COALESCE(EXCLUDED.position, `+config.PlayersTable+`.position),
+			detailed_position = COALESCE(EXCLUDED.detailed_position, `+config.PlayersTable+`.detailed_position),
+			nationality = COALESCE(EXCLUDED.nationality, `+config.PlayersTable+`.nationality),
+			height = COALESCE(EXCLUDED.height, `+config.PlayersTable+`.height),
+			weight = COALESCE(EXCLUDED.weight, `+config.PlayersTable+`.weight),
+			date_of_birth = COALESCE(EXCLUDED.date_of_birth, `+config.PlayersTable+`.date_of_birth),
+			photo_url = COALESCE(EXCLUDED.photo_url, `+config.PlayersTable+`.photo_url),
+			team_id = COALESCE(EXCLUDED.team_id, `+config.PlayersTable+`.team_id),
+			meta = COALESCE(EXCLUDED.meta, `+config.PlayersTable+`.meta),
+			updated_at = NOW()`,
+		player.ID, sport, player.Name, nilEmpty(player.FirstName), nilEmpty(player.LastName),
+		nilEmpty(player.Position), nilEmpty(player.DetailedPosition), nilEmpty(player.Nationality),
+		nilEmpty(player.Height), nilEmpty(player.Weight), nilEmpty(player.DateOfBirth),
+		nilEmpty(player.PhotoURL), player.TeamID, meta,
+	)
+	observeUpsert(config.PlayersTable, sport, start, err)
+	return err
+}
+
+// UpsertPlayerStats writes canonical player stats to the player_stats table.
+// Postgres triggers automatically compute derived stats on INSERT/UPDATE.
+//
+// A row_hash of the stats payload is stored alongside the row. The update
+// branch is gated on the hash differing from what's already stored, so
+// unchanged stats never touch the row (no updated_at bump, no downstream
+// work). Returns changed=true when the row was inserted or its stats
+// actually differed, so callers can skip percentile recalculation and
+// notifications when nothing moved.
+func UpsertPlayerStats(ctx context.Context, pool *pgxpool.Pool, sport string, season, leagueID int, data provider.PlayerStats) (changed bool, err error) {
+	start := time.Now()
+	if problems := schema.Validate(sport, data.Stats); len(problems) > 0 {
+		slog.Default().Warn("player stats failed schema validation", "sport", sport, "player_id", data.PlayerID, "problems", problems)
+		observeSeedError(sport, ErrKindSchemaMismatch)
+	}
+	stats, _ := json.Marshal(nonNilMapI(data.Stats))
+	raw := data.Raw
+	if raw == nil {
+		raw = []byte("{}")
+	}
+	hash := statsHash(stats)
+
+	err = pool.QueryRow(ctx, `
+		WITH upsert AS (
+			INSERT INTO `+config.PlayerStatsTable+` (
+				player_id, sport, season, league_id, team_id,
+				stats, raw_response, row_hash
+			) VALUES ($1,$2,$3,$4,$5,$6,$7,$8)
+			ON CONFLICT (player_id, sport, season, league_id) DO UPDATE SET
+				team_id = EXCLUDED.team_id,
+				stats = EXCLUDED.stats,
+				raw_response = EXCLUDED.raw_response,
+				row_hash = EXCLUDED.row_hash,
+				updated_at = NOW()
+			WHERE `+config.PlayerStatsTable+`.row_hash IS DISTINCT FROM EXCLUDED.row_hash
+			RETURNING 1
+		)
+		SELECT EXISTS(SELECT 1 FROM upsert)`,
+		data.PlayerID, sport, season, leagueID, data.TeamID,
+		stats, raw, hash,
+	).Scan(&changed)
+	observeUpsert(config.PlayerStatsTable, sport, start, err)
+	if err != nil {
+		return false, err
+	}
+	if changed {
+		notifyStatsUpdated(ctx, pool, "player", data.PlayerID, sport, season, leagueID)
+	}
+	return changed, nil
+}
+
+// UpsertTeamStats writes canonical team stats to the team_stats table.
+// Postgres triggers automatically compute derived stats on INSERT/UPDATE.
+// See UpsertPlayerStats for the row_hash changed/unchanged gating.
+func UpsertTeamStats(ctx context.Context, pool *pgxpool.Pool, sport string, season, leagueID int, data provider.TeamStats) (changed bool, err error) {
+	start := time.Now()
+	if problems := schema.Validate(sport, data.Stats); len(problems) > 0 {
+		slog.Default().Warn("team stats failed schema validation", "sport", sport, "team_id", data.TeamID, "problems", problems)
+		observeSeedError(sport, ErrKindSchemaMismatch)
+	}
+	stats, _ := json.Marshal(nonNilMapI(data.Stats))
+	raw := data.Raw
+	if raw == nil {
+		raw = []byte("{}")
+	}
+	hash := statsHash(stats)
+
+	err = pool.QueryRow(ctx, `
+		WITH upsert AS (
+			INSERT INTO `+config.TeamStatsTable+` (
+				team_id, sport, season, league_id,
+				stats, raw_response, row_hash
+			) VALUES ($1,$2,$3,$4,$5,$6,$7)
+			ON CONFLICT (team_id, sport, season, league_id) DO UPDATE SET
+				stats = EXCLUDED.stats,
+				raw_response = EXCLUDED.raw_response,
+				row_hash = EXCLUDED.row_hash,
+				updated_at = NOW()
+			WHERE `+config.TeamStatsTable+`.row_hash IS DISTINCT FROM EXCLUDED.row_hash
+			RETURNING 1
+		)
+		SELECT EXISTS(SELECT 1 FROM upsert)`,
+		data.TeamID, sport, season, leagueID,
+		stats, raw, hash,
+	).Scan(&changed)
+	observeUpsert(config.TeamStatsTable, sport, start, err)
+	if err != nil {
+		return false, err
+	}
+	if changed {
+		notifyStatsUpdated(ctx, pool, "team", data.TeamID, sport, season, leagueID)
+	}
+	return changed, nil
+}
+
+// RecalculatePercentiles triggers the Postgres percentile calculation function.
+func RecalculatePercentiles(ctx context.Context, pool *pgxpool.Pool, sport string, season int) (playersUpdated, teamsUpdated int, err error) {
+	err = db.QueryRowRecalculatePercentiles(ctx, pool, sport, season).Scan(&playersUpdated, &teamsUpdated)
+	if err != nil {
+		return 0, 0, fmt.Errorf("recalculate percentiles: %w", err)
+	}
+	return playersUpdated, teamsUpdated, nil
+}
+
+// --------------------------------------------------------------------------
+// Helpers
+// --------------------------------------------------------------------------
+
+// statsHash returns a stable hex digest of a stats JSON payload, used to
+// detect whether a provider's reported stats actually changed since the
+// last seed.
+func statsHash(stats []byte) string {
+	sum := sha256.Sum256(stats)
+	return hex.EncodeToString(sum[:])
+}
+
+// nilEmpty returns nil for empty strings (maps to SQL NULL).
+func nilEmpty(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+// nonNilMap ensures a nil map becomes an empty map for JSON marshaling.
+func nonNilMap(m map[string]interface{}) map[string]interface{} {
+	if m == nil {
+		return map[string]interface{}{}
+	}
+	return m
+}
+
+// nonNilMapI is the same as nonNilMap (Go uses the same type).
+func nonNilMapI(m map[string]interface{}) map[string]interface{} {
+	return nonNilMap(m)
+}
+
+// notifyStatsUpdated fires pg_notify(stats_updated, ...) with the same cache
+// key Handler.GetEntityStats uses, so internal/statstream can push a fresh
+// SSE event to any client subscribed to that entity/season/league. Best
+// effort: a failed notify doesn't fail the seed, since the row write already
+// succeeded and the next poll (or SSE reconnect) will still observe it.
+func notifyStatsUpdated(ctx context.Context, pool *pgxpool.Pool, entityType string, entityID int, sport string, season, leagueID int) {
+	cacheKey := fmt.Sprintf("stats:%s:%d:%s:%d:%d", entityType, entityID, sport, season, leagueID)
+	_, _ = db.ExecNotifyStatsUpdated(ctx, pool, cacheKey)
+}
@@ -0,0 +1,16 @@
+package sport
+
+// knownFootballLeagues are the leagues the BL is currently onboarded to:
+// 8=Premier League, 82=Bundesliga, 301=Ligue 1, 384=Serie A, 564=La Liga.
+var knownFootballLeagues = map[int]bool{8: true, 82: true, 301: true, 384: true, 564: true}
+
+func init() {
+	Register(&Definition{
+		Code:          "FOOTBALL",
+		Name:          "Football (Soccer)",
+		CurrentSeason: 2025,
+		ValidateLeagueID: func(leagueID int) bool {
+			return knownFootballLeagues[leagueID]
+		},
+	})
+}
@@ -0,0 +1,10 @@
+package sport
+
+func init() {
+	Register(&Definition{
+		Code:             "NBA",
+		Name:             "National Basketball Association",
+		CurrentSeason:    2025,
+		ValidateLeagueID: func(int) bool { return true }, // BallDontLie has no league concept
+	})
+}
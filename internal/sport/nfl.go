@@ -0,0 +1,10 @@
+package sport
+
+func init() {
+	Register(&Definition{
+		Code:             "NFL",
+		Name:             "National Football League",
+		CurrentSeason:    2025,
+		ValidateLeagueID: func(int) bool { return true }, // BallDontLie has no league concept
+	})
+}
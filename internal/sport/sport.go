@@ -0,0 +1,84 @@
+// Package sport is the extensible registry of everything the API and
+// ingestion CLI need to know about a sport — current season and league-ID
+// validation. Each sport self-registers via Register in an init() (see
+// nba.go, nfl.go, football.go), so adding a new sport (MLB, NHL, MLS, ...)
+// is a single new file rather than edits scattered across handler/config.
+// Full-season seeding itself is handled by the provider-keyed registry in
+// internal/provider/seasonseed, not here — see its doc comment for why
+// that's a separate axis from this one.
+package sport
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Definition describes one sport's config.
+type Definition struct {
+	// Code is the canonical sport identifier (NBA, NFL, FOOTBALL, ...),
+	// matching the `sport` column used throughout the schema.
+	Code string
+	// Name is a human-readable label, returned by /sports.
+	Name string
+	// CurrentSeason is the season year stats/seasons default to when a
+	// request omits ?season=.
+	CurrentSeason int
+	// ValidateLeagueID reports whether leagueID is meaningful for this
+	// sport (e.g. FOOTBALL has many leagues; NBA/NFL effectively have one
+	// and accept any value).
+	ValidateLeagueID func(leagueID int) bool
+}
+
+var (
+	mu     sync.RWMutex
+	sports = map[string]*Definition{}
+)
+
+// Register adds or replaces a sport definition. Called from each sport
+// file's init().
+func Register(d *Definition) {
+	mu.Lock()
+	defer mu.Unlock()
+	sports[d.Code] = d
+}
+
+// Lookup returns the definition for code, if registered.
+func Lookup(code string) (*Definition, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+	d, ok := sports[code]
+	return d, ok
+}
+
+// Codes returns every registered sport code, sorted.
+func Codes() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+	codes := make([]string, 0, len(sports))
+	for c := range sports {
+		codes = append(codes, c)
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+// CurrentSeason returns the registered current season for code, or this
+// calendar year if code isn't registered.
+func CurrentSeason(code string) int {
+	if d, ok := Lookup(code); ok {
+		return d.CurrentSeason
+	}
+	return time.Now().Year()
+}
+
+// ValidateLeagueID reports whether leagueID is valid for code. Unregistered
+// sports accept anything — validation is a courtesy, not a security
+// boundary.
+func ValidateLeagueID(code string, leagueID int) bool {
+	d, ok := Lookup(code)
+	if !ok || d.ValidateLeagueID == nil {
+		return true
+	}
+	return d.ValidateLeagueID(leagueID)
+}
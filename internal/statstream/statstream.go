@@ -0,0 +1,128 @@
+// Package statstream provides a process-wide Postgres LISTEN/NOTIFY fan-out
+// for stat changes, so the API can push updated stat JSON to connected SSE
+// clients the moment a seed run writes it, instead of clients having to
+// poll. It holds a single dedicated pgx connection (not from the pool, which
+// doesn't expose long-lived LISTEN sessions) and fans notifications out to
+// per-cache-key subscriber channels.
+package statstream
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	channel          = "stats_updated"
+	reconnectBackoff = 5 * time.Second
+	maxReconnect     = 30 * time.Second
+
+	// subscriberBuffer lets a subscriber miss at most one notification while
+	// it's busy re-querying and writing the previous one, without blocking
+	// Broker.publish. Further notifications for the same key while still
+	// full are coalesced (dropped) rather than queued.
+	subscriberBuffer = 1
+)
+
+// Broker fans out stats_updated notifications, keyed by the same cache key
+// used by Handler.cache (e.g. "stats:player:23:NBA:2025:0"), to subscribed
+// SSE handlers.
+type Broker struct {
+	mu   sync.Mutex
+	subs map[string]map[chan struct{}]struct{}
+}
+
+// NewBroker creates an empty Broker.
+func NewBroker() *Broker {
+	return &Broker{subs: make(map[string]map[chan struct{}]struct{})}
+}
+
+// Subscribe registers interest in cacheKey. The returned channel receives a
+// (coalesced) signal each time the key changes; call unsubscribe when done,
+// typically via defer.
+func (b *Broker) Subscribe(cacheKey string) (ch <-chan struct{}, unsubscribe func()) {
+	c := make(chan struct{}, subscriberBuffer)
+
+	b.mu.Lock()
+	set, ok := b.subs[cacheKey]
+	if !ok {
+		set = make(map[chan struct{}]struct{})
+		b.subs[cacheKey] = set
+	}
+	set[c] = struct{}{}
+	b.mu.Unlock()
+
+	return c, func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		delete(b.subs[cacheKey], c)
+		if len(b.subs[cacheKey]) == 0 {
+			delete(b.subs, cacheKey)
+		}
+	}
+}
+
+// publish signals every subscriber of cacheKey. Non-blocking: a subscriber
+// that hasn't drained its previous signal yet just misses this one, since
+// its next re-query will pick up the latest row anyway.
+func (b *Broker) publish(cacheKey string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for c := range b.subs[cacheKey] {
+		select {
+		case c <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// Listen opens a dedicated connection and listens on the stats_updated
+// channel, publishing each notification's payload (a cache key) to b. It
+// reconnects automatically on connection loss. Blocks until ctx is
+// cancelled. Intended to be called with `go`.
+func Listen(ctx context.Context, dbURL string, b *Broker, logger *slog.Logger) {
+	backoff := reconnectBackoff
+
+	for {
+		err := listenLoop(ctx, dbURL, b, logger)
+		if ctx.Err() != nil {
+			logger.Info("Stat stream listener stopped (context cancelled)")
+			return
+		}
+
+		logger.Error("Stat stream listener disconnected, reconnecting...",
+			"error", err, "backoff", backoff)
+
+		select {
+		case <-time.After(backoff):
+			backoff = min(backoff*2, maxReconnect)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func listenLoop(ctx context.Context, dbURL string, b *Broker, logger *slog.Logger) error {
+	conn, err := pgx.Connect(ctx, dbURL)
+	if err != nil {
+		return fmt.Errorf("connect: %w", err)
+	}
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "LISTEN "+channel); err != nil {
+		return fmt.Errorf("LISTEN %s: %w", channel, err)
+	}
+	logger.Info("Stat stream listener connected", "channel", channel)
+
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return fmt.Errorf("wait for notification: %w", err)
+		}
+		b.publish(notification.Payload)
+	}
+}
@@ -0,0 +1,202 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	deliverTimeout       = 10 * time.Second
+	defaultMaxRetries    = 3
+	deliverBaseDelay     = 2 * time.Second
+	deliverMaxDelay      = 5 * time.Minute
+	maxResponseBodyBytes = 4 * 1024
+)
+
+// Signature/timestamp/event headers, mirroring the Stripe/GitHub pattern so
+// a subscriber can use an off-the-shelf verification library.
+const (
+	SignatureHeader = "X-Scoracle-Signature"
+	TimestampHeader = "X-Scoracle-Timestamp"
+	EventHeader     = "X-Scoracle-Event"
+)
+
+var httpClient = &http.Client{Timeout: deliverTimeout}
+
+// Notify asynchronously delivers payload, marshaled to JSON, to every active
+// subscription whose event_types include eventType. Fire-and-forget: the
+// fixture scheduler run that triggered this doesn't wait on delivery, which
+// may retry for minutes against a slow or dead endpoint — see
+// fixture.ProcessPending and fixture.SeedFixture's call sites. Delivery runs
+// against a background context rather than the caller's, so it isn't
+// canceled when a request-scoped ctx (e.g. the admin fixture-stream
+// endpoint) ends before retries are exhausted.
+func Notify(ctx context.Context, pool *pgxpool.Pool, logger *slog.Logger, eventType string, payload interface{}) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		logger.Warn("webhook: marshal event payload failed", "event", eventType, "error", err)
+		return
+	}
+	go deliverToSubscribers(context.Background(), pool, logger, eventType, body)
+}
+
+func deliverToSubscribers(ctx context.Context, pool *pgxpool.Pool, logger *slog.Logger, eventType string, body []byte) {
+	subs, err := activeSubscriptionsFor(ctx, pool, eventType)
+	if err != nil {
+		logger.Warn("webhook: list subscriptions failed", "event", eventType, "error", err)
+		return
+	}
+	for _, sub := range subs {
+		deliverWithRetries(ctx, pool, logger, sub, eventType, body)
+	}
+}
+
+// deliverWithRetries POSTs body to sub.URL, retrying on deliverBackoff up to
+// defaultMaxRetries times, recording every attempt via recordDelivery
+// regardless of outcome.
+func deliverWithRetries(ctx context.Context, pool *pgxpool.Pool, logger *slog.Logger, sub Subscription, eventType string, body []byte) {
+	for attempt := 1; attempt <= defaultMaxRetries; attempt++ {
+		if attempt > 1 {
+			select {
+			case <-time.After(deliverBackoff(attempt - 1)):
+			case <-ctx.Done():
+				return
+			}
+		}
+
+		statusCode, respBody, err := send(ctx, sub, eventType, body)
+		success := err == nil && statusCode >= 200 && statusCode < 300
+
+		if recErr := recordDelivery(ctx, pool, Delivery{
+			SubscriptionID: sub.ID,
+			EventType:      eventType,
+			Payload:        body,
+			Attempt:        attempt,
+			StatusCode:     statusCode,
+			ResponseBody:   respBody,
+			Success:        success,
+		}); recErr != nil {
+			logger.Warn("webhook: record delivery failed", "subscription_id", sub.ID, "error", recErr)
+		}
+
+		if success {
+			observeDelivery(eventType, "success")
+			return
+		}
+		if err != nil {
+			observeDelivery(eventType, "error")
+			logger.Warn("webhook: delivery attempt failed", "subscription_id", sub.ID, "event", eventType, "attempt", attempt, "error", err)
+		} else {
+			observeDelivery(eventType, "rejected")
+			logger.Warn("webhook: delivery rejected", "subscription_id", sub.ID, "event", eventType, "attempt", attempt, "status", statusCode)
+		}
+	}
+}
+
+// send POSTs one signed request and returns the response's status code and
+// (truncated) body. err is non-nil only for a transport-level failure —
+// a non-2xx response is a normal return, not an error, so the caller can
+// still record it.
+func send(ctx context.Context, sub Subscription, eventType string, body []byte) (statusCode int, responseBody string, err error) {
+	ts := strconv.FormatInt(time.Now().Unix(), 10)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sub.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, "", fmt.Errorf("build delivery request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(SignatureHeader, "sha256="+sign(sub.Secret, ts, body))
+	req.Header.Set(TimestampHeader, ts)
+	req.Header.Set(EventHeader, eventType)
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return 0, "", fmt.Errorf("delivery post: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, maxResponseBodyBytes))
+	return resp.StatusCode, string(respBody), nil
+}
+
+// sign returns the hex-encoded HMAC-SHA256 of timestamp + "." + body, keyed
+// by secret — the same signed-timestamp construction Stripe and GitHub use,
+// so a subscriber can reject stale or replayed deliveries by checking
+// TimestampHeader's age before trusting SignatureHeader.
+func sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// deliverBackoff returns the exponential backoff (base 2s, cap 5m) with full
+// jitter for the given attempt count (1-indexed) — the same shape as
+// newswatch.deliverBackoff/fixture.backoffDelay.
+func deliverBackoff(attempt int) time.Duration {
+	if attempt < 1 {
+		attempt = 1
+	}
+	raw := float64(deliverBaseDelay) * math.Pow(2, float64(attempt-1))
+	if raw > float64(deliverMaxDelay) {
+		raw = float64(deliverMaxDelay)
+	}
+	return time.Duration(rand.Int63n(int64(raw) + 1))
+}
+
+// Replay re-sends a past delivery's original payload to its subscription
+// under a fresh attempt, for POST /admin/webhooks/{id}/replay. Looks up the
+// subscription's current URL/secret rather than reusing whatever was true
+// at the original delivery time, so a rotated secret or updated endpoint is
+// honored.
+func Replay(ctx context.Context, pool *pgxpool.Pool, deliveryID int) (Delivery, error) {
+	d, err := GetDelivery(ctx, pool, deliveryID)
+	if err != nil {
+		return Delivery{}, err
+	}
+	sub, err := getSubscription(ctx, pool, d.SubscriptionID)
+	if err != nil {
+		return Delivery{}, err
+	}
+
+	statusCode, respBody, sendErr := send(ctx, sub, d.EventType, d.Payload)
+	success := sendErr == nil && statusCode >= 200 && statusCode < 300
+
+	replayed := Delivery{
+		SubscriptionID: sub.ID,
+		EventType:      d.EventType,
+		Payload:        d.Payload,
+		Attempt:        1,
+		StatusCode:     statusCode,
+		ResponseBody:   respBody,
+		Success:        success,
+	}
+	if err := recordDelivery(ctx, pool, replayed); err != nil {
+		return Delivery{}, fmt.Errorf("record replay delivery: %w", err)
+	}
+	if sendErr != nil {
+		observeDelivery(d.EventType, "error")
+		return replayed, sendErr
+	}
+	if success {
+		observeDelivery(d.EventType, "success")
+	} else {
+		observeDelivery(d.EventType, "rejected")
+	}
+	return replayed, nil
+}
@@ -0,0 +1,23 @@
+package webhook
+
+import "github.com/albapepper/scoracle-data/internal/metrics"
+
+// reg is the process-wide metrics registry, set once at startup via
+// SetMetrics. A nil reg (the default) makes every instrumented call a
+// no-op, mirroring newswatch.SetMetrics.
+var reg *metrics.Registry
+
+// SetMetrics wires a metrics registry into subsequent Notify/Replay calls.
+func SetMetrics(r *metrics.Registry) {
+	reg = r
+}
+
+// observeDelivery counts scoracle_webhook_delivery_total{event,result} for
+// one delivery attempt, including retries and replays.
+func observeDelivery(eventType, result string) {
+	if reg == nil {
+		return
+	}
+	reg.IncCounter("scoracle_webhook_delivery_total", "Webhook delivery attempts, labeled by event type and result.",
+		map[string]string{"event": eventType, "result": result})
+}
@@ -0,0 +1,44 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// recordDelivery inserts one delivery attempt, successful or not, so
+// /admin/webhooks/{id}/replay has the original payload to re-send and an
+// operator can see every attempt's status code and response body.
+func recordDelivery(ctx context.Context, pool *pgxpool.Pool, d Delivery) error {
+	_, err := pool.Exec(ctx, `
+		INSERT INTO webhook_deliveries (
+			subscription_id, event_type, payload, attempt, status_code, response_body, success, delivered_at
+		) VALUES ($1,$2,$3,$4,$5,$6,$7,NOW())`,
+		d.SubscriptionID, d.EventType, d.Payload, d.Attempt, nullableInt(d.StatusCode), d.ResponseBody, d.Success)
+	if err != nil {
+		return fmt.Errorf("insert webhook delivery: %w", err)
+	}
+	return nil
+}
+
+// GetDelivery loads one past delivery attempt by ID, for Replay.
+func GetDelivery(ctx context.Context, pool *pgxpool.Pool, id int) (Delivery, error) {
+	var d Delivery
+	err := pool.QueryRow(ctx, `
+		SELECT id, subscription_id, event_type, payload, attempt, COALESCE(status_code, 0), COALESCE(response_body, ''), success, delivered_at
+		FROM webhook_deliveries
+		WHERE id = $1`, id).Scan(
+		&d.ID, &d.SubscriptionID, &d.EventType, &d.Payload, &d.Attempt, &d.StatusCode, &d.ResponseBody, &d.Success, &d.DeliveredAt)
+	if err != nil {
+		return Delivery{}, fmt.Errorf("get webhook delivery %d: %w", id, err)
+	}
+	return d, nil
+}
+
+func nullableInt(n int) interface{} {
+	if n == 0 {
+		return nil
+	}
+	return n
+}
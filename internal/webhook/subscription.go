@@ -0,0 +1,46 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// activeSubscriptionsFor returns every active subscription whose event_types
+// includes eventType, for Notify to deliver to.
+func activeSubscriptionsFor(ctx context.Context, pool *pgxpool.Pool, eventType string) ([]Subscription, error) {
+	rows, err := pool.Query(ctx, `
+		SELECT id, url, secret, event_types, active, created_at
+		FROM webhook_subscriptions
+		WHERE active AND $1 = ANY(event_types)`, eventType)
+	if err != nil {
+		return nil, fmt.Errorf("list active webhook subscriptions: %w", err)
+	}
+	defer rows.Close()
+
+	var subs []Subscription
+	for rows.Next() {
+		var s Subscription
+		if err := rows.Scan(&s.ID, &s.URL, &s.Secret, &s.EventTypes, &s.Active, &s.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan webhook subscription: %w", err)
+		}
+		subs = append(subs, s)
+	}
+	return subs, rows.Err()
+}
+
+// getSubscription loads one subscription by ID, for Replay to resolve the
+// current URL/secret a dead delivery's subscription might have rotated
+// since the original attempt.
+func getSubscription(ctx context.Context, pool *pgxpool.Pool, id int) (Subscription, error) {
+	var s Subscription
+	err := pool.QueryRow(ctx, `
+		SELECT id, url, secret, event_types, active, created_at
+		FROM webhook_subscriptions
+		WHERE id = $1`, id).Scan(&s.ID, &s.URL, &s.Secret, &s.EventTypes, &s.Active, &s.CreatedAt)
+	if err != nil {
+		return Subscription{}, fmt.Errorf("get webhook subscription %d: %w", id, err)
+	}
+	return s, nil
+}
@@ -0,0 +1,69 @@
+// Package webhook pushes fixture-seeding outcomes to external subscribers —
+// the Astro frontend and any other consumer that wants to react to fresh
+// stats within seconds of a game ending, instead of polling the autofill
+// endpoints. internal/fixture calls Notify once per seeded fixture and once
+// per scheduler run (see scheduler.go); this package looks up every active
+// subscription for that event type, signs a request per subscriber, and
+// delivers with retries, independent of the scheduler run that triggered it.
+//
+// Backed by webhook_subscriptions and webhook_deliveries tables (no
+// migrations directory exists in this repo — see subscription.go and
+// store.go):
+//
+//	CREATE TABLE webhook_subscriptions (
+//	    id           serial PRIMARY KEY,
+//	    url          text NOT NULL,
+//	    secret       text NOT NULL,
+//	    event_types  text[] NOT NULL,
+//	    active       boolean NOT NULL DEFAULT true,
+//	    created_at   timestamptz NOT NULL DEFAULT now()
+//	);
+//
+//	CREATE TABLE webhook_deliveries (
+//	    id              serial PRIMARY KEY,
+//	    subscription_id int NOT NULL REFERENCES webhook_subscriptions(id),
+//	    event_type      text NOT NULL,
+//	    payload         jsonb NOT NULL,
+//	    attempt         int NOT NULL,
+//	    status_code     int,
+//	    response_body   text,
+//	    success         boolean NOT NULL,
+//	    delivered_at    timestamptz NOT NULL DEFAULT now()
+//	);
+package webhook
+
+import "time"
+
+// Event types a subscription's event_types column can list. Mirrors
+// fixture.EventType's fixture_seeded/run_complete naming but namespaced as
+// "fixture." since this package may grow event types for other producers.
+const (
+	EventFixtureSeeded = "fixture.seeded"
+	EventRunComplete   = "fixture.run_complete"
+)
+
+// Subscription is a registered webhook endpoint, active for a subset of
+// event types.
+type Subscription struct {
+	ID         int       `json:"id"`
+	URL        string    `json:"url"`
+	Secret     string    `json:"-"` // never echoed back over the API
+	EventTypes []string  `json:"event_types"`
+	Active     bool      `json:"active"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// Delivery is one recorded attempt to deliver an event to a Subscription,
+// successful or not. Replay re-sends the original Payload under a fresh
+// attempt, recording a new Delivery row rather than mutating this one.
+type Delivery struct {
+	ID             int       `json:"id"`
+	SubscriptionID int       `json:"subscription_id"`
+	EventType      string    `json:"event_type"`
+	Payload        []byte    `json:"-"`
+	Attempt        int       `json:"attempt"`
+	StatusCode     int       `json:"status_code"`
+	ResponseBody   string    `json:"response_body,omitempty"`
+	Success        bool      `json:"success"`
+	DeliveredAt    time.Time `json:"delivered_at"`
+}
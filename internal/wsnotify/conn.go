@@ -0,0 +1,102 @@
+package wsnotify
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	writeWait      = 10 * time.Second
+	pongWait       = 60 * time.Second
+	pingInterval   = (pongWait * 9) / 10
+	maxMessageSize = 4096
+)
+
+// Conn wraps one upgraded WebSocket connection: a buffered send queue plus
+// the read/write pumps that drain it, so a slow client can't block
+// Hub.broadcast.
+type Conn struct {
+	ws     *websocket.Conn
+	hub    *Hub
+	userID string
+	send   chan []byte
+	logger *slog.Logger
+}
+
+// subscribeFrame is the client->server message listing one entity to
+// follow. Clients send one frame per (entity_type, entity_id, sport) tuple
+// they want pushed to this connection.
+type subscribeFrame struct {
+	EntityType string `json:"entity_type"`
+	EntityID   int    `json:"entity_id"`
+	Sport      string `json:"sport"`
+}
+
+// readPump reads subscribe frames from the client until it disconnects,
+// registering each with the hub, and answers pings with pongWait deadline
+// resets so a dead connection is detected within pongWait. Runs until the
+// client disconnects or a read fails; unsubscribes and closes on return.
+func (c *Conn) readPump() {
+	defer func() {
+		c.hub.unsubscribeAll(c)
+		c.ws.Close()
+	}()
+
+	c.ws.SetReadLimit(maxMessageSize)
+	c.ws.SetReadDeadline(time.Now().Add(pongWait))
+	c.ws.SetPongHandler(func(string) error {
+		c.ws.SetReadDeadline(time.Now().Add(pongWait))
+		return nil
+	})
+
+	for {
+		_, data, err := c.ws.ReadMessage()
+		if err != nil {
+			return
+		}
+		var frame subscribeFrame
+		if err := json.Unmarshal(data, &frame); err != nil {
+			c.logger.Warn("invalid ws subscribe frame", "user_id", c.userID, "error", err)
+			continue
+		}
+		c.hub.subscribe(c, frame.EntityType, frame.EntityID, frame.Sport)
+	}
+}
+
+// writePump drains send, forwarding each message as a text frame, and pings
+// on pingInterval to detect dead connections before pongWait expires. Runs
+// until ctx is cancelled, send is closed, or a write fails.
+func (c *Conn) writePump(ctx context.Context) {
+	ticker := time.NewTicker(pingInterval)
+	defer func() {
+		ticker.Stop()
+		c.ws.Close()
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			c.ws.SetWriteDeadline(time.Now().Add(writeWait))
+			c.ws.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+			return
+		case payload, ok := <-c.send:
+			c.ws.SetWriteDeadline(time.Now().Add(writeWait))
+			if !ok {
+				c.ws.WriteMessage(websocket.CloseMessage, []byte{})
+				return
+			}
+			if err := c.ws.WriteMessage(websocket.TextMessage, payload); err != nil {
+				return
+			}
+		case <-ticker.C:
+			c.ws.SetWriteDeadline(time.Now().Add(writeWait))
+			if err := c.ws.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		}
+	}
+}
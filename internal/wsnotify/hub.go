@@ -0,0 +1,106 @@
+// Package wsnotify streams milestone notifications to browser clients over
+// WebSocket — a push channel for web clients that have no APNs/FCM device
+// token to register. A connected client sends a subscribe frame listing the
+// (entity_type, entity_id, sport) tuples it follows, and Hub fans out
+// matching milestone_reached events to every connection subscribed to that
+// tuple, same as notifications.GetFollowers resolves followers for push.
+package wsnotify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sync"
+
+	"github.com/albapepper/scoracle-data/internal/listener"
+)
+
+// connBuffer bounds how many undelivered messages a single connection
+// queues before the oldest is dropped — a slow browser tab shouldn't stall
+// fan-out to every other subscriber of the same entity.
+const connBuffer = 16
+
+// Hub fans milestone notifications out to subscribed WebSocket connections,
+// keyed by the same (entity_type, entity_id, sport) tuple used by
+// notifications.GetFollowers.
+type Hub struct {
+	mu     sync.Mutex
+	subs   map[string]map[*Conn]struct{}
+	logger *slog.Logger
+}
+
+// NewHub creates an empty Hub.
+func NewHub(logger *slog.Logger) *Hub {
+	return &Hub{subs: make(map[string]map[*Conn]struct{}), logger: logger}
+}
+
+// message is the envelope written to every subscribed connection.
+type message struct {
+	Type string `json:"type"`
+	Data any    `json:"data"`
+}
+
+func entityKey(entityType string, entityID int, sport string) string {
+	return fmt.Sprintf("%s:%d:%s", entityType, entityID, sport)
+}
+
+// subscribe adds conn as a listener for (entityType, entityID, sport).
+func (h *Hub) subscribe(conn *Conn, entityType string, entityID int, sport string) {
+	key := entityKey(entityType, entityID, sport)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	set, ok := h.subs[key]
+	if !ok {
+		set = make(map[*Conn]struct{})
+		h.subs[key] = set
+	}
+	set[conn] = struct{}{}
+}
+
+// unsubscribeAll removes conn from every entity it subscribed to, called
+// once its read pump exits.
+func (h *Hub) unsubscribeAll(conn *Conn) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for key, set := range h.subs {
+		if _, ok := set[conn]; ok {
+			delete(set, conn)
+			if len(set) == 0 {
+				delete(h.subs, key)
+			}
+		}
+	}
+}
+
+// broadcast sends payload to every connection subscribed to
+// (entityType, entityID, sport). Non-blocking per connection: one slow
+// client can't stall delivery to the others.
+func (h *Hub) broadcast(entityType string, entityID int, sport string, payload []byte) {
+	key := entityKey(entityType, entityID, sport)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for conn := range h.subs[key] {
+		select {
+		case conn.send <- payload:
+		default:
+			h.logger.Warn("ws subscriber mailbox full, dropping notification", "entity_key", key)
+		}
+	}
+}
+
+// HandleMilestone is a listener.Bus handler (registered via RegisterCodec)
+// that broadcasts event to every connection subscribed to its entity.
+// Errors are returned for logging by the bus, same as handleMilestone's
+// push path — there's nothing to retry against, Postgres doesn't replay
+// NOTIFY payloads.
+func (h *Hub) HandleMilestone(ctx context.Context, event listener.MilestoneEvent) error {
+	payload, err := json.Marshal(message{Type: "milestone_reached", Data: event})
+	if err != nil {
+		return fmt.Errorf("marshal milestone message: %w", err)
+	}
+	h.broadcast(event.EntityType, event.EntityID, event.Sport, payload)
+	return nil
+}
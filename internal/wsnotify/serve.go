@@ -0,0 +1,39 @@
+package wsnotify
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/gorilla/websocket"
+)
+
+// upgrader is shared across connections; CheckOrigin is left permissive
+// (same as the rest of the public API — see api.NewRouter's CORS setup)
+// since this endpoint, like the REST routes, has no per-client auth to
+// gate access on origin alone.
+var upgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+	CheckOrigin:     func(r *http.Request) bool { return true },
+}
+
+// ServeWS upgrades r to a WebSocket connection registered under userID and
+// blocks, running its read/write pumps, until the client disconnects or ctx
+// is cancelled. userID is taken as given by the caller — there's no
+// authentication middleware in internal/api yet, so this is a known gap
+// rather than a real identity check; it exists only to let the caller
+// eventually key per-user delivery the same way notifications.GetFollowers
+// does today.
+func (h *Hub) ServeWS(ctx context.Context, w http.ResponseWriter, r *http.Request, userID string, logger *slog.Logger) error {
+	ws, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return err
+	}
+
+	conn := &Conn{ws: ws, hub: h, userID: userID, send: make(chan []byte, connBuffer), logger: logger}
+
+	go conn.writePump(ctx)
+	conn.readPump()
+	return nil
+}